@@ -1,40 +1,77 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"time"
+	_ "time/tzdata" // lifecycle.timezone must resolve in the alpine image
 
 	// "os"
 
+	lifecycle_service "term-service/internal/lifecycle/service"
+	webhook_service "term-service/internal/webhook/service"
 	"term-service/pkg/config"
 	"term-service/pkg/consul"
 	"term-service/pkg/db"
+	"term-service/pkg/db/migration"
+	"term-service/pkg/health"
+	"term-service/pkg/outbox"
+	"term-service/pkg/outbox/dispatch"
+	"term-service/pkg/ratelimit"
 	"term-service/pkg/router"
+	"term-service/pkg/servicetoken"
+	"term-service/pkg/tracing"
 
 	"term-service/pkg/zap"
 
 	consulapi "github.com/hashicorp/consul/api"
+	"github.com/redis/go-redis/v9"
 )
 
+// Usage:
+//
+//	api [config.yaml]          start the HTTP server
+//	api migrate [config.yaml]  apply pending database migrations and exit
 func main() {
-	filePath := os.Args[1]
-	if filePath == "" {
-		filePath = "configs/config.yaml"
+	args := os.Args[1:]
+	command := "serve"
+	if len(args) > 0 && (args[0] == "serve" || args[0] == "migrate") {
+		command = args[0]
+		args = args[1:]
+	}
+
+	filePath := "configs/config.yaml"
+	if len(args) > 0 && args[0] != "" {
+		filePath = args[0]
 	}
 
 	config.LoadConfig(filePath)
 
 	cfg := config.AppConfig
 
-	// logger.WriteLogData("info", map[string]any{"id": 123, "name": "Hung"})
-
 	//logger
 	logger, err := zap.New(cfg)
 	if err != nil {
 		log.Fatalf("Failed to initialize logger: %v", err)
 	}
+	zap.SetGlobal(logger)
+
+	//tracing
+	shutdownTracing, err := tracing.Init(cfg.Tracing)
+	if err != nil {
+		logger.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	if command == "migrate" {
+		// MySQL schema is migrated by ConnectMySQL itself.
+		if _, err := connectDatabase(cfg, logger, true); err != nil {
+			logger.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
 
 	//consul
 	consulConn := consul.NewConsulConn(logger, cfg)
@@ -46,15 +83,245 @@ func main() {
 	}
 
 	//db
-	db.ConnectMongoDB()
+	repos, err := connectDatabase(cfg, logger, cfg.Database.Mongo.AutoMigrate)
+	if err != nil {
+		logger.Fatalf("Migration failed: %v", err)
+	}
+
+	//health
+	healthChecker := health.NewChecker(5*time.Second, 2*time.Second)
+	if cfg.Database.Active == "mysql" {
+		healthChecker.Register("mysql", health.SQLCheck(db.MySqlDB))
+	} else {
+		healthChecker.Register("mongodb", health.MongoCheck(db.MongoClient))
+	}
+	healthChecker.Register("consul", health.ConsulCheck(consulClient))
+	healthChecker.Register("go-main-service", health.ServiceCheck(consulClient, "go-main-service"))
+	consulConn.SetReadiness(healthChecker)
+
+	//service auth
+	if cfg.Service.Secret == "" {
+		logger.Warnw("service_auth.secret is not set; /api/v1/gateway routes will reject every caller")
+	}
+	signer := servicetoken.NewSigner(cfg.Service.Secret, cfg.Service.Issuer, cfg.Service.TokenTTL)
+	verifier := servicetoken.NewVerifier(cfg.Service.Secret, cfg.Service.AllowedCallers)
+
+	//rate limit
+	rateLimitStore, closeRateLimitStore := newRateLimitStore(cfg.Limits, logger)
+	defer closeRateLimitStore()
+
+	//domain events
+	stopEvents := startEventDispatcher(cfg.Events, repos.Outbox, logger)
+	defer stopEvents()
 
-	r := router.SetupRouter(db.TermCollection, db.HolidayCollection, consulClient)
+	//webhooks
+	webhookDestinations := webhook_service.Destinations{AllowInsecure: cfg.Webhooks.AllowInsecureURLs}
+	webhookSender := webhook_service.NewSender(cfg.Webhooks.Timeout, webhookDestinations)
+	stopWebhooks := startWebhookWorker(cfg.Webhooks, repos, webhookSender, logger)
+	defer stopWebhooks()
+
+	gateways := router.NewGateways(consulClient, signer)
+
+	//lifecycle notifications
+	stopLifecycle := startLifecycleScheduler(cfg.Lifecycle, repos, gateways, logger)
+	defer stopLifecycle()
+
+	r := router.SetupRouter(router.Dependencies{
+		Repositories:   repos,
+		Gateways:       gateways,
+		Health:         healthChecker,
+		ServiceAuth:    verifier,
+		TrustedProxies: cfg.Server.TrustedProxies,
+		RateLimitStore: rateLimitStore,
+		Limits:         rateLimits(cfg.Limits),
+		IdempotencyTTL: cfg.Idem.TTL,
+
+		WebhookSender:       webhookSender,
+		WebhookDestinations: webhookDestinations,
+	})
 	port := cfg.Server.Port
 	if err := r.Run(":" + port); err != nil {
 		log.Fatal("Failed to run server:", err)
 	}
 }
 
+// newRateLimitStore returns the store selected by rate_limit.store, or nil
+// when rate limiting is disabled, and a func releasing it.
+func newRateLimitStore(cfg config.RateLimitConfig, logger zap.Logger) (ratelimit.Store, func()) {
+	if !cfg.Enabled {
+		logger.Warnw("rate limiting is disabled")
+		return nil, func() {}
+	}
+	if cfg.Store != "redis" {
+		return ratelimit.NewMemoryStore(), func() {}
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Redis.Addr,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+	return ratelimit.NewRedisStore(client, "term-service:ratelimit:"), func() {
+		if err := client.Close(); err != nil {
+			logger.Warnw("close redis client", "error", err)
+		}
+	}
+}
+
+// startEventDispatcher delivers outbox events to the sink selected by
+// events.sink and returns a func stopping it. Events are still recorded
+// while it is disabled and go out once it is enabled.
+func startEventDispatcher(cfg config.EventsConfig, store outbox.Store, logger zap.Logger) func() {
+	if !cfg.Enabled {
+		logger.Warnw("event delivery is disabled; events stay in the outbox")
+		return func() {}
+	}
+
+	sink, err := newEventSink(cfg)
+	if err != nil {
+		logger.Fatalf("Failed to initialize event sink: %v", err)
+	}
+
+	d := dispatch.New(store, sink, dispatch.Config{
+		Interval:   cfg.Dispatch.Interval,
+		BatchSize:  cfg.Dispatch.BatchSize,
+		Lease:      cfg.Dispatch.Lease,
+		MaxBackoff: cfg.Dispatch.MaxBackoff,
+		Retention:  cfg.Dispatch.Retention,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		d.Run(ctx)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+		if err := sink.Close(); err != nil {
+			logger.Warnw("close event sink", "error", err)
+		}
+	}
+}
+
+// startWebhookWorker sends queued webhook deliveries until the returned
+// func is called.
+func startWebhookWorker(cfg config.WebhookConfig, repos router.Repositories, sender webhook_service.Sender, logger zap.Logger) func() {
+	if !cfg.Enabled {
+		logger.Warnw("webhook delivery is disabled; deliveries stay queued")
+		return func() {}
+	}
+
+	w := webhook_service.NewWorker(repos.WebhookSubscriptions, repos.WebhookDeliveries, sender, webhook_service.WorkerConfig{
+		Interval:    cfg.Interval,
+		BatchSize:   cfg.BatchSize,
+		Lease:       cfg.Lease,
+		MaxAttempts: cfg.MaxAttempts,
+		MinBackoff:  cfg.MinBackoff,
+		MaxBackoff:  cfg.MaxBackoff,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.Run(ctx)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// startLifecycleScheduler notifies term and holiday transitions until the
+// returned func is called.
+func startLifecycleScheduler(cfg config.LifecycleConfig, repos router.Repositories, gateways router.Gateways, logger zap.Logger) func() {
+	if !cfg.Enabled {
+		logger.Warnw("lifecycle notifications are disabled")
+		return func() {}
+	}
+
+	loc, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		logger.Fatalf("Invalid lifecycle timezone: %v", err)
+	}
+
+	notifier := lifecycle_service.NewEventNotifier(webhook_service.NewFanoutRecorder(repos.Outbox, repos.WebhookSubscriptions, repos.WebhookDeliveries))
+
+	s := lifecycle_service.NewScheduler(gateways.Organization, repos.Term, repos.Holiday, repos.LifecycleFired, repos.Transactor, notifier, lifecycle_service.Config{
+		Interval:          cfg.Interval,
+		Location:          loc,
+		TermLeadDays:      cfg.TermLeadDays,
+		HolidayLeadDays:   cfg.HolidayLeadDays,
+		EndedLookbackDays: cfg.EndedLookbackDays,
+		Retention:         cfg.Retention,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Run(ctx)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func newEventSink(cfg config.EventsConfig) (outbox.Sink, error) {
+	switch cfg.Sink {
+	case "kafka":
+		return outbox.NewKafkaSink(cfg.Kafka.Brokers, cfg.Kafka.Topic), nil
+	case "nats":
+		return outbox.NewNATSSink(cfg.NATS.URL, cfg.NATS.SubjectPrefix)
+	case "webhook":
+		return outbox.NewWebhookSink(cfg.Webhook.URL, cfg.Webhook.Token, cfg.Webhook.Timeout), nil
+	case "memory":
+		return outbox.NewMemorySink(), nil
+	default:
+		return outbox.NewFileSink(cfg.File.Path)
+	}
+}
+
+func rateLimits(cfg config.RateLimitConfig) ratelimit.Limits {
+	return ratelimit.Limits{
+		PerIP:           ratelimit.Rule{Requests: cfg.PerIP.Requests, Window: cfg.PerIP.Window},
+		PerUser:         ratelimit.Rule{Requests: cfg.PerUser.Requests, Window: cfg.PerUser.Window},
+		PerOrganization: ratelimit.Rule{Requests: cfg.PerOrganization.Requests, Window: cfg.PerOrganization.Window},
+		MaxBodyBytes:    cfg.MaxBodyBytes,
+		MaxUploadItems:  cfg.MaxUploadItems,
+	}
+}
+
+// connectDatabase opens the backend selected by database.active and returns
+// its repositories, applying Mongo migrations first when migrate is set.
+func connectDatabase(cfg *config.AppConfigStruct, logger zap.Logger, migrate bool) (router.Repositories, error) {
+	if cfg.Database.Active == "mysql" {
+		db.ConnectMySQL()
+		return router.NewGormRepositories(db.MySqlDB), nil
+	}
+
+	db.ConnectMongoDB()
+	if migrate {
+		if err := runMigrations(logger); err != nil {
+			return router.Repositories{}, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return router.NewMongoRepositories(ctx, db.MongoDatabase, cfg.Database.Mongo.AllowNonTransactional)
+}
+
+func runMigrations(logger zap.Logger) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	return migration.NewRunner(db.MongoDatabase, migration.All, logger).Run(ctx)
+}
+
 func waitPassing(cli *consulapi.Client, name string, timeout time.Duration) error {
 	dl := time.Now().Add(timeout)
 	for time.Now().Before(dl) {
@@ -0,0 +1,12 @@
+package request
+
+type DateLookup struct {
+	// OrganizationID defaults to the request's organization_id.
+	OrganizationID string `json:"organization_id"`
+	Date           string `json:"date" binding:"required"` // YYYY-MM-DD
+}
+
+type ResolveDatesRequest struct {
+	OrganizationID string       `json:"organization_id"`
+	Lookups        []DateLookup `json:"lookups" binding:"required,min=1,max=1000,dive"`
+}
@@ -0,0 +1,31 @@
+package response
+
+import term_response "term-service/internal/term/dto/response"
+
+// Date statuses. A holiday takes precedence over a break, and both over
+// plain term time.
+const (
+	DateInTerm       = "in_term"
+	DateBreak        = "break"
+	DateHoliday      = "holiday"
+	DateBetweenTerms = "between_terms"
+	DateNoTerm       = "no_term" // before the first or after the last term
+)
+
+type DateResDTO struct {
+	OrganizationID string `json:"organization_id"`
+	Date           string `json:"date"`
+	Status         string `json:"status"`
+	// Term contains the date. Between terms, PreviousTerm ended before it
+	// and NextTerm starts after it.
+	Term         *term_response.Term4GwResponse `json:"term,omitempty"`
+	Week         int                            `json:"week,omitempty"`
+	PreviousTerm *term_response.Term4GwResponse `json:"previous_term,omitempty"`
+	NextTerm     *term_response.Term4GwResponse `json:"next_term,omitempty"`
+	Holidays     []HolidayRefResDTO             `json:"holidays"`
+	Periods      []term_response.PeriodResDTO   `json:"periods"`
+}
+
+type ResolveDatesResDTO struct {
+	Dates []DateResDTO `json:"dates"` // in the order of the lookups
+}
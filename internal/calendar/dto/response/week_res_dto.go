@@ -0,0 +1,28 @@
+package response
+
+import term_response "term-service/internal/term/dto/response"
+
+type TermWeeksResDTO struct {
+	TermID    string       `json:"term_id"`
+	Title     string       `json:"title"`
+	StartDate string       `json:"start_date"`
+	EndDate   string       `json:"end_date"`
+	Weeks     []WeekResDTO `json:"weeks"`
+}
+
+type WeekResDTO struct {
+	Number            int                          `json:"number"` // 0 for weeks within breaks
+	StartDate         string                       `json:"start_date"`
+	EndDate           string                       `json:"end_date"`
+	InstructionalDays int                          `json:"instructional_days"`
+	Holidays          []HolidayRefResDTO           `json:"holidays"`
+	Periods           []term_response.PeriodResDTO `json:"periods"`
+}
+
+type HolidayRefResDTO struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Color     string `json:"color"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
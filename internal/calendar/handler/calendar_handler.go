@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"term-service/internal/calendar/dto/request"
+	"term-service/internal/calendar/service"
+	"term-service/pkg/db"
+	"term-service/pkg/helper"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CalendarHandler struct {
+	service service.CalendarService
+}
+
+func NewHandler(s service.CalendarService) *CalendarHandler {
+	return &CalendarHandler{service: s}
+}
+
+func (h *CalendarHandler) GetTermWeeks(c *gin.Context) {
+	res, err := h.service.GetTermWeeks(c.Request.Context(), c.Param("term_id"), c.Query("channel"))
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Success", res)
+}
+
+func (h *CalendarHandler) ResolveDate(c *gin.Context) {
+	res, err := h.service.ResolveDate(c.Request.Context(), c.Query("organization_id"), c.Param("date"))
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Success", res)
+}
+
+func (h *CalendarHandler) ResolveDates(c *gin.Context) {
+	var req request.ResolveDatesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	res, err := h.service.ResolveDates(c.Request.Context(), req)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Success", res)
+}
+
+func sendError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		helper.SendError(c, http.StatusNotFound, err, helper.ErrNotFount)
+	case errors.Is(err, service.ErrInvalidLookup), errors.Is(err, service.ErrUnknownChannel):
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+	default:
+		helper.SendServiceError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+	}
+}
@@ -0,0 +1,25 @@
+package mapper
+
+import (
+	"term-service/internal/calendar/dto/response"
+	holiday_model "term-service/internal/holiday/model"
+	"term-service/pkg/helper"
+)
+
+func MapHolidayToRefDTO(h *holiday_model.Holiday) response.HolidayRefResDTO {
+	return response.HolidayRefResDTO{
+		ID:        h.ID.Hex(),
+		Title:     h.Title,
+		Color:     h.Color,
+		StartDate: helper.FormatDate(h.StartDate),
+		EndDate:   helper.FormatDate(h.EndDate),
+	}
+}
+
+func MapHolidaysToRefDTO(holidays []*holiday_model.Holiday) []response.HolidayRefResDTO {
+	res := make([]response.HolidayRefResDTO, 0, len(holidays))
+	for _, h := range holidays {
+		res = append(res, MapHolidayToRefDTO(h))
+	}
+	return res
+}
@@ -0,0 +1,33 @@
+package route
+
+import (
+	"term-service/internal/calendar/handler"
+	"term-service/internal/term/middleware"
+	"term-service/pkg/servicetoken"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterCalendarRoutes registers reads that combine terms and holidays.
+// The week schedule authorizes against the term's organization itself, as
+// it is only known once the term is loaded; terms of other organizations
+// are not found.
+func RegisterCalendarRoutes(r *gin.Engine, h *handler.CalendarHandler, serviceAuth servicetoken.Verifier) {
+	// User routes
+	userGroup := r.Group("/api/v1")
+	userGroup.Use(middleware.Secured())
+	{
+		userGroup.GET("/terms/:term_id/weeks", h.GetTermWeeks)
+	}
+
+	// gw routes: internal services only, authenticated by service token
+	gatewayGroup := r.Group("/api/v1/gateway")
+	gatewayGroup.Use(middleware.ServiceAuth(serviceAuth))
+	{
+		datesGateway := gatewayGroup.Group("/calendar/dates")
+		{
+			datesGateway.GET("/:date", h.ResolveDate)
+			datesGateway.POST("", h.ResolveDates)
+		}
+	}
+}
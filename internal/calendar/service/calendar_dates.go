@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"term-service/internal/calendar/dto/request"
+	"term-service/internal/calendar/dto/response"
+	"term-service/internal/calendar/mapper"
+	holiday_model "term-service/internal/holiday/model"
+	term_response "term-service/internal/term/dto/response"
+	term_mappers "term-service/internal/term/mappers"
+	"term-service/internal/term/model"
+	"term-service/pkg/helper"
+	"time"
+)
+
+// ErrInvalidLookup marks a date lookup without an organization or with a
+// malformed date.
+var ErrInvalidLookup = errors.New("invalid date lookup")
+
+func (s *calendarService) ResolveDate(ctx context.Context, organizationID, date string) (*response.DateResDTO, error) {
+	res, err := s.ResolveDates(ctx, request.ResolveDatesRequest{
+		Lookups: []request.DateLookup{{OrganizationID: organizationID, Date: date}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &res.Dates[0], nil
+}
+
+func (s *calendarService) ResolveDates(ctx context.Context, req request.ResolveDatesRequest) (*response.ResolveDatesResDTO, error) {
+	calendars := make(map[string]*orgCalendar)
+	res := &response.ResolveDatesResDTO{Dates: make([]response.DateResDTO, 0, len(req.Lookups))}
+	for i, l := range req.Lookups {
+		orgID := l.OrganizationID
+		if orgID == "" {
+			orgID = req.OrganizationID
+		}
+		if orgID == "" {
+			return nil, fmt.Errorf("lookup %d: missing organization_id: %w", i, ErrInvalidLookup)
+		}
+		date, err := time.Parse("2006-01-02", l.Date)
+		if err != nil {
+			return nil, fmt.Errorf("lookup %d: date %q is not YYYY-MM-DD: %w", i, l.Date, ErrInvalidLookup)
+		}
+
+		cal, ok := calendars[orgID]
+		if !ok {
+			if cal, err = s.loadCalendar(ctx, orgID); err != nil {
+				return nil, err
+			}
+			calendars[orgID] = cal
+		}
+		res.Dates = append(res.Dates, cal.resolve(orgID, date))
+	}
+	return res, nil
+}
+
+// orgCalendar is what resolving dates needs to know about an organization.
+type orgCalendar struct {
+	terms    []*model.Term // by start date
+	holidays []*holiday_model.Holiday
+	word     string
+}
+
+func (s *calendarService) loadCalendar(ctx context.Context, orgID string) (*orgCalendar, error) {
+	terms, err := s.terms.GetAllByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("get terms by orgID %s failed: %w", orgID, err)
+	}
+	slices.SortStableFunc(terms, func(a, b *model.Term) int { return a.StartDate.Compare(b.StartDate) })
+
+	holidays, err := s.holidays.GetAllByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("get holidays by orgID %s failed: %w", orgID, err)
+	}
+
+	// get word by orgID
+	msg, _ := s.messageLanguageGateway.GetMessageLanguage(ctx, "term", orgID)
+	word := ""
+	if msg.Contents != nil {
+		if val, ok := msg.Contents["word"]; ok {
+			word = val
+		}
+	}
+
+	return &orgCalendar{terms: terms, holidays: holidays, word: word}, nil
+}
+
+func (c *orgCalendar) resolve(orgID string, date time.Time) response.DateResDTO {
+	res := response.DateResDTO{
+		OrganizationID: orgID,
+		Date:           helper.FormatDate(date),
+		Periods:        []term_response.PeriodResDTO{},
+	}
+
+	var holidays []*holiday_model.Holiday
+	for _, h := range c.holidays {
+		if overlaps(h.StartDate, h.EndDate, date, date) {
+			holidays = append(holidays, h)
+		}
+	}
+	res.Holidays = mapper.MapHolidaysToRefDTO(holidays)
+
+	var term, previous, next *model.Term
+	for _, t := range c.terms {
+		switch {
+		case overlaps(t.StartDate, t.EndDate, date, date):
+			if term == nil {
+				term = t
+			}
+		case t.EndDate.Before(date):
+			if previous == nil || t.EndDate.After(previous.EndDate) {
+				previous = t
+			}
+		case next == nil:
+			next = t
+		}
+	}
+
+	switch {
+	case term != nil:
+		res.Status = response.DateInTerm
+		if term.Periods.InBreak(date) {
+			res.Status = response.DateBreak
+		}
+		res.Term = term_mappers.MapTermToRes4GwResponse(term, c.word)
+		res.Week = weekOf(term, date)
+		var periods model.Periods
+		for _, p := range term.Periods {
+			if p.Contains(date) {
+				periods = append(periods, p)
+			}
+		}
+		res.Periods = term_mappers.MapPeriodsToResDTO(periods)
+	case previous != nil && next != nil:
+		res.Status = response.DateBetweenTerms
+	default:
+		res.Status = response.DateNoTerm
+	}
+	if term == nil {
+		if previous != nil {
+			res.PreviousTerm = term_mappers.MapTermToRes4GwResponse(previous, c.word)
+		}
+		if next != nil {
+			res.NextTerm = term_mappers.MapTermToRes4GwResponse(next, c.word)
+		}
+	}
+	if len(holidays) > 0 {
+		res.Status = response.DateHoliday
+	}
+	return res
+}
+
+// weekOf returns the number of term's week containing date.
+func weekOf(term *model.Term, date time.Time) int {
+	for _, w := range term.Weeks() {
+		if overlaps(w.StartDate, w.EndDate, date, date) {
+			return w.Number
+		}
+	}
+	return 0
+}
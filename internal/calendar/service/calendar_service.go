@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"term-service/internal/calendar/dto/request"
+	"term-service/internal/calendar/dto/response"
+	"term-service/internal/gateway"
+	holiday_model "term-service/internal/holiday/model"
+	holiday_repo "term-service/internal/holiday/repository"
+	"term-service/internal/policy"
+	term_repo "term-service/internal/term/repository"
+	"term-service/pkg/db"
+	"term-service/pkg/helper"
+	"term-service/pkg/publish"
+	"time"
+)
+
+// ErrUnknownChannel marks a week schedule asked for on a channel that does
+// not exist.
+var ErrUnknownChannel = errors.New("unknown channel")
+
+// CalendarService answers questions that need an organization's terms and
+// holidays together. It only reads.
+type CalendarService interface {
+	// GetTermWeeks lists every week of a term with its instructional days
+	// and the holidays and periods overlapping it, as published on channel
+	// (desktop when empty). Organization admins see unpublished terms and
+	// holidays too.
+	GetTermWeeks(ctx context.Context, termID, channel string) (*response.TermWeeksResDTO, error)
+	// ResolveDate places a date (YYYY-MM-DD) of an organization in its
+	// calendar: the term containing it, or the terms around it, and the
+	// holidays and periods on it.
+	ResolveDate(ctx context.Context, organizationID, date string) (*response.DateResDTO, error)
+	// ResolveDates resolves a batch of dates, possibly of several
+	// organizations, answering in the order of the lookups.
+	ResolveDates(ctx context.Context, req request.ResolveDatesRequest) (*response.ResolveDatesResDTO, error)
+}
+
+type calendarService struct {
+	terms                  term_repo.TermRepository
+	holidays               holiday_repo.HolidayRepository
+	userGateway            gateway.UserGateway
+	messageLanguageGateway gateway.MessageLanguageGateway
+}
+
+func NewCalendarService(terms term_repo.TermRepository, holidays holiday_repo.HolidayRepository, userGateway gateway.UserGateway, messageLanguageGateway gateway.MessageLanguageGateway) CalendarService {
+	return &calendarService{
+		terms:                  terms,
+		holidays:               holidays,
+		userGateway:            userGateway,
+		messageLanguageGateway: messageLanguageGateway,
+	}
+}
+
+func (s *calendarService) GetTermWeeks(ctx context.Context, termID, channel string) (*response.TermWeeksResDTO, error) {
+	if channel == "" {
+		channel = publish.Desktop
+	}
+	if !slices.Contains(publish.Channels, channel) {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownChannel, channel)
+	}
+
+	currentUser, err := s.userGateway.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get current user info failed: %w", policy.ErrUnauthenticated)
+	}
+
+	term, err := s.terms.GetByID(ctx, termID)
+	if err != nil {
+		return nil, fmt.Errorf("get term by id failed: %w", err)
+	}
+	// Terms the caller may not see are reported as not found, so their
+	// existence is not revealed to other organizations.
+	if err := policy.Authorize(currentUser, policy.TermRead, term.OrganizationID); err != nil {
+		return nil, fmt.Errorf("term %s: %w", termID, db.ErrNotFound)
+	}
+	admin := policy.Authorize(currentUser, policy.TermWrite, term.OrganizationID) == nil
+	if !admin && !term.VisibleOn(channel, time.Now()) {
+		return nil, fmt.Errorf("term %s on %s: %w", termID, channel, db.ErrNotFound)
+	}
+
+	var holidays []*holiday_model.Holiday
+	if admin {
+		holidays, err = s.holidays.GetAllByOrgID(ctx, term.OrganizationID)
+	} else {
+		holidays, err = s.holidays.GetAllVisibleByOrgID(ctx, term.OrganizationID, channel)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get holidays by orgID failed: %w", err)
+	}
+
+	// get word by orgID
+	msg, _ := s.messageLanguageGateway.GetMessageLanguage(ctx, "term", term.OrganizationID)
+	word := ""
+	if msg.Contents != nil {
+		if val, ok := msg.Contents["word"]; ok {
+			word = val
+		}
+	}
+
+	return &response.TermWeeksResDTO{
+		TermID:    term.ID.Hex(),
+		Title:     word + " " + term.Title,
+		StartDate: helper.FormatDate(term.StartDate),
+		EndDate:   helper.FormatDate(term.EndDate),
+		Weeks:     termWeeks(term, holidays),
+	}, nil
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"term-service/internal/calendar/dto/response"
+	"term-service/internal/calendar/mapper"
+	holiday_model "term-service/internal/holiday/model"
+	term_mappers "term-service/internal/term/mappers"
+	"term-service/internal/term/model"
+	"term-service/pkg/helper"
+	"time"
+)
+
+// termWeeks describes the weeks of term. A day is instructional when it is
+// a weekday outside every break and holiday.
+func termWeeks(term *model.Term, holidays []*holiday_model.Holiday) []response.WeekResDTO {
+	weeks := term.Weeks()
+	res := make([]response.WeekResDTO, 0, len(weeks))
+	for _, w := range weeks {
+		var overlapping []*holiday_model.Holiday
+		for _, h := range holidays {
+			if overlaps(h.StartDate, h.EndDate, w.StartDate, w.EndDate) {
+				overlapping = append(overlapping, h)
+			}
+		}
+		var periods model.Periods
+		for _, p := range term.Periods {
+			if overlaps(p.StartDate, p.EndDate, w.StartDate, w.EndDate) {
+				periods = append(periods, p)
+			}
+		}
+
+		days := 0
+		for day := w.StartDate; !day.After(w.EndDate); day = day.AddDate(0, 0, 1) {
+			if isInstructional(day, term.Periods, overlapping) {
+				days++
+			}
+		}
+
+		res = append(res, response.WeekResDTO{
+			Number:            w.Number,
+			StartDate:         helper.FormatDate(w.StartDate),
+			EndDate:           helper.FormatDate(w.EndDate),
+			InstructionalDays: days,
+			Holidays:          mapper.MapHolidaysToRefDTO(overlapping),
+			Periods:           term_mappers.MapPeriodsToResDTO(periods),
+		})
+	}
+	return res
+}
+
+func isInstructional(day time.Time, periods model.Periods, holidays []*holiday_model.Holiday) bool {
+	if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday || periods.InBreak(day) {
+		return false
+	}
+	for _, h := range holidays {
+		if overlaps(h.StartDate, h.EndDate, day, day) {
+			return false
+		}
+	}
+	return true
+}
+
+// overlaps reports whether the inclusive ranges [aStart, aEnd] and
+// [bStart, bEnd] share a day.
+func overlaps(aStart, aEnd, bStart, bEnd time.Time) bool {
+	return !aStart.After(bEnd) && !aEnd.Before(bStart)
+}
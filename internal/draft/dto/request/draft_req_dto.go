@@ -0,0 +1,30 @@
+package request
+
+import (
+	holiday_request "term-service/internal/holiday/dto/request"
+	term_request "term-service/internal/term/dto/request"
+)
+
+// SaveDraftRequest is the whole proposed term and holiday set. Items use the
+// upload formats; an item with an id replaces that live record and live
+// records left out are removed on approval. Item versions are ignored:
+// approval checks the calendar against what it was when the draft was saved.
+type SaveDraftRequest struct {
+	// OrganizationID lets a super admin draft on behalf of an organization.
+	OrganizationID string                              `json:"organization_id,omitempty"`
+	LanguageID     uint                                `json:"language_id" binding:"required"`
+	Word           string                              `json:"word" binding:"required"`
+	Terms          []term_request.UploadTermItem       `json:"terms"`
+	Holidays       []holiday_request.UploadHolidayItem `json:"holidays"`
+
+	// Version is the draft version an update is based on; required when
+	// updating unless the request sends If-Match.
+	Version int64 `json:"version,omitempty"`
+
+	// IfMatchVersion comes from the If-Match header.
+	IfMatchVersion int64 `json:"-"`
+}
+
+type ReviewDraftRequest struct {
+	Comment string `json:"comment"`
+}
@@ -0,0 +1,59 @@
+package response
+
+import (
+	term_response "term-service/internal/term/dto/response"
+	"term-service/pkg/publish"
+	"time"
+)
+
+type DraftResDTO struct {
+	ID             string       `json:"id"`
+	OrganizationID string       `json:"organization_id"`
+	Status         string       `json:"status"`
+	LanguageID     uint         `json:"language_id"`
+	Word           string       `json:"word"`
+	Terms          []ItemResDTO `json:"terms"`
+	Holidays       []ItemResDTO `json:"holidays"`
+	AuthorID       string       `json:"author_id"`
+	SubmittedAt    *time.Time   `json:"submitted_at,omitempty"`
+	ReviewerID     string       `json:"reviewer_id,omitempty"`
+	ReviewedAt     *time.Time   `json:"reviewed_at,omitempty"`
+	ReviewComment  string       `json:"review_comment,omitempty"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+	Version        int64        `json:"version"` // send back when updating
+}
+
+// ItemResDTO is a term or holiday of a draft or of the live calendar.
+type ItemResDTO struct {
+	ID        string            `json:"id,omitempty"`
+	Title     string            `json:"title"`
+	Color     string            `json:"color"`
+	Audiences publish.Audiences `json:"audiences"`
+	StartDate string            `json:"start_date"`
+	EndDate   string            `json:"end_date"`
+	// Periods are a term's sub-periods; left out for holidays.
+	Periods []term_response.PeriodResDTO `json:"periods,omitempty"`
+}
+
+// DiffResDTO compares a draft with the live calendar. Stale is set when the
+// live calendar changed after the draft was saved; such a draft cannot be
+// approved until it is saved again.
+type DiffResDTO struct {
+	Terms    SetDiffResDTO `json:"terms"`
+	Holidays SetDiffResDTO `json:"holidays"`
+	Stale    bool          `json:"stale"`
+}
+
+type SetDiffResDTO struct {
+	Added   []ItemResDTO   `json:"added"`
+	Changed []ChangeResDTO `json:"changed"`
+	Removed []ItemResDTO   `json:"removed"`
+}
+
+type ChangeResDTO struct {
+	ID     string     `json:"id"`
+	Fields []string   `json:"fields"` // json names of the fields that differ
+	Before ItemResDTO `json:"before"`
+	After  ItemResDTO `json:"after"`
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"term-service/internal/draft/dto/request"
+	"term-service/internal/draft/service"
+	"term-service/pkg/constants"
+	"term-service/pkg/db"
+	"term-service/pkg/helper"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DraftHandler struct {
+	service service.DraftService
+}
+
+func NewHandler(s service.DraftService) *DraftHandler {
+	return &DraftHandler{service: s}
+}
+
+// organizationID is the organization authorization resolved for the request.
+func organizationID(c *gin.Context) string {
+	return c.GetString(constants.TargetOrgID.String())
+}
+
+func (h *DraftHandler) CreateDraft(c *gin.Context) {
+	var req request.SaveDraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	res, err := h.service.CreateDraft(c.Request.Context(), organizationID(c), req)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusCreated, "Success", res)
+}
+
+func (h *DraftHandler) GetDrafts(c *gin.Context) {
+	res, err := h.service.GetDrafts(c.Request.Context(), organizationID(c), c.Query("status"))
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Success", res)
+}
+
+func (h *DraftHandler) GetDraft(c *gin.Context) {
+	res, err := h.service.GetDraft(c.Request.Context(), organizationID(c), c.Param("id"))
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Success", res)
+}
+
+func (h *DraftHandler) GetDraftDiff(c *gin.Context) {
+	res, err := h.service.GetDraftDiff(c.Request.Context(), organizationID(c), c.Param("id"))
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Success", res)
+}
+
+func (h *DraftHandler) UpdateDraft(c *gin.Context) {
+	var req request.SaveDraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+	ifMatch, err := helper.IfMatchVersion(c)
+	if err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+	req.IfMatchVersion = ifMatch
+
+	res, err := h.service.UpdateDraft(c.Request.Context(), organizationID(c), c.Param("id"), req)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Success", res)
+}
+
+func (h *DraftHandler) SubmitDraft(c *gin.Context) {
+	res, err := h.service.SubmitDraft(c.Request.Context(), organizationID(c), c.Param("id"))
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Success", res)
+}
+
+func (h *DraftHandler) ApproveDraft(c *gin.Context) {
+	req, ok := bindReview(c)
+	if !ok {
+		return
+	}
+	res, err := h.service.ApproveDraft(c.Request.Context(), organizationID(c), c.Param("id"), req)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Success", res)
+}
+
+func (h *DraftHandler) RejectDraft(c *gin.Context) {
+	req, ok := bindReview(c)
+	if !ok {
+		return
+	}
+	res, err := h.service.RejectDraft(c.Request.Context(), organizationID(c), c.Param("id"), req)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Success", res)
+}
+
+// bindReview reads the optional review comment; an empty body is allowed.
+func bindReview(c *gin.Context) (request.ReviewDraftRequest, bool) {
+	var req request.ReviewDraftRequest
+	if c.Request.ContentLength == 0 {
+		return req, true
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return req, false
+	}
+	return req, true
+}
+
+func sendError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		helper.SendError(c, http.StatusNotFound, err, helper.ErrNotFount)
+	case errors.Is(err, service.ErrInvalidDraft):
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+	case errors.Is(err, service.ErrInvalidState):
+		helper.SendError(c, http.StatusConflict, err, helper.ErrConflict)
+	default:
+		helper.SendServiceError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+	}
+}
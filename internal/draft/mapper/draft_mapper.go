@@ -0,0 +1,129 @@
+package mapper
+
+import (
+	"term-service/internal/draft/dto/response"
+	"term-service/internal/draft/model"
+	holiday_request "term-service/internal/holiday/dto/request"
+	holiday_model "term-service/internal/holiday/model"
+	term_request "term-service/internal/term/dto/request"
+	term_mappers "term-service/internal/term/mappers"
+	term_model "term-service/internal/term/model"
+	"term-service/pkg/publish"
+)
+
+// dateLayout is the date format of term and holiday uploads.
+const dateLayout = "2006-01-02"
+
+func MapDraftToResDTO(d *model.Draft) response.DraftResDTO {
+	return response.DraftResDTO{
+		ID:             d.ID.Hex(),
+		OrganizationID: d.OrganizationID,
+		Status:         d.Status,
+		LanguageID:     d.LanguageID,
+		Word:           d.Word,
+		Terms:          MapItemListToResDTO(d.Terms),
+		Holidays:       MapItemListToResDTO(d.Holidays),
+		AuthorID:       d.AuthorID,
+		SubmittedAt:    d.SubmittedAt,
+		ReviewerID:     d.ReviewerID,
+		ReviewedAt:     d.ReviewedAt,
+		ReviewComment:  d.ReviewComment,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+		Version:        d.Version,
+	}
+}
+
+func MapDraftListToResDTO(drafts []*model.Draft) []response.DraftResDTO {
+	result := make([]response.DraftResDTO, 0, len(drafts))
+	for _, d := range drafts {
+		result = append(result, MapDraftToResDTO(d))
+	}
+	return result
+}
+
+func MapItemToResDTO(item model.Item) response.ItemResDTO {
+	audiences := item.Audiences
+	if audiences == nil {
+		audiences = publish.Audiences{}
+	}
+	return response.ItemResDTO{
+		ID:        item.ID,
+		Title:     item.Title,
+		Color:     item.Color,
+		Audiences: audiences,
+		StartDate: item.StartDate.Format(dateLayout),
+		EndDate:   item.EndDate.Format(dateLayout),
+		Periods:   term_mappers.MapPeriodsToResDTO(item.Periods),
+	}
+}
+
+func MapItemListToResDTO(items []model.Item) []response.ItemResDTO {
+	result := make([]response.ItemResDTO, 0, len(items))
+	for _, item := range items {
+		result = append(result, MapItemToResDTO(item))
+	}
+	return result
+}
+
+func MapTermToItem(t *term_model.Term) model.Item {
+	return model.Item{
+		ID:        t.ID.Hex(),
+		Title:     t.Title,
+		Color:     t.Color,
+		Audiences: t.Audiences,
+		StartDate: t.StartDate,
+		EndDate:   t.EndDate,
+		Periods:   t.Periods,
+	}
+}
+
+func MapHolidayToItem(h *holiday_model.Holiday) model.Item {
+	return model.Item{
+		ID:        h.ID.Hex(),
+		Title:     h.Title,
+		Color:     h.Color,
+		Audiences: h.Audiences,
+		StartDate: h.StartDate,
+		EndDate:   h.EndDate,
+	}
+}
+
+// MapItemToUploadTermItem builds the upload of an approved item; version is
+// the live version it replaces, or 0 for a new term. The item's periods
+// replace the term's.
+func MapItemToUploadTermItem(item model.Item, version int64) term_request.UploadTermItem {
+	periods := make([]term_request.UploadPeriodItem, 0, len(item.Periods))
+	for _, p := range item.Periods {
+		periods = append(periods, term_request.UploadPeriodItem{
+			ID:        p.ID,
+			Type:      p.Type,
+			Title:     p.Title,
+			StartDate: p.StartDate.Format(dateLayout),
+			EndDate:   p.EndDate.Format(dateLayout),
+		})
+	}
+	return term_request.UploadTermItem{
+		ID:        item.ID,
+		Title:     item.Title,
+		Color:     item.Color,
+		Audiences: item.Audiences,
+		StartDate: item.StartDate.Format(dateLayout),
+		EndDate:   item.EndDate.Format(dateLayout),
+		Periods:   periods,
+		Version:   version,
+	}
+}
+
+// MapItemToUploadHolidayItem is MapItemToUploadTermItem for holidays.
+func MapItemToUploadHolidayItem(item model.Item, version int64) holiday_request.UploadHolidayItem {
+	return holiday_request.UploadHolidayItem{
+		ID:        item.ID,
+		Title:     item.Title,
+		Color:     item.Color,
+		Audiences: item.Audiences,
+		StartDate: item.StartDate.Format(dateLayout),
+		EndDate:   item.EndDate.Format(dateLayout),
+		Version:   version,
+	}
+}
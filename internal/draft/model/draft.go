@@ -0,0 +1,74 @@
+package model
+
+import (
+	term_model "term-service/internal/term/model"
+	"term-service/pkg/objectid"
+	"term-service/pkg/publish"
+	"time"
+)
+
+// Draft states. A draft is edited while StatusDraft or StatusRejected,
+// frozen while StatusPending and final once StatusApproved.
+const (
+	StatusDraft    = "draft"
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+// Draft is a proposed version of an organization's whole term and holiday
+// set. Approving it applies the difference to the live calendar, which is
+// what every other endpoint reads.
+type Draft struct {
+	ID             objectid.ID `bson:"_id,omitempty" gorm:"primaryKey;size:24"`
+	OrganizationID string      `bson:"organization_id" gorm:"size:64;index:idx_calendar_drafts_org_created,priority:1"`
+	Status         string      `bson:"status" gorm:"size:16"`
+	LanguageID     uint        `bson:"language_id"`
+	Word           string      `bson:"word"` // term word, as in term uploads
+	Terms          []Item      `bson:"terms" gorm:"serializer:json"`
+	Holidays       []Item      `bson:"holidays" gorm:"serializer:json"`
+
+	// The live calendar the draft was saved against. Approval is refused
+	// once it has changed, so a draft never silently reverts other edits.
+	BaseTerms    []BaseRef `bson:"base_terms" gorm:"serializer:json"`
+	BaseHolidays []BaseRef `bson:"base_holidays" gorm:"serializer:json"`
+
+	AuthorID      string     `bson:"author_id" gorm:"size:64"`
+	SubmittedAt   *time.Time `bson:"submitted_at"`
+	ReviewerID    string     `bson:"reviewer_id" gorm:"size:64"`
+	ReviewedAt    *time.Time `bson:"reviewed_at"`
+	ReviewComment string     `bson:"review_comment" gorm:"size:1024"`
+
+	CreatedAt time.Time `bson:"created_at" gorm:"index:idx_calendar_drafts_org_created,priority:2"`
+	UpdatedAt time.Time `bson:"updated_at"`
+	Version   int64     `bson:"version" gorm:"not null;default:1"` // bumped by every update
+}
+
+func (Draft) TableName() string {
+	return "calendar_drafts"
+}
+
+// Editable reports whether the draft's content may still change.
+func (d *Draft) Editable() bool {
+	return d.Status == StatusDraft || d.Status == StatusRejected
+}
+
+// Item is a term or holiday as the draft would have it. ID names the live
+// record it replaces and is empty for new ones.
+type Item struct {
+	ID        string            `bson:"id,omitempty" json:"id,omitempty"`
+	Title     string            `bson:"title" json:"title"`
+	Color     string            `bson:"color" json:"color"`
+	Audiences publish.Audiences `bson:"audiences" json:"audiences"`
+	StartDate time.Time         `bson:"start_date" json:"start_date"`
+	EndDate   time.Time         `bson:"end_date" json:"end_date"`
+	// Periods are a term's sub-periods; new ones have no ID until approval.
+	// Holidays have none.
+	Periods term_model.Periods `bson:"periods,omitempty" json:"periods,omitempty"`
+}
+
+// BaseRef is a live record and the version the draft saw.
+type BaseRef struct {
+	ID      string `bson:"id" json:"id"`
+	Version int64  `bson:"version" json:"version"`
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"term-service/internal/draft/model"
+	"term-service/pkg/db"
+	"term-service/pkg/objectid"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type DraftRepository interface {
+	Create(ctx context.Context, draft *model.Draft) (*model.Draft, error)
+	GetByID(ctx context.Context, id string) (*model.Draft, error)
+	// Update stores draft if its Version still matches the stored one and
+	// bumps Version; otherwise it returns db.ErrVersionConflict.
+	Update(ctx context.Context, draft *model.Draft) error
+	// GetAllByOrgID lists an organization's drafts oldest first, limited to
+	// one status unless status is empty.
+	GetAllByOrgID(ctx context.Context, orgID, status string) ([]*model.Draft, error)
+}
+
+type draftRepository struct {
+	collection *mongo.Collection
+}
+
+func NewDraftRepository(collection *mongo.Collection) DraftRepository {
+	return &draftRepository{collection}
+}
+
+func (r *draftRepository) Create(ctx context.Context, draft *model.Draft) (*model.Draft, error) {
+	now := time.Now()
+	if draft.ID.IsZero() {
+		draft.ID = objectid.New()
+	}
+	draft.CreatedAt = now
+	draft.UpdatedAt = now
+	draft.Version = 1
+
+	if _, err := r.collection.InsertOne(ctx, draft); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+func (r *draftRepository) GetByID(ctx context.Context, id string) (*model.Draft, error) {
+	var draft model.Draft
+	err := r.collection.FindOne(ctx, bson.M{"_id": objectid.ID(id)}).Decode(&draft)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, db.ErrNotFound
+		}
+		return nil, err
+	}
+	return &draft, nil
+}
+
+func (r *draftRepository) Update(ctx context.Context, draft *model.Draft) error {
+	draft.UpdatedAt = time.Now()
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": draft.ID, "version": draft.Version}, bson.M{
+		"$set": bson.M{
+			"status":         draft.Status,
+			"language_id":    draft.LanguageID,
+			"word":           draft.Word,
+			"terms":          draft.Terms,
+			"holidays":       draft.Holidays,
+			"base_terms":     draft.BaseTerms,
+			"base_holidays":  draft.BaseHolidays,
+			"submitted_at":   draft.SubmittedAt,
+			"reviewer_id":    draft.ReviewerID,
+			"reviewed_at":    draft.ReviewedAt,
+			"review_comment": draft.ReviewComment,
+			"updated_at":     draft.UpdatedAt,
+		},
+		"$inc": bson.M{"version": 1},
+	})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		exists, err := r.collection.CountDocuments(ctx, bson.M{"_id": draft.ID})
+		if err != nil {
+			return err
+		}
+		if exists == 0 {
+			return db.ErrNotFound
+		}
+		return db.ErrVersionConflict
+	}
+	draft.Version++
+	return nil
+}
+
+func (r *draftRepository) GetAllByOrgID(ctx context.Context, orgID, status string) ([]*model.Draft, error) {
+	filter := bson.M{"organization_id": orgID}
+	if status != "" {
+		filter["status"] = status
+	}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	drafts := make([]*model.Draft, 0)
+	if err := cursor.All(ctx, &drafts); err != nil {
+		return nil, err
+	}
+	return drafts, nil
+}
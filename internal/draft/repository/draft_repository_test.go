@@ -0,0 +1,32 @@
+package repository_test
+
+import (
+	"term-service/internal/draft/repository"
+	"term-service/internal/draft/repository/repositorytest"
+	"term-service/pkg/db/dbtest"
+	"testing"
+)
+
+func TestMongoDraftRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.DraftRepository {
+		return repository.NewDraftRepository(dbtest.NewMongo(t).Collection("calendar_drafts"))
+	})
+}
+
+func TestGormSQLiteDraftRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.DraftRepository {
+		return repository.NewGormDraftRepository(dbtest.NewSQLite(t))
+	})
+}
+
+func TestGormMySQLDraftRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.DraftRepository {
+		return repository.NewGormDraftRepository(dbtest.NewMySQL(t))
+	})
+}
+
+func TestMemoryDraftRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.DraftRepository {
+		return repository.NewMemoryDraftRepository()
+	})
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"term-service/internal/draft/model"
+	"term-service/pkg/db"
+	"term-service/pkg/db/txn"
+	"term-service/pkg/objectid"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type gormDraftRepository struct {
+	db *gorm.DB
+}
+
+// NewGormDraftRepository returns a DraftRepository backed by MySQL (or any
+// other GORM dialect).
+func NewGormDraftRepository(db *gorm.DB) DraftRepository {
+	return &gormDraftRepository{db}
+}
+
+func (r *gormDraftRepository) Create(ctx context.Context, draft *model.Draft) (*model.Draft, error) {
+	now := time.Now()
+	if draft.ID.IsZero() {
+		draft.ID = objectid.New()
+	}
+	draft.CreatedAt = now
+	draft.UpdatedAt = now
+	draft.Version = 1
+
+	if err := txn.Gorm(ctx, r.db).Create(draft).Error; err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+func (r *gormDraftRepository) GetByID(ctx context.Context, id string) (*model.Draft, error) {
+	var draft model.Draft
+	err := txn.Gorm(ctx, r.db).Where("id = ?", id).First(&draft).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, db.ErrNotFound
+		}
+		return nil, err
+	}
+	return &draft, nil
+}
+
+func (r *gormDraftRepository) Update(ctx context.Context, draft *model.Draft) error {
+	draft.UpdatedAt = time.Now()
+
+	// A struct update so the JSON serializer applies; Select keeps zero
+	// values such as a cleared review comment.
+	stored := *draft
+	stored.Version++
+	result := txn.Gorm(ctx, r.db).Model(&model.Draft{}).
+		Where("id = ? AND version = ?", draft.ID, draft.Version).
+		Select("status", "language_id", "word", "terms", "holidays", "base_terms", "base_holidays",
+			"submitted_at", "reviewer_id", "reviewed_at", "review_comment", "updated_at", "version").
+		Updates(&stored)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		var exists int64
+		if err := txn.Gorm(ctx, r.db).Model(&model.Draft{}).Where("id = ?", draft.ID).Count(&exists).Error; err != nil {
+			return err
+		}
+		if exists == 0 {
+			return db.ErrNotFound
+		}
+		return db.ErrVersionConflict
+	}
+	draft.Version++
+	return nil
+}
+
+func (r *gormDraftRepository) GetAllByOrgID(ctx context.Context, orgID, status string) ([]*model.Draft, error) {
+	query := txn.Gorm(ctx, r.db).Where("organization_id = ?", orgID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	drafts := make([]*model.Draft, 0)
+	err := query.Order("created_at").Find(&drafts).Error
+	return drafts, err
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"term-service/internal/draft/model"
+	"term-service/pkg/db"
+	"term-service/pkg/objectid"
+	"time"
+)
+
+type memoryDraftRepository struct {
+	mu     sync.RWMutex
+	drafts map[objectid.ID]model.Draft
+}
+
+// NewMemoryDraftRepository returns a DraftRepository kept in process
+// memory, for tests and local runs without a database.
+func NewMemoryDraftRepository() DraftRepository {
+	return &memoryDraftRepository{drafts: make(map[objectid.ID]model.Draft)}
+}
+
+func (r *memoryDraftRepository) Create(ctx context.Context, draft *model.Draft) (*model.Draft, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if draft.ID.IsZero() {
+		draft.ID = objectid.New()
+	}
+	draft.CreatedAt = now
+	draft.UpdatedAt = now
+	draft.Version = 1
+
+	r.drafts[draft.ID] = cloneDraft(*draft)
+	return draft, nil
+}
+
+func (r *memoryDraftRepository) GetByID(ctx context.Context, id string) (*model.Draft, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	draft, ok := r.drafts[objectid.ID(id)]
+	if !ok {
+		return nil, db.ErrNotFound
+	}
+	draft = cloneDraft(draft)
+	return &draft, nil
+}
+
+func (r *memoryDraftRepository) Update(ctx context.Context, draft *model.Draft) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.drafts[draft.ID]
+	if !ok {
+		return db.ErrNotFound
+	}
+	if existing.Version != draft.Version {
+		return db.ErrVersionConflict
+	}
+
+	draft.UpdatedAt = time.Now()
+	draft.Version++
+	stored := cloneDraft(*draft)
+	stored.OrganizationID = existing.OrganizationID
+	stored.AuthorID = existing.AuthorID
+	stored.CreatedAt = existing.CreatedAt
+	r.drafts[draft.ID] = stored
+	return nil
+}
+
+func (r *memoryDraftRepository) GetAllByOrgID(ctx context.Context, orgID, status string) ([]*model.Draft, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	drafts := make([]*model.Draft, 0)
+	for _, d := range r.drafts {
+		if d.OrganizationID == orgID && (status == "" || d.Status == status) {
+			d := cloneDraft(d)
+			drafts = append(drafts, &d)
+		}
+	}
+	sort.Slice(drafts, func(i, j int) bool {
+		if drafts[i].CreatedAt.Equal(drafts[j].CreatedAt) {
+			return drafts[i].ID < drafts[j].ID
+		}
+		return drafts[i].CreatedAt.Before(drafts[j].CreatedAt)
+	})
+	return drafts, nil
+}
+
+// cloneDraft copies the slices so callers cannot edit the stored draft.
+func cloneDraft(d model.Draft) model.Draft {
+	d.Terms = append([]model.Item(nil), d.Terms...)
+	d.Holidays = append([]model.Item(nil), d.Holidays...)
+	d.BaseTerms = append([]model.BaseRef(nil), d.BaseTerms...)
+	d.BaseHolidays = append([]model.BaseRef(nil), d.BaseHolidays...)
+	return d
+}
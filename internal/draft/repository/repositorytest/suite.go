@@ -0,0 +1,156 @@
+// Package repositorytest holds the behaviour every draft repository backend
+// must share. Backend tests call Run with a constructor for an empty store.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"term-service/internal/draft/model"
+	"term-service/internal/draft/repository"
+	"term-service/pkg/db"
+	"term-service/pkg/objectid"
+	"term-service/pkg/publish"
+	"testing"
+	"time"
+)
+
+// Run executes the conformance suite; newRepo must return an empty
+// repository.
+func Run(t *testing.T, newRepo func(t *testing.T) repository.DraftRepository) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo repository.DraftRepository)
+	}{
+		{"CreateAndGet", testCreateAndGet},
+		{"UpdateChecksVersion", testUpdateChecksVersion},
+		{"GetAllByOrgID", testGetAllByOrgID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+func newDraft(orgID string) *model.Draft {
+	start := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	return &model.Draft{
+		OrganizationID: orgID,
+		Status:         model.StatusDraft,
+		LanguageID:     1,
+		Word:           "term",
+		Terms: []model.Item{{
+			Title:     "Autumn",
+			Color:     "#ff0000",
+			Audiences: publish.Audiences{{Channel: publish.Mobile}},
+			StartDate: start,
+			EndDate:   start.AddDate(0, 3, 0),
+		}},
+		Holidays:  []model.Item{{ID: objectid.New().Hex(), Title: "Tet", StartDate: start, EndDate: start.AddDate(0, 0, 5)}},
+		BaseTerms: []model.BaseRef{{ID: "t1", Version: 3}},
+		AuthorID:  "secretary",
+	}
+}
+
+func testCreateAndGet(t *testing.T, repo repository.DraftRepository) {
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, newDraft("org-a"))
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.ID.IsZero() || created.Version != 1 {
+		t.Fatalf("Create = %+v, want an id and version 1", created)
+	}
+
+	got, err := repo.GetByID(ctx, created.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.OrganizationID != "org-a" || got.Status != model.StatusDraft || got.AuthorID != "secretary" {
+		t.Fatalf("GetByID = %+v", got)
+	}
+	if len(got.Terms) != 1 || got.Terms[0].Title != "Autumn" || !got.Terms[0].Audiences.VisibleOn(publish.Mobile, time.Now()) ||
+		!got.Terms[0].StartDate.Equal(created.Terms[0].StartDate) {
+		t.Fatalf("terms = %+v", got.Terms)
+	}
+	if len(got.Holidays) != 1 || got.Holidays[0].ID != created.Holidays[0].ID {
+		t.Fatalf("holidays = %+v", got.Holidays)
+	}
+	if len(got.BaseTerms) != 1 || got.BaseTerms[0] != (model.BaseRef{ID: "t1", Version: 3}) {
+		t.Fatalf("base terms = %+v", got.BaseTerms)
+	}
+
+	if _, err := repo.GetByID(ctx, objectid.New().Hex()); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("GetByID(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func testUpdateChecksVersion(t *testing.T, repo repository.DraftRepository) {
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, newDraft("org-a"))
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	stale := *created
+	now := time.Now().UTC().Truncate(time.Second)
+	created.Status = model.StatusPending
+	created.SubmittedAt = &now
+	created.Holidays = nil
+	if err := repo.Update(ctx, created); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if created.Version != 2 {
+		t.Fatalf("version after Update = %d, want 2", created.Version)
+	}
+
+	got, _ := repo.GetByID(ctx, created.ID.Hex())
+	if got.Status != model.StatusPending || got.SubmittedAt == nil || !got.SubmittedAt.Equal(now) || len(got.Holidays) != 0 || got.Version != 2 {
+		t.Fatalf("after Update = %+v", got)
+	}
+
+	stale.Status = model.StatusRejected
+	if err := repo.Update(ctx, &stale); !errors.Is(err, db.ErrVersionConflict) {
+		t.Fatalf("stale Update error = %v, want ErrVersionConflict", err)
+	}
+	missing := newDraft("org-a")
+	missing.ID = objectid.New()
+	missing.Version = 1
+	if err := repo.Update(ctx, missing); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("Update(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func testGetAllByOrgID(t *testing.T, repo repository.DraftRepository) {
+	ctx := context.Background()
+
+	first, _ := repo.Create(ctx, newDraft("org-a"))
+	time.Sleep(2 * time.Millisecond)
+	second, _ := repo.Create(ctx, newDraft("org-a"))
+	if _, err := repo.Create(ctx, newDraft("org-b")); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	second.Status = model.StatusPending
+	if err := repo.Update(ctx, second); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	all, err := repo.GetAllByOrgID(ctx, "org-a", "")
+	if err != nil {
+		t.Fatalf("GetAllByOrgID: %v", err)
+	}
+	if len(all) != 2 || all[0].ID != first.ID || all[1].ID != second.ID {
+		t.Fatalf("GetAllByOrgID = %+v, want both org-a drafts oldest first", all)
+	}
+
+	pending, err := repo.GetAllByOrgID(ctx, "org-a", model.StatusPending)
+	if err != nil {
+		t.Fatalf("GetAllByOrgID(pending): %v", err)
+	}
+	if len(pending) != 1 || pending[0].ID != second.ID {
+		t.Fatalf("GetAllByOrgID(pending) = %+v", pending)
+	}
+}
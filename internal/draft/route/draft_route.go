@@ -0,0 +1,34 @@
+package route
+
+import (
+	"term-service/internal/draft/handler"
+	"term-service/internal/gateway"
+	"term-service/internal/policy"
+	"term-service/internal/term/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// authLimit throttles per user and organization and runs after authorization
+// has resolved them.
+func RegisterDraftRoutes(r *gin.Engine, h *handler.DraftHandler, userGW gateway.UserGateway, authLimit gin.HandlerFunc) {
+	// Admin routes
+	adminGroup := r.Group("/api/v1/admin")
+	adminGroup.Use(middleware.Secured())
+	{
+		drafts := adminGroup.Group("/calendar/drafts")
+		{
+			edit := drafts.Group("", middleware.Authorize(userGW, policy.CalendarDraft, middleware.MemberTarget), authLimit)
+			edit.POST("", h.CreateDraft)
+			edit.GET("", h.GetDrafts)
+			edit.GET("/:id", h.GetDraft)
+			edit.GET("/:id/diff", h.GetDraftDiff)
+			edit.PUT("/:id", h.UpdateDraft)
+			edit.POST("/:id/submit", h.SubmitDraft)
+
+			review := drafts.Group("", middleware.Authorize(userGW, policy.CalendarApprove, middleware.AdminTarget), authLimit)
+			review.POST("/:id/approve", h.ApproveDraft)
+			review.POST("/:id/reject", h.RejectDraft)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"term-service/internal/draft/dto/response"
+	"term-service/internal/draft/mapper"
+	"term-service/internal/draft/model"
+)
+
+// setDiff is how a draft's terms or holidays differ from the live ones.
+type setDiff struct {
+	added   []model.Item
+	changed []change
+	removed []model.Item
+}
+
+type change struct {
+	before, after model.Item
+	fields        []string
+}
+
+// diffItems compares draft items with live ones. Draft items without an id
+// are added, live items the draft leaves out are removed and the rest are
+// changed when any field differs.
+func diffItems(draft, live []model.Item) setDiff {
+	var d setDiff
+	kept := make(map[string]bool, len(draft))
+	byID := make(map[string]model.Item, len(live))
+	for _, item := range live {
+		byID[item.ID] = item
+	}
+
+	for _, item := range draft {
+		before, ok := byID[item.ID]
+		if item.ID == "" || !ok {
+			d.added = append(d.added, item)
+			continue
+		}
+		kept[item.ID] = true
+		if fields := changedFields(before, item); len(fields) > 0 {
+			d.changed = append(d.changed, change{before: before, after: item, fields: fields})
+		}
+	}
+	for _, item := range live {
+		if !kept[item.ID] {
+			d.removed = append(d.removed, item)
+		}
+	}
+	return d
+}
+
+func changedFields(before, after model.Item) []string {
+	var fields []string
+	if before.Title != after.Title {
+		fields = append(fields, "title")
+	}
+	if before.Color != after.Color {
+		fields = append(fields, "color")
+	}
+	if !before.Audiences.Equal(after.Audiences) {
+		fields = append(fields, "audiences")
+	}
+	if !before.StartDate.Equal(after.StartDate) {
+		fields = append(fields, "start_date")
+	}
+	if !before.EndDate.Equal(after.EndDate) {
+		fields = append(fields, "end_date")
+	}
+	if !before.Periods.Equal(after.Periods) {
+		fields = append(fields, "periods")
+	}
+	return fields
+}
+
+// empty reports whether applying the diff would change nothing.
+func (d setDiff) empty() bool {
+	return len(d.added) == 0 && len(d.changed) == 0 && len(d.removed) == 0
+}
+
+func (d setDiff) toResDTO() response.SetDiffResDTO {
+	res := response.SetDiffResDTO{
+		Added:   mapper.MapItemListToResDTO(d.added),
+		Changed: make([]response.ChangeResDTO, 0, len(d.changed)),
+		Removed: mapper.MapItemListToResDTO(d.removed),
+	}
+	for _, c := range d.changed {
+		res.Changed = append(res.Changed, response.ChangeResDTO{
+			ID:     c.after.ID,
+			Fields: c.fields,
+			Before: mapper.MapItemToResDTO(c.before),
+			After:  mapper.MapItemToResDTO(c.after),
+		})
+	}
+	return res
+}
@@ -0,0 +1,528 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"term-service/internal/draft/dto/request"
+	"term-service/internal/draft/dto/response"
+	"term-service/internal/draft/mapper"
+	"term-service/internal/draft/model"
+	"term-service/internal/draft/repository"
+	"term-service/internal/gateway"
+	holiday_request "term-service/internal/holiday/dto/request"
+	holiday_mapper "term-service/internal/holiday/mapper"
+	holiday_repo "term-service/internal/holiday/repository"
+	holiday_service "term-service/internal/holiday/service"
+	term_request "term-service/internal/term/dto/request"
+	term_mappers "term-service/internal/term/mappers"
+	term_model "term-service/internal/term/model"
+	term_repo "term-service/internal/term/repository"
+	term_service "term-service/internal/term/service"
+	"term-service/pkg/db"
+	"term-service/pkg/db/txn"
+	"term-service/pkg/helper"
+	"term-service/pkg/publish"
+	"time"
+)
+
+var (
+	// ErrInvalidDraft marks draft content or filters that cannot be used.
+	ErrInvalidDraft = errors.New("invalid calendar draft")
+	// ErrInvalidState marks a step the draft's status does not allow, such
+	// as approving a draft that was never submitted.
+	ErrInvalidState = errors.New("calendar draft status does not allow this")
+)
+
+var statuses = []string{model.StatusDraft, model.StatusPending, model.StatusApproved, model.StatusRejected}
+
+// DraftService runs the draft, submit and approve workflow for an
+// organization's calendar. Every method is scoped to organizationID, which
+// the caller has already been authorized for; drafts of other organizations
+// are not found.
+type DraftService interface {
+	CreateDraft(ctx context.Context, organizationID string, req request.SaveDraftRequest) (*response.DraftResDTO, error)
+	// GetDrafts lists the organization's drafts, only those in status unless
+	// it is empty.
+	GetDrafts(ctx context.Context, organizationID, status string) ([]response.DraftResDTO, error)
+	GetDraft(ctx context.Context, organizationID, id string) (*response.DraftResDTO, error)
+	// GetDraftDiff compares the draft with the live calendar.
+	GetDraftDiff(ctx context.Context, organizationID, id string) (*response.DiffResDTO, error)
+	// UpdateDraft replaces the content of a draft or rejected draft, which
+	// becomes a draft again.
+	UpdateDraft(ctx context.Context, organizationID, id string, req request.SaveDraftRequest) (*response.DraftResDTO, error)
+	SubmitDraft(ctx context.Context, organizationID, id string) (*response.DraftResDTO, error)
+	// ApproveDraft applies a submitted draft to the live calendar. It fails
+	// with a conflict, carrying the diff, when the calendar changed after
+	// the draft was saved.
+	ApproveDraft(ctx context.Context, organizationID, id string, req request.ReviewDraftRequest) (*response.DraftResDTO, error)
+	RejectDraft(ctx context.Context, organizationID, id string, req request.ReviewDraftRequest) (*response.DraftResDTO, error)
+}
+
+type draftService struct {
+	drafts      repository.DraftRepository
+	terms       term_repo.TermRepository
+	holidays    holiday_repo.HolidayRepository
+	termSvc     term_service.TermService
+	holidaySvc  holiday_service.HolidayService
+	userGateway gateway.UserGateway
+	tx          txn.Transactor
+}
+
+func NewDraftService(
+	drafts repository.DraftRepository,
+	terms term_repo.TermRepository,
+	holidays holiday_repo.HolidayRepository,
+	termSvc term_service.TermService,
+	holidaySvc holiday_service.HolidayService,
+	userGateway gateway.UserGateway,
+	tx txn.Transactor,
+) DraftService {
+	return &draftService{
+		drafts:      drafts,
+		terms:       terms,
+		holidays:    holidays,
+		termSvc:     termSvc,
+		holidaySvc:  holidaySvc,
+		userGateway: userGateway,
+		tx:          tx,
+	}
+}
+
+func (s *draftService) CreateDraft(ctx context.Context, organizationID string, req request.SaveDraftRequest) (*response.DraftResDTO, error) {
+	currentUser, err := s.userGateway.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get current user info failed: %w", err)
+	}
+
+	draft := &model.Draft{
+		OrganizationID: organizationID,
+		Status:         model.StatusDraft,
+		AuthorID:       currentUser.ID,
+	}
+	if err := s.fill(ctx, draft, req); err != nil {
+		return nil, err
+	}
+	if _, err := s.drafts.Create(ctx, draft); err != nil {
+		return nil, fmt.Errorf("create calendar draft: %w", err)
+	}
+
+	res := mapper.MapDraftToResDTO(draft)
+	return &res, nil
+}
+
+func (s *draftService) GetDrafts(ctx context.Context, organizationID, status string) ([]response.DraftResDTO, error) {
+	if status != "" && !slices.Contains(statuses, status) {
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidDraft, status)
+	}
+	drafts, err := s.drafts.GetAllByOrgID(ctx, organizationID, status)
+	if err != nil {
+		return nil, fmt.Errorf("get calendar drafts by orgID failed: %w", err)
+	}
+	return mapper.MapDraftListToResDTO(drafts), nil
+}
+
+func (s *draftService) GetDraft(ctx context.Context, organizationID, id string) (*response.DraftResDTO, error) {
+	draft, err := s.get(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	res := mapper.MapDraftToResDTO(draft)
+	return &res, nil
+}
+
+func (s *draftService) GetDraftDiff(ctx context.Context, organizationID, id string) (*response.DiffResDTO, error) {
+	draft, err := s.get(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	live, err := s.live(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return diffResDTO(draft, live), nil
+}
+
+func (s *draftService) UpdateDraft(ctx context.Context, organizationID, id string, req request.SaveDraftRequest) (*response.DraftResDTO, error) {
+	draft, err := s.get(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	version, err := helper.ExpectedVersion(req.Version, req.IfMatchVersion, 1)
+	if err != nil {
+		return nil, fmt.Errorf("calendar draft %s: %w", id, err)
+	}
+	if draft.Version != version {
+		return nil, draftConflict(draft)
+	}
+	if !draft.Editable() {
+		return nil, fmt.Errorf("%w: calendar draft %s is %s", ErrInvalidState, id, draft.Status)
+	}
+
+	if err := s.fill(ctx, draft, req); err != nil {
+		return nil, err
+	}
+	draft.Status = model.StatusDraft
+	return s.update(ctx, draft)
+}
+
+func (s *draftService) SubmitDraft(ctx context.Context, organizationID, id string) (*response.DraftResDTO, error) {
+	draft, err := s.get(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !draft.Editable() {
+		return nil, fmt.Errorf("%w: calendar draft %s is %s", ErrInvalidState, id, draft.Status)
+	}
+
+	now := time.Now()
+	draft.Status = model.StatusPending
+	draft.SubmittedAt = &now
+	// A resubmitted draft starts a new review.
+	draft.ReviewerID = ""
+	draft.ReviewedAt = nil
+	draft.ReviewComment = ""
+	return s.update(ctx, draft)
+}
+
+func (s *draftService) ApproveDraft(ctx context.Context, organizationID, id string, req request.ReviewDraftRequest) (*response.DraftResDTO, error) {
+	currentUser, err := s.userGateway.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get current user info failed: %w", err)
+	}
+	draft, err := s.get(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	if draft.Status != model.StatusPending {
+		return nil, fmt.Errorf("%w: calendar draft %s is %s, not pending", ErrInvalidState, id, draft.Status)
+	}
+
+	var res *response.DraftResDTO
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		live, err := s.live(ctx, organizationID)
+		if err != nil {
+			return err
+		}
+		if live.stale(draft) {
+			return &helper.ConflictError{
+				Err:     fmt.Errorf("calendar of organization %s changed after draft %s was saved: %w", organizationID, id, db.ErrVersionConflict),
+				Current: diffResDTO(draft, live),
+			}
+		}
+		if err := s.apply(ctx, draft, live); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		draft.Status = model.StatusApproved
+		draft.ReviewerID = currentUser.ID
+		draft.ReviewedAt = &now
+		draft.ReviewComment = req.Comment
+		res, err = s.update(ctx, draft)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *draftService) RejectDraft(ctx context.Context, organizationID, id string, req request.ReviewDraftRequest) (*response.DraftResDTO, error) {
+	currentUser, err := s.userGateway.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get current user info failed: %w", err)
+	}
+	draft, err := s.get(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	if draft.Status != model.StatusPending {
+		return nil, fmt.Errorf("%w: calendar draft %s is %s, not pending", ErrInvalidState, id, draft.Status)
+	}
+
+	now := time.Now()
+	draft.Status = model.StatusRejected
+	draft.ReviewerID = currentUser.ID
+	draft.ReviewedAt = &now
+	draft.ReviewComment = req.Comment
+	return s.update(ctx, draft)
+}
+
+// apply writes the draft to the live calendar through the term and holiday
+// services, so the usual checks, events and messages apply. It runs in the
+// approval's transaction; the services defer their message-language calls
+// until that commits (txn.AfterCommit).
+func (s *draftService) apply(ctx context.Context, draft *model.Draft, live *liveCalendar) error {
+	terms := diffItems(draft.Terms, live.terms)
+	for _, t := range terms.removed {
+		if err := s.termSvc.DeleteTerm(ctx, t.ID); err != nil {
+			return fmt.Errorf("remove term %s: %w", t.ID, err)
+		}
+	}
+	if upserts := termUploads(terms, live.termVersions); len(upserts) > 0 {
+		err := s.termSvc.UploadTerms(ctx, term_request.UploadTermRequest{
+			OrganizationID: draft.OrganizationID,
+			LanguageID:     draft.LanguageID,
+			Word:           draft.Word,
+			Terms:          upserts,
+		})
+		if err != nil {
+			return fmt.Errorf("apply draft terms: %w", err)
+		}
+	}
+
+	holidays := diffItems(draft.Holidays, live.holidays)
+	if holidays.empty() {
+		return nil
+	}
+	upload := holiday_request.UploadHolidayRequest{
+		OrganizationID: draft.OrganizationID,
+		LanguageID:     draft.LanguageID,
+	}
+	for _, h := range holidays.removed {
+		upload.DeleteIds = append(upload.DeleteIds, h.ID)
+	}
+	for _, h := range holidays.added {
+		upload.Holidays = append(upload.Holidays, mapper.MapItemToUploadHolidayItem(h, 0))
+	}
+	for _, c := range holidays.changed {
+		upload.Holidays = append(upload.Holidays, mapper.MapItemToUploadHolidayItem(c.after, live.holidayVersions[c.after.ID]))
+	}
+	if err := s.holidaySvc.UploadHolidays(ctx, upload); err != nil {
+		return fmt.Errorf("apply draft holidays: %w", err)
+	}
+	return nil
+}
+
+func termUploads(d setDiff, versions map[string]int64) []term_request.UploadTermItem {
+	items := make([]term_request.UploadTermItem, 0, len(d.added)+len(d.changed))
+	for _, t := range d.added {
+		items = append(items, mapper.MapItemToUploadTermItem(t, 0))
+	}
+	for _, c := range d.changed {
+		items = append(items, mapper.MapItemToUploadTermItem(c.after, versions[c.after.ID]))
+	}
+	return items
+}
+
+// fill validates req and stores it in draft, along with the live calendar it
+// is based on.
+func (s *draftService) fill(ctx context.Context, draft *model.Draft, req request.SaveDraftRequest) error {
+	live, err := s.live(ctx, draft.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	livePeriods := make(map[string]term_model.Periods, len(live.terms))
+	for _, t := range live.terms {
+		livePeriods[t.ID] = t.Periods
+	}
+	terms := make([]model.Item, 0, len(req.Terms))
+	for _, t := range req.Terms {
+		item, err := parseItem("term", t.ID, t.Title, t.Color, t.StartDate, t.EndDate, term_mappers.MapUploadTermItemToAudiences(t))
+		if err != nil {
+			return err
+		}
+		if item.Periods, err = parsePeriods(item, t.Periods, livePeriods[t.ID]); err != nil {
+			return err
+		}
+		terms = append(terms, item)
+	}
+	if err := checkIDs("term", terms, live.termVersions); err != nil {
+		return err
+	}
+
+	holidays := make([]model.Item, 0, len(req.Holidays))
+	for _, h := range req.Holidays {
+		item, err := parseItem("holiday", h.ID, h.Title, h.Color, h.StartDate, h.EndDate, holiday_mapper.MapUploadHolidayItemToAudiences(h))
+		if err != nil {
+			return err
+		}
+		holidays = append(holidays, item)
+	}
+	if err := checkIDs("holiday", holidays, live.holidayVersions); err != nil {
+		return err
+	}
+
+	draft.LanguageID = req.LanguageID
+	draft.Word = req.Word
+	draft.Terms = terms
+	draft.Holidays = holidays
+	draft.BaseTerms = baseRefs(live.terms, live.termVersions)
+	draft.BaseHolidays = baseRefs(live.holidays, live.holidayVersions)
+	return nil
+}
+
+func parseItem(kind, id, title, color, start, end string, audiences publish.Audiences) (model.Item, error) {
+	startDate, err := time.Parse("2006-01-02", start)
+	if err != nil {
+		return model.Item{}, fmt.Errorf("%w: invalid start_date for %s %s: %v", ErrInvalidDraft, kind, title, err)
+	}
+	endDate, err := time.Parse("2006-01-02", end)
+	if err != nil {
+		return model.Item{}, fmt.Errorf("%w: invalid end_date for %s %s: %v", ErrInvalidDraft, kind, title, err)
+	}
+	if !helper.ValidateDateRange(startDate, endDate) {
+		return model.Item{}, fmt.Errorf("%w: start_date must be before or equal to end_date for %s %s", ErrInvalidDraft, kind, title)
+	}
+	if err := audiences.Validate(); err != nil {
+		return model.Item{}, fmt.Errorf("%w: invalid audiences for %s %s: %v", ErrInvalidDraft, kind, title, err)
+	}
+	return model.Item{
+		ID:        id,
+		Title:     title,
+		Color:     color,
+		Audiences: audiences,
+		StartDate: startDate,
+		EndDate:   endDate,
+	}, nil
+}
+
+// parsePeriods validates the periods of a draft term. As in an upload, a term
+// that leaves them out keeps its live ones, and a period with an id must be
+// one of them.
+func parsePeriods(term model.Item, items []term_request.UploadPeriodItem, live term_model.Periods) (term_model.Periods, error) {
+	periods := live
+	if items != nil {
+		periods = make(term_model.Periods, 0, len(items))
+		for _, item := range items {
+			if _, ok := live.Find(item.ID); item.ID != "" && !ok {
+				return nil, fmt.Errorf("%w: period %s of term %s not found", ErrInvalidDraft, item.ID, term.Title)
+			}
+			start, err := time.Parse("2006-01-02", item.StartDate)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid start_date for %s period %s of term %s: %v", ErrInvalidDraft, item.Type, item.Title, term.Title, err)
+			}
+			end, err := time.Parse("2006-01-02", item.EndDate)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid end_date for %s period %s of term %s: %v", ErrInvalidDraft, item.Type, item.Title, term.Title, err)
+			}
+			periods = append(periods, term_model.Period{ID: item.ID, Type: item.Type, Title: item.Title, StartDate: start, EndDate: end})
+		}
+	}
+	if err := periods.Validate(term.StartDate, term.EndDate); err != nil {
+		return nil, fmt.Errorf("%w: invalid periods for term %s: %v", ErrInvalidDraft, term.Title, err)
+	}
+	return periods, nil
+}
+
+// checkIDs requires every id to name a live record of the organization, at
+// most once.
+func checkIDs(kind string, items []model.Item, live map[string]int64) error {
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		if item.ID == "" {
+			continue
+		}
+		if _, ok := live[item.ID]; !ok {
+			return fmt.Errorf("%w: %s %s not found", ErrInvalidDraft, kind, item.ID)
+		}
+		if seen[item.ID] {
+			return fmt.Errorf("%w: %s %s listed twice", ErrInvalidDraft, kind, item.ID)
+		}
+		seen[item.ID] = true
+	}
+	return nil
+}
+
+func (s *draftService) update(ctx context.Context, draft *model.Draft) (*response.DraftResDTO, error) {
+	if err := s.drafts.Update(ctx, draft); err != nil {
+		if errors.Is(err, db.ErrVersionConflict) {
+			// changed between our read and write
+			if current, getErr := s.drafts.GetByID(ctx, draft.ID.Hex()); getErr == nil {
+				return nil, draftConflict(current)
+			}
+		}
+		return nil, fmt.Errorf("update calendar draft %s: %w", draft.ID.Hex(), err)
+	}
+	res := mapper.MapDraftToResDTO(draft)
+	return &res, nil
+}
+
+// get loads a draft of organizationID; others are reported as not found so
+// their existence is not revealed.
+func (s *draftService) get(ctx context.Context, organizationID, id string) (*model.Draft, error) {
+	draft, err := s.drafts.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get calendar draft %s: %w", id, err)
+	}
+	if draft.OrganizationID != organizationID {
+		return nil, fmt.Errorf("calendar draft %s in organization %s: %w", id, organizationID, db.ErrNotFound)
+	}
+	return draft, nil
+}
+
+func draftConflict(current *model.Draft) error {
+	return &helper.ConflictError{
+		Err:     fmt.Errorf("calendar draft %s is at version %d: %w", current.ID.Hex(), current.Version, db.ErrVersionConflict),
+		Current: mapper.MapDraftToResDTO(current),
+	}
+}
+
+func diffResDTO(draft *model.Draft, live *liveCalendar) *response.DiffResDTO {
+	return &response.DiffResDTO{
+		Terms:    diffItems(draft.Terms, live.terms).toResDTO(),
+		Holidays: diffItems(draft.Holidays, live.holidays).toResDTO(),
+		Stale:    live.stale(draft),
+	}
+}
+
+// liveCalendar is an organization's current terms and holidays with their
+// versions.
+type liveCalendar struct {
+	terms, holidays               []model.Item
+	termVersions, holidayVersions map[string]int64
+}
+
+func (s *draftService) live(ctx context.Context, organizationID string) (*liveCalendar, error) {
+	terms, err := s.terms.GetAllByOrgID(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("get terms by orgID failed: %w", err)
+	}
+	holidays, err := s.holidays.GetAllByOrgID(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("get holidays by orgID failed: %w", err)
+	}
+
+	live := &liveCalendar{
+		termVersions:    make(map[string]int64, len(terms)),
+		holidayVersions: make(map[string]int64, len(holidays)),
+	}
+	for _, t := range terms {
+		live.terms = append(live.terms, mapper.MapTermToItem(t))
+		live.termVersions[t.ID.Hex()] = t.Version
+	}
+	for _, h := range holidays {
+		live.holidays = append(live.holidays, mapper.MapHolidayToItem(h))
+		live.holidayVersions[h.ID.Hex()] = h.Version
+	}
+	return live, nil
+}
+
+func baseRefs(items []model.Item, versions map[string]int64) []model.BaseRef {
+	refs := make([]model.BaseRef, 0, len(items))
+	for _, item := range items {
+		refs = append(refs, model.BaseRef{ID: item.ID, Version: versions[item.ID]})
+	}
+	return refs
+}
+
+// stale reports whether a term or holiday was added, removed or updated
+// since the draft was saved.
+func (l *liveCalendar) stale(draft *model.Draft) bool {
+	return !sameBase(draft.BaseTerms, l.termVersions) || !sameBase(draft.BaseHolidays, l.holidayVersions)
+}
+
+func sameBase(base []model.BaseRef, live map[string]int64) bool {
+	if len(base) != len(live) {
+		return false
+	}
+	for _, ref := range base {
+		if version, ok := live[ref.ID]; !ok || version != ref.Version {
+			return false
+		}
+	}
+	return true
+}
@@ -2,14 +2,23 @@ package gateway
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
-	"term-service/logger"
+	"strings"
 	"term-service/pkg/consul"
+	"term-service/pkg/metrics"
+	"term-service/pkg/tracing"
+	"term-service/pkg/zap"
+	"time"
 
 	"github.com/hashicorp/consul/api"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type HTTPClient interface {
@@ -30,10 +39,7 @@ func NewGatewayClient(serviceName, token string, consulClient *api.Client, httpC
 
 	sd, err := consul.NewServiceDiscovery(consulClient, serviceName)
 	if err != nil {
-		logger.WriteLogEx("error", "failed to init service discovery", map[string]any{
-			"service": serviceName,
-			"error":   err.Error(),
-		})
+		zap.L().Errorw("failed to init service discovery", "service", serviceName, "error", err.Error())
 		return nil, fmt.Errorf("failed to init service discovery: %v", err)
 	}
 
@@ -46,7 +52,25 @@ func NewGatewayClient(serviceName, token string, consulClient *api.Client, httpC
 }
 
 // Call gọi API tới service khác thông qua Consul discovery
-func (c *GatewayClient) Call(method, path string, body interface{}, headers map[string]string) ([]byte, error) {
+func (c *GatewayClient) Call(ctx context.Context, method, path string, body interface{}, headers map[string]string) (_ []byte, err error) {
+	start := time.Now()
+	status := 0
+	route := metricPath(path)
+
+	ctx, span := tracing.StartSpan(ctx, method+" "+route,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("peer.service", c.ServiceName),
+			attribute.String("http.request.method", method),
+			attribute.String("url.path", route),
+		),
+	)
+	defer func() {
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		tracing.EndSpan(span, err)
+		metrics.ObserveGatewayCall(c.ServiceName, method, route, status, time.Since(start))
+	}()
+
 	service, err := c.ServiceDiscovery.DiscoverService()
 	if err != nil {
 		return nil, fmt.Errorf("service discovery failed: %v", err)
@@ -63,7 +87,7 @@ func (c *GatewayClient) Call(method, path string, body interface{}, headers map[
 
 	url := fmt.Sprintf("http://%s:%d%s", service.ServiceAddress, service.ServicePort, path)
 
-	req, err := http.NewRequest(method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("create request failed: %v", err)
 	}
@@ -81,11 +105,15 @@ func (c *GatewayClient) Call(method, path string, body interface{}, headers map[
 		req.Header.Set(k, v)
 	}
 
+	// W3C traceparent/tracestate
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("http call failed: %v", err)
 	}
 	defer resp.Body.Close()
+	status = resp.StatusCode
 
 	if resp.StatusCode >= 400 {
 		return nil, fmt.Errorf("http error: %s", resp.Status)
@@ -98,3 +126,36 @@ func (c *GatewayClient) Call(method, path string, body interface{}, headers map[
 
 	return data, nil
 }
+
+// metricPath strips the query string and replaces id-like segments so the
+// path can be used as a metric label without unbounded cardinality.
+func metricPath(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if isIDSegment(seg) {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func isIDSegment(seg string) bool {
+	if seg == "" {
+		return false
+	}
+	hasDigit := false
+	for _, r := range seg {
+		switch {
+		case r >= '0' && r <= '9':
+			hasDigit = true
+		case r >= 'a' && r <= 'f', r >= 'A' && r <= 'F', r == '-':
+		default:
+			return false
+		}
+	}
+	return hasDigit
+}
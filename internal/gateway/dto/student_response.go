@@ -5,3 +5,11 @@ type StudentResponse struct {
 	OrganizationID string `json:"organization_id"`
 	StudentName    string `json:"student_name"`
 }
+
+// StudentRelations lists the users who look after a student outside the
+// student's organization membership.
+type StudentRelations struct {
+	StudentID   string   `json:"student_id"`
+	GuardianIDs []string `json:"guardian_ids"`
+	TeacherIDs  []string `json:"teacher_ids"`
+}
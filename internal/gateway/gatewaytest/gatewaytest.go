@@ -0,0 +1,234 @@
+// Package gatewaytest provides in-memory fakes of the go-main-service
+// gateways so services can be exercised without Consul or the network.
+package gatewaytest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"term-service/internal/gateway"
+	"term-service/internal/gateway/dto"
+	"term-service/pkg/constants"
+	"term-service/pkg/helper"
+)
+
+// UserGateway resolves the current user from the request token.
+type UserGateway struct {
+	mu       sync.RWMutex
+	users    map[string]*dto.CurrentUser
+	students map[string]*dto.StudentResponse
+	related  map[string]*dto.StudentRelations
+}
+
+var _ gateway.UserGateway = (*UserGateway)(nil)
+
+func NewUserGateway() *UserGateway {
+	return &UserGateway{
+		users:    make(map[string]*dto.CurrentUser),
+		students: make(map[string]*dto.StudentResponse),
+		related:  make(map[string]*dto.StudentRelations),
+	}
+}
+
+// AddUser makes user the current user for requests carrying token.
+func (g *UserGateway) AddUser(token string, user *dto.CurrentUser) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.users[token] = user
+}
+
+func (g *UserGateway) AddStudent(student *dto.StudentResponse) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.students[student.ID] = student
+}
+
+// AddStudentRelations sets the guardians and teachers of a student.
+func (g *UserGateway) AddStudentRelations(relations *dto.StudentRelations) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.related[relations.StudentID] = relations
+}
+
+func (g *UserGateway) GetAuthorInfo(ctx context.Context, userID string) (*gateway.User, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	for _, u := range g.users {
+		if u.ID == userID {
+			return &gateway.User{ID: u.ID, Name: u.Fullname, Email: u.Email}, nil
+		}
+	}
+	return nil, fmt.Errorf("user %s not found", userID)
+}
+
+func (g *UserGateway) GetCurrentUser(ctx context.Context) (*dto.CurrentUser, error) {
+	if u, ok := ctx.Value(constants.CurrentUserKey).(*dto.CurrentUser); ok && u != nil {
+		return u, nil
+	}
+	token, ok := ctx.Value(constants.Token).(string)
+	if !ok {
+		return nil, fmt.Errorf("token not found in context")
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	user, ok := g.users[token]
+	if !ok {
+		return nil, fmt.Errorf("gateway error: unauthorized")
+	}
+	return user, nil
+}
+
+func (g *UserGateway) GetStudentInfo(ctx context.Context, studentID string) (*dto.StudentResponse, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	student, ok := g.students[studentID]
+	if !ok {
+		return nil, fmt.Errorf("gateway error: student %s not found", studentID)
+	}
+	return student, nil
+}
+
+func (g *UserGateway) GetStudentRelations(ctx context.Context, studentID string) (*dto.StudentRelations, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if r, ok := g.related[studentID]; ok {
+		return r, nil
+	}
+	return &dto.StudentRelations{StudentID: studentID}, nil
+}
+
+// OrganizationGateway serves a fixed set of organizations.
+type OrganizationGateway struct {
+	mu   sync.RWMutex
+	orgs []dto.OrganizationInfo
+}
+
+var _ gateway.OrganizationGateway = (*OrganizationGateway)(nil)
+
+func NewOrganizationGateway(orgs ...dto.OrganizationInfo) *OrganizationGateway {
+	return &OrganizationGateway{orgs: orgs}
+}
+
+func (g *OrganizationGateway) AddOrganization(org dto.OrganizationInfo) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.orgs = append(g.orgs, org)
+}
+
+func (g *OrganizationGateway) GetOrganizationInfo(ctx context.Context, organizationID string) (*dto.OrganizationInfo, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	for i := range g.orgs {
+		if g.orgs[i].ID == organizationID {
+			org := g.orgs[i]
+			return &org, nil
+		}
+	}
+	return nil, fmt.Errorf("gateway error: organization %s not found", organizationID)
+}
+
+func (g *OrganizationGateway) GetAllOrg(ctx context.Context) ([]dto.OrganizationInfo, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return append([]dto.OrganizationInfo(nil), g.orgs...), nil
+}
+
+// MessageLanguageGateway stores uploaded messages per type, type ID and
+// language, and records every upload for assertions.
+type MessageLanguageGateway struct {
+	mu       sync.RWMutex
+	messages map[messageKey]map[string]string
+	uploads  []dto.UploadMessageRequest
+
+	// UploadErr, when set, fails every upload.
+	UploadErr error
+}
+
+type messageKey struct {
+	typeStr string
+	typeID  string
+	langID  uint
+}
+
+var _ gateway.MessageLanguageGateway = (*MessageLanguageGateway)(nil)
+
+func NewMessageLanguageGateway() *MessageLanguageGateway {
+	return &MessageLanguageGateway{messages: make(map[messageKey]map[string]string)}
+}
+
+// Uploads returns every message uploaded so far, in order.
+func (g *MessageLanguageGateway) Uploads() []dto.UploadMessageRequest {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return append([]dto.UploadMessageRequest(nil), g.uploads...)
+}
+
+func (g *MessageLanguageGateway) UploadMessage(ctx context.Context, req dto.UploadMessageRequest) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.UploadErr != nil {
+		return g.UploadErr
+	}
+	key := messageKey{req.Type, req.TypeID, req.LanguageID}
+	if g.messages[key] == nil {
+		g.messages[key] = make(map[string]string)
+	}
+	g.messages[key][req.Key] = req.Value
+	g.uploads = append(g.uploads, req)
+	return nil
+}
+
+func (g *MessageLanguageGateway) UploadMessages(ctx context.Context, req dto.UploadMessageLanguagesRequest) error {
+	for _, m := range req.MessageLanguages {
+		if err := g.UploadMessage(ctx, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *MessageLanguageGateway) GetMessageLanguages(ctx context.Context, typeStr string, typeID string) ([]dto.MessageLanguageResponse, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	res := make([]dto.MessageLanguageResponse, 0)
+	for key, contents := range g.messages {
+		if key.typeStr == typeStr && key.typeID == typeID {
+			res = append(res, dto.MessageLanguageResponse{LangID: key.langID, Contents: copyContents(contents)})
+		}
+	}
+	return res, nil
+}
+
+func (g *MessageLanguageGateway) GetMessageLanguage(ctx context.Context, typeStr string, typeID string) (dto.MessageLanguageResponse, error) {
+	langID := helper.GetAppLanguage(ctx, 1)
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	contents, ok := g.messages[messageKey{typeStr, typeID, langID}]
+	if !ok {
+		return dto.MessageLanguageResponse{}, fmt.Errorf("message language not found")
+	}
+	return dto.MessageLanguageResponse{LangID: langID, Contents: copyContents(contents)}, nil
+}
+
+func (g *MessageLanguageGateway) DeleleByTypeAndTypeID(ctx context.Context, typeStr string, typeID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for key := range g.messages {
+		if key.typeStr == typeStr && key.typeID == typeID {
+			delete(g.messages, key)
+		}
+	}
+	return nil
+}
+
+func copyContents(contents map[string]string) map[string]string {
+	out := make(map[string]string, len(contents))
+	for k, v := range contents {
+		out[k] = v
+	}
+	return out
+}
@@ -8,6 +8,7 @@ import (
 	"term-service/internal/gateway/dto"
 	"term-service/pkg/constants"
 	"term-service/pkg/helper"
+	"term-service/pkg/servicetoken"
 
 	"github.com/hashicorp/consul/api"
 )
@@ -23,16 +24,18 @@ type MessageLanguageGateway interface {
 type messageLanguageGateway struct {
 	serviceName string
 	consul      *api.Client
+	signer      servicetoken.Signer
 }
 
-func NewMessageLanguageGateway(serviceName string, consulClient *api.Client) MessageLanguageGateway {
+func NewMessageLanguageGateway(serviceName string, consulClient *api.Client, signer servicetoken.Signer) MessageLanguageGateway {
 	return &messageLanguageGateway{
 		serviceName: serviceName,
 		consul:      consulClient,
+		signer:      signer,
 	}
 }
 func (g *messageLanguageGateway) UploadMessage(ctx context.Context, req dto.UploadMessageRequest) error {
-	token, ok := ctx.Value("token").(string)
+	token, ok := callerToken(ctx, g.signer, g.serviceName)
 	if !ok {
 		return nil
 	}
@@ -44,7 +47,7 @@ func (g *messageLanguageGateway) UploadMessage(ctx context.Context, req dto.Uplo
 
 	headers := helper.GetHeaders(ctx)
 
-	_, err = client.Call("POST", "/v1/gateway/messages", req, headers)
+	_, err = client.Call(ctx, "POST", "/v1/gateway/messages", req, headers)
 	if err != nil {
 		return err
 	}
@@ -53,7 +56,7 @@ func (g *messageLanguageGateway) UploadMessage(ctx context.Context, req dto.Uplo
 }
 
 func (g *messageLanguageGateway) UploadMessages(ctx context.Context, req dto.UploadMessageLanguagesRequest) error {
-	token, ok := ctx.Value(constants.Token).(string)
+	token, ok := callerToken(ctx, g.signer, g.serviceName)
 	if !ok {
 		return fmt.Errorf("token not found in context")
 	}
@@ -65,7 +68,7 @@ func (g *messageLanguageGateway) UploadMessages(ctx context.Context, req dto.Upl
 
 	headers := helper.GetHeaders(ctx)
 
-	_, err = client.Call("POST", "/v1/gateway/messages", req, headers)
+	_, err = client.Call(ctx, "POST", "/v1/gateway/messages", req, headers)
 	if err != nil {
 		return err
 	}
@@ -75,7 +78,7 @@ func (g *messageLanguageGateway) UploadMessages(ctx context.Context, req dto.Upl
 
 func (g *messageLanguageGateway) GetMessageLanguages(ctx context.Context, typeStr string, typeID string) ([]dto.MessageLanguageResponse, error) {
 	// lấy token từ context
-	token, ok := ctx.Value(constants.Token).(string)
+	token, ok := callerToken(ctx, g.signer, g.serviceName)
 	if !ok {
 		return nil, fmt.Errorf("token not found in context")
 	}
@@ -90,7 +93,7 @@ func (g *messageLanguageGateway) GetMessageLanguages(ctx context.Context, typeSt
 
 	// gọi API với query params
 	url := fmt.Sprintf("/v1/gateway/messages?type=%s&type_id=%s", typeStr, typeID)
-	resp, err := client.Call("GET", url, nil, headers)
+	resp, err := client.Call(ctx, "GET", url, nil, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +114,7 @@ func (g *messageLanguageGateway) GetMessageLanguages(ctx context.Context, typeSt
 
 func (g *messageLanguageGateway) GetMessageLanguage(ctx context.Context, typeStr string, typeID string) (dto.MessageLanguageResponse, error) {
 	// lấy token từ context
-	token, ok := ctx.Value(constants.Token).(string)
+	token, ok := callerToken(ctx, g.signer, g.serviceName)
 	if !ok {
 		return dto.MessageLanguageResponse{}, fmt.Errorf("token not found in context")
 	}
@@ -131,7 +134,7 @@ func (g *messageLanguageGateway) GetMessageLanguage(ctx context.Context, typeStr
 
 	// gọi API với query params
 	url := fmt.Sprintf("/v1/gateway/messages/get-by-language?type=%s&type_id=%s&language_id=%d", typeStr, typeID, appLanguage)
-	resp, err := client.Call("GET", url, nil, headers)
+	resp, err := client.Call(ctx, "GET", url, nil, headers)
 	if err != nil {
 		return dto.MessageLanguageResponse{}, err
 	}
@@ -152,7 +155,7 @@ func (g *messageLanguageGateway) GetMessageLanguage(ctx context.Context, typeStr
 
 func (g *messageLanguageGateway) DeleleByTypeAndTypeID(ctx context.Context, typeStr string, typeID string) error {
 	// lấy token từ context
-	token, ok := ctx.Value(constants.Token).(string)
+	token, ok := callerToken(ctx, g.signer, g.serviceName)
 	if !ok {
 		return fmt.Errorf("token not found in context")
 	}
@@ -167,7 +170,7 @@ func (g *messageLanguageGateway) DeleleByTypeAndTypeID(ctx context.Context, type
 
 	// gọi API với query params
 	url := fmt.Sprintf("/v1/gateway/messages?type=%s&type_id=%s", typeStr, typeID)
-	_, err = client.Call("DELETE", url, nil, headers)
+	_, err = client.Call(ctx, "DELETE", url, nil, headers)
 	if err != nil {
 		return err
 	}
@@ -5,8 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"term-service/internal/gateway/dto"
-	"term-service/pkg/constants"
 	"term-service/pkg/helper"
+	"term-service/pkg/servicetoken"
 
 	"github.com/hashicorp/consul/api"
 )
@@ -19,17 +19,19 @@ type OrganizationGateway interface {
 type organizationGatewayImpl struct {
 	serviceName string
 	consul      *api.Client
+	signer      servicetoken.Signer
 }
 
-func NewOrganizationGateway(serviceName string, consulClient *api.Client) OrganizationGateway {
+func NewOrganizationGateway(serviceName string, consulClient *api.Client, signer servicetoken.Signer) OrganizationGateway {
 	return &organizationGatewayImpl{
 		serviceName: serviceName,
 		consul:      consulClient,
+		signer:      signer,
 	}
 }
 
 func (g *organizationGatewayImpl) GetOrganizationInfo(ctx context.Context, organizationID string) (*dto.OrganizationInfo, error) {
-	token, ok := ctx.Value(constants.Token).(string)
+	token, ok := callerToken(ctx, g.signer, g.serviceName)
 	if !ok {
 		return nil, fmt.Errorf("token not found in context")
 	}
@@ -41,7 +43,7 @@ func (g *organizationGatewayImpl) GetOrganizationInfo(ctx context.Context, organ
 
 	headers := helper.GetHeaders(ctx)
 
-	resp, err := client.Call("GET", "/v1/organization/"+organizationID, nil, headers)
+	resp, err := client.Call(ctx, "GET", "/v1/organization/"+organizationID, nil, headers)
 	if err != nil {
 		return nil, fmt.Errorf("call API get info organization fail: %w", err)
 	}
@@ -61,7 +63,7 @@ func (g *organizationGatewayImpl) GetOrganizationInfo(ctx context.Context, organ
 }
 
 func (g *organizationGatewayImpl) GetAllOrg(ctx context.Context) ([]dto.OrganizationInfo, error) {
-	token, ok := ctx.Value(constants.Token).(string)
+	token, ok := callerToken(ctx, g.signer, g.serviceName)
 	if !ok {
 		return nil, fmt.Errorf("token not found in context")
 	}
@@ -73,7 +75,7 @@ func (g *organizationGatewayImpl) GetAllOrg(ctx context.Context) ([]dto.Organiza
 
 	headers := helper.GetHeaders(ctx)
 
-	resp, err := client.Call("GET", "/v1/gateway/organizations", nil, headers)
+	resp, err := client.Call(ctx, "GET", "/v1/gateway/organizations", nil, headers)
 	if err != nil {
 		return nil, fmt.Errorf("call API get all organization fail: %w", err)
 	}
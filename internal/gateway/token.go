@@ -0,0 +1,27 @@
+package gateway
+
+import (
+	"context"
+	"term-service/pkg/constants"
+	"term-service/pkg/servicetoken"
+	"term-service/pkg/zap"
+)
+
+// callerToken returns the end user's token when the request carries one and
+// otherwise a service token for audience, so service-to-service requests
+// and background jobs never forward an end-user credential.
+func callerToken(ctx context.Context, signer servicetoken.Signer, audience string) (string, bool) {
+	if token, ok := ctx.Value(constants.Token).(string); ok && token != "" {
+		return token, true
+	}
+	if signer == nil {
+		return "", false
+	}
+
+	token, err := signer.Sign(audience)
+	if err != nil {
+		zap.FromContext(ctx).Warnw("sign service token failed", "audience", audience, "error", err.Error())
+		return "", false
+	}
+	return token, true
+}
@@ -5,9 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"term-service/internal/gateway/dto"
-	"term-service/logger"
 	"term-service/pkg/constants"
 	"term-service/pkg/helper"
+	"term-service/pkg/servicetoken"
+	"term-service/pkg/zap"
 
 	"github.com/hashicorp/consul/api"
 )
@@ -22,23 +23,26 @@ type UserGateway interface {
 	GetAuthorInfo(ctx context.Context, userID string) (*User, error)
 	GetCurrentUser(ctx context.Context) (*dto.CurrentUser, error)
 	GetStudentInfo(ctx context.Context, studentID string) (*dto.StudentResponse, error)
+	GetStudentRelations(ctx context.Context, studentID string) (*dto.StudentRelations, error)
 }
 
 type userGatewayImpl struct {
 	serviceName string
 	consul      *api.Client
+	signer      servicetoken.Signer
 }
 
-func NewUserGateway(serviceName string, consulClient *api.Client) UserGateway {
+func NewUserGateway(serviceName string, consulClient *api.Client, signer servicetoken.Signer) UserGateway {
 	return &userGatewayImpl{
 		serviceName: serviceName,
 		consul:      consulClient,
+		signer:      signer,
 	}
 }
 
 // GetAuthorInfo lấy thông tin user từ service user
 func (g *userGatewayImpl) GetAuthorInfo(ctx context.Context, userID string) (*User, error) {
-	token, ok := ctx.Value("token").(string) // hoặc dùng constants.TokenKey
+	token, ok := callerToken(ctx, g.signer, g.serviceName)
 	if !ok || token == "" {
 		return nil, fmt.Errorf("token not exist context")
 	}
@@ -50,7 +54,7 @@ func (g *userGatewayImpl) GetAuthorInfo(ctx context.Context, userID string) (*Us
 
 	headers := helper.GetHeaders(ctx)
 
-	resp, err := client.Call("GET", "/v1/user/"+userID, nil, headers)
+	resp, err := client.Call(ctx, "GET", "/v1/user/"+userID, nil, headers)
 	if err != nil {
 		return nil, fmt.Errorf("Call API user fail: %w", err)
 	}
@@ -65,45 +69,41 @@ func (g *userGatewayImpl) GetAuthorInfo(ctx context.Context, userID string) (*Us
 
 // GetCurrentUser
 func (g *userGatewayImpl) GetCurrentUser(ctx context.Context) (*dto.CurrentUser, error) {
+	// SecuredV2 / Authorize đã load user cho request này
+	if u, ok := ctx.Value(constants.CurrentUserKey).(*dto.CurrentUser); ok && u != nil {
+		return u, nil
+	}
+
 	token, ok := ctx.Value(constants.Token).(string)
 	if !ok {
-		logger.WriteLogEx("warn", "token not found in context", nil)
+		zap.FromContext(ctx).Warnw("token not found in context")
 		return nil, fmt.Errorf("token not found in context")
 	}
 
 	client, err := NewGatewayClient(g.serviceName, token, g.consul, nil)
 	if err != nil {
-		logger.WriteLogEx("error", "init GatewayClient fail", map[string]any{
-			"error": err.Error(),
-		})
+		zap.FromContext(ctx).Errorw("init GatewayClient fail", "error", err.Error())
 		return nil, fmt.Errorf("init GatewayClient fail: %w", err)
 	}
 
 	headers := helper.GetHeaders(ctx)
 
-	resp, err := client.Call("GET", "/v1/user/current-user", nil, headers)
+	resp, err := client.Call(ctx, "GET", "/v1/user/current-user", nil, headers)
 	if err != nil {
-		logger.WriteLogEx("error", "call API user fail", map[string]any{
-			"error": err.Error(),
-		})
+		zap.FromContext(ctx).Errorw("call API user fail", "error", err.Error())
 		return nil, fmt.Errorf("call API user fail: %w", err)
 	}
 
 	// Unmarshal response theo format Gateway
 	var gwResp dto.APIGateWayResponse[dto.CurrentUser]
 	if err := json.Unmarshal(resp, &gwResp); err != nil {
-		logger.WriteLogEx("error", "unmarshal response fail", map[string]any{
-			"error": string(resp),
-		})
+		zap.FromContext(ctx).Errorw("unmarshal response fail", "error", string(resp))
 		return nil, fmt.Errorf("unmarshal response fail: %w", err)
 	}
 
 	// Check status_code trả về
 	if gwResp.StatusCode != 200 {
-		logger.WriteLogEx("warn", "gateway error", map[string]any{
-			"status_code": gwResp.StatusCode,
-			"message":     gwResp.Message,
-		})
+		zap.FromContext(ctx).Warnw("gateway error", "status_code", gwResp.StatusCode, "message", gwResp.Message)
 		return nil, fmt.Errorf("gateway error: %s", gwResp.Message)
 	}
 
@@ -111,7 +111,7 @@ func (g *userGatewayImpl) GetCurrentUser(ctx context.Context) (*dto.CurrentUser,
 }
 
 func (g *userGatewayImpl) GetStudentInfo(ctx context.Context, studentID string) (*dto.StudentResponse, error) {
-	token, ok := ctx.Value(constants.Token).(string)
+	token, ok := callerToken(ctx, g.signer, g.serviceName)
 	if !ok {
 		return nil, fmt.Errorf("token not found in context")
 	}
@@ -123,7 +123,7 @@ func (g *userGatewayImpl) GetStudentInfo(ctx context.Context, studentID string)
 
 	headers := helper.GetHeaders(ctx)
 
-	resp, err := client.Call("GET", "/v1/gateway/students/"+studentID, nil, headers)
+	resp, err := client.Call(ctx, "GET", "/v1/gateway/students/"+studentID, nil, headers)
 	if err != nil {
 		return nil, fmt.Errorf("call API student fail: %w", err)
 	}
@@ -141,3 +141,34 @@ func (g *userGatewayImpl) GetStudentInfo(ctx context.Context, studentID string)
 
 	return &gwResp.Data, nil
 }
+
+// GetStudentRelations lấy guardian / teacher của student
+func (g *userGatewayImpl) GetStudentRelations(ctx context.Context, studentID string) (*dto.StudentRelations, error) {
+	token, ok := callerToken(ctx, g.signer, g.serviceName)
+	if !ok {
+		return nil, fmt.Errorf("token not found in context")
+	}
+
+	client, err := NewGatewayClient(g.serviceName, token, g.consul, nil)
+	if err != nil {
+		return nil, fmt.Errorf("init GatewayClient fail: %w", err)
+	}
+
+	headers := helper.GetHeaders(ctx)
+
+	resp, err := client.Call(ctx, "GET", "/v1/gateway/students/"+studentID+"/relations", nil, headers)
+	if err != nil {
+		return nil, fmt.Errorf("call API student relations fail: %w", err)
+	}
+
+	var gwResp dto.APIGateWayResponse[dto.StudentRelations]
+	if err := json.Unmarshal(resp, &gwResp); err != nil {
+		return nil, fmt.Errorf("unmarshal response fail: %w", err)
+	}
+
+	if gwResp.StatusCode != 200 {
+		return nil, fmt.Errorf("gateway error: %s", gwResp.Message)
+	}
+
+	return &gwResp.Data, nil
+}
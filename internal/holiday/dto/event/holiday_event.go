@@ -0,0 +1,29 @@
+package event
+
+import (
+	"term-service/pkg/publish"
+	"time"
+)
+
+// HolidayData is the payload of holiday.created, holiday.updated and
+// holiday.deleted events. For deletions it is the holiday as it was last
+// stored.
+type HolidayData struct {
+	ID               string            `json:"id"`
+	OrganizationID   string            `json:"organization_id"`
+	Title            string            `json:"title"`
+	Color            string            `json:"color"`
+	PublishedMobile  bool              `json:"published_mobile"`
+	PublishedDesktop bool              `json:"published_desktop"`
+	StartDate        string            `json:"start_date"`
+	EndDate          string            `json:"end_date"`
+	Version          int64             `json:"version"`
+	Audiences        publish.Audiences `json:"audiences"`
+
+	// The published_* flags and windows mirror Audiences for clients that
+	// predate it.
+	PublishMobileAt    *time.Time `json:"publish_mobile_at,omitempty"`
+	UnpublishMobileAt  *time.Time `json:"unpublish_mobile_at,omitempty"`
+	PublishDesktopAt   *time.Time `json:"publish_desktop_at,omitempty"`
+	UnpublishDesktopAt *time.Time `json:"unpublish_desktop_at,omitempty"`
+}
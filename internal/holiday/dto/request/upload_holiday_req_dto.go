@@ -1,5 +1,10 @@
 package request
 
+import (
+	"term-service/pkg/publish"
+	"time"
+)
+
 type UploadHolidayItem struct {
 	ID               string `json:"id,omitempty"`
 	Title            string `json:"title" binding:"required"`
@@ -8,10 +13,27 @@ type UploadHolidayItem struct {
 	PublishedDesktop bool   `json:"published_desktop"`
 	StartDate        string `json:"start_date" binding:"required"`
 	EndDate          string `json:"end_date" binding:"required"`
+	// Audiences lists the channels the holiday is published to. When empty,
+	// the legacy published_* flags and publish_*_at/unpublish_*_at windows
+	// (RFC 3339) below are used instead.
+	Audiences          []publish.Audience `json:"audiences,omitempty"`
+	PublishMobileAt    *time.Time         `json:"publish_mobile_at,omitempty"`
+	UnpublishMobileAt  *time.Time         `json:"unpublish_mobile_at,omitempty"`
+	PublishDesktopAt   *time.Time         `json:"publish_desktop_at,omitempty"`
+	UnpublishDesktopAt *time.Time         `json:"unpublish_desktop_at,omitempty"`
+	// Version is the version the update is based on; required with ID
+	// unless the request sends If-Match.
+	Version int64 `json:"version,omitempty"`
 }
 
 type UploadHolidayRequest struct {
-	LanguageID uint                `json:"language_id" binding:"required"`
-	DeleteIds  []string            `json:"delete_ids"`
-	Holidays   []UploadHolidayItem `json:"holidays"`
+	// OrganizationID lets a super admin upload on behalf of an organization.
+	OrganizationID string              `json:"organization_id,omitempty"`
+	LanguageID     uint                `json:"language_id" binding:"required"`
+	DeleteIds      []string            `json:"delete_ids"`
+	Holidays       []UploadHolidayItem `json:"holidays"`
+
+	// IfMatchVersion comes from the If-Match header and applies when the
+	// request updates a single holiday.
+	IfMatchVersion int64 `json:"-"`
 }
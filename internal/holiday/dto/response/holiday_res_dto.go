@@ -1,6 +1,10 @@
 package response
 
-import "term-service/internal/gateway/dto"
+import (
+	"term-service/internal/gateway/dto"
+	"term-service/pkg/publish"
+	"time"
+)
 
 type HolidayResDTO struct {
 	ID               string                        `json:"id"`
@@ -10,5 +14,14 @@ type HolidayResDTO struct {
 	StartDate        string                        `json:"start_date"`
 	EndDate          string                        `json:"end_date"`
 	CreatedAt        string                        `json:"created_at"`
+	Version          int64                         `json:"version"` // send back when updating
 	MessageLanguages []dto.MessageLanguageResponse `json:"message_languages"`
+	Audiences        publish.Audiences             `json:"audiences"`
+
+	// The published_* flags and windows mirror Audiences for clients that
+	// predate it.
+	PublishMobileAt    *time.Time `json:"publish_mobile_at,omitempty"`
+	UnpublishMobileAt  *time.Time `json:"unpublish_mobile_at,omitempty"`
+	PublishDesktopAt   *time.Time `json:"publish_desktop_at,omitempty"`
+	UnpublishDesktopAt *time.Time `json:"unpublish_desktop_at,omitempty"`
 }
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 	"term-service/internal/holiday/dto/request"
 	"term-service/internal/holiday/service"
@@ -10,17 +11,26 @@ import (
 )
 
 type HolidayHandler struct {
-	service service.HolidayService
+	service        service.HolidayService
+	maxUploadItems int
 }
 
-func NewHandler(s service.HolidayService) *HolidayHandler {
-	return &HolidayHandler{service: s}
+// NewHandler builds the holiday handler. maxUploadItems caps the holidays
+// plus deletions in one upload; zero means no cap.
+func NewHandler(s service.HolidayService, maxUploadItems int) *HolidayHandler {
+	return &HolidayHandler{service: s, maxUploadItems: maxUploadItems}
 }
 
 func (h *HolidayHandler) GetHolidays4Web(c *gin.Context) {
-	holidays, err := h.service.GetHolidays4Web(c.Request.Context())
+	asOf, err := helper.AsOf(c)
 	if err != nil {
-		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInvalidOperation)
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	holidays, err := h.service.GetHolidays4Web(c.Request.Context(), helper.RequestedOrganizationID(c), asOf)
+	if err != nil {
+		helper.SendServiceError(c, http.StatusInternalServerError, err, helper.ErrInvalidOperation)
 		return
 	}
 
@@ -33,9 +43,22 @@ func (h *HolidayHandler) UploadHolidays(c *gin.Context) {
 		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
 		return
 	}
+	if items := len(req.Holidays) + len(req.DeleteIds); h.maxUploadItems > 0 && items > h.maxUploadItems {
+		helper.SendError(c, http.StatusRequestEntityTooLarge, fmt.Errorf("upload has %d items, at most %d allowed", items, h.maxUploadItems), helper.ErrPayloadTooLarge)
+		return
+	}
+	if req.OrganizationID == "" {
+		req.OrganizationID = helper.RequestedOrganizationID(c)
+	}
+	ifMatch, err := helper.IfMatchVersion(c)
+	if err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+	req.IfMatchVersion = ifMatch
 
 	if err := h.service.UploadHolidays(c.Request.Context(), req); err != nil {
-		helper.SendError(c, http.StatusInternalServerError, err, err.Error())
+		helper.SendServiceError(c, http.StatusInternalServerError, err, err.Error())
 		return
 	}
 
@@ -1,21 +1,27 @@
 package mapper
 
 import (
+	"term-service/internal/holiday/dto/event"
+	"term-service/internal/holiday/dto/request"
 	"term-service/internal/holiday/dto/response"
 	"term-service/internal/holiday/model"
 	"term-service/pkg/helper"
+	"term-service/pkg/publish"
 )
 
 func MapHolidayToResDTO(holiday *model.Holiday) response.HolidayResDTO {
-	return response.HolidayResDTO{
-		ID:               holiday.ID.Hex(),
-		Color:            holiday.Color,
-		PublishedMobile:  holiday.PublishedMobile,
-		PublishedDesktop: holiday.PublishedDesktop,
-		StartDate:        helper.FormatDate(holiday.StartDate),
-		EndDate:          helper.FormatDate(holiday.EndDate),
-		CreatedAt:        helper.FormatDate(holiday.CreatedAt),
+	res := response.HolidayResDTO{
+		ID:        holiday.ID.Hex(),
+		Color:     holiday.Color,
+		StartDate: helper.FormatDate(holiday.StartDate),
+		EndDate:   helper.FormatDate(holiday.EndDate),
+		CreatedAt: helper.FormatDate(holiday.CreatedAt),
+		Version:   holiday.Version,
+		Audiences: nonNilAudiences(holiday.Audiences),
 	}
+	res.PublishedMobile, res.PublishMobileAt, res.UnpublishMobileAt = holiday.Audiences.Flag(publish.Mobile)
+	res.PublishedDesktop, res.PublishDesktopAt, res.UnpublishDesktopAt = holiday.Audiences.Flag(publish.Desktop)
+	return res
 }
 
 func MapHolidayListToResDTO(holidays []*model.Holiday) []response.HolidayResDTO {
@@ -25,3 +31,39 @@ func MapHolidayListToResDTO(holidays []*model.Holiday) []response.HolidayResDTO
 	}
 	return result
 }
+
+func MapHolidayToEventData(holiday *model.Holiday) event.HolidayData {
+	data := event.HolidayData{
+		ID:             holiday.ID.Hex(),
+		OrganizationID: holiday.OrganizationID,
+		Title:          holiday.Title,
+		Color:          holiday.Color,
+		StartDate:      helper.FormatDate(holiday.StartDate),
+		EndDate:        helper.FormatDate(holiday.EndDate),
+		Version:        holiday.Version,
+		Audiences:      nonNilAudiences(holiday.Audiences),
+	}
+	data.PublishedMobile, data.PublishMobileAt, data.UnpublishMobileAt = holiday.Audiences.Flag(publish.Mobile)
+	data.PublishedDesktop, data.PublishDesktopAt, data.UnpublishDesktopAt = holiday.Audiences.Flag(publish.Desktop)
+	return data
+}
+
+// MapUploadHolidayItemToAudiences returns the audiences of an uploaded
+// holiday, falling back to the legacy per-channel flags when none are
+// listed.
+func MapUploadHolidayItemToAudiences(h request.UploadHolidayItem) publish.Audiences {
+	if len(h.Audiences) > 0 {
+		return h.Audiences
+	}
+	return publish.Audiences(nil).
+		WithFlag(publish.Mobile, h.PublishedMobile, h.PublishMobileAt, h.UnpublishMobileAt).
+		WithFlag(publish.Desktop, h.PublishedDesktop, h.PublishDesktopAt, h.UnpublishDesktopAt)
+}
+
+// nonNilAudiences keeps "audiences" an array in JSON.
+func nonNilAudiences(as publish.Audiences) publish.Audiences {
+	if as == nil {
+		return publish.Audiences{}
+	}
+	return as
+}
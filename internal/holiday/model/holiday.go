@@ -1,20 +1,25 @@
 package model
 
 import (
+	"term-service/pkg/objectid"
+	"term-service/pkg/publish"
 	"time"
-
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type Holiday struct {
-	ID               primitive.ObjectID `bson:"_id,omitempty"`
-	OrganizationID   string             `bson:"organization_id"`
-	Title            string             `bson:"title"`
-	Color            string             `bson:"color"`
-	PublishedMobile  bool               `bson:"published_mobile"`
-	PublishedDesktop bool               `bson:"published_desktop"`
-	StartDate        time.Time          `bson:"start_date"`
-	EndDate          time.Time          `bson:"end_date"`
-	CreatedAt        time.Time          `bson:"created_at"`
-	UpdatedAt        time.Time          `bson:"updated_at"`
+	ID             objectid.ID       `bson:"_id,omitempty" gorm:"primaryKey;size:24"`
+	OrganizationID string            `bson:"organization_id" gorm:"size:64;index:idx_holidays_org_start,priority:1"`
+	Title          string            `bson:"title"`
+	Color          string            `bson:"color" gorm:"size:32"`
+	Audiences      publish.Audiences `bson:"audiences"` // channels the holiday is published to
+	StartDate      time.Time         `bson:"start_date" gorm:"index:idx_holidays_org_start,priority:2"`
+	EndDate        time.Time         `bson:"end_date"`
+	CreatedAt      time.Time         `bson:"created_at"`
+	UpdatedAt      time.Time         `bson:"updated_at"`
+	Version        int64             `bson:"version" gorm:"not null;default:1"` // bumped by every update
+}
+
+// VisibleOn reports whether the holiday is shown on channel at now.
+func (h *Holiday) VisibleOn(channel string, now time.Time) bool {
+	return h.Audiences.VisibleOn(channel, now)
 }
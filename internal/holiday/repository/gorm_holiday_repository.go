@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"term-service/internal/holiday/model"
+	"term-service/pkg/db"
+	"term-service/pkg/db/txn"
+	"term-service/pkg/objectid"
+	"term-service/pkg/publish"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type gormHolidayRepository struct {
+	db *gorm.DB
+}
+
+// NewGormHolidayRepository returns a HolidayRepository backed by MySQL (or
+// any other GORM dialect).
+func NewGormHolidayRepository(db *gorm.DB) HolidayRepository {
+	return &gormHolidayRepository{db}
+}
+
+func (r *gormHolidayRepository) Create(ctx context.Context, holiday *model.Holiday) (*model.Holiday, error) {
+	now := time.Now()
+	if holiday.ID.IsZero() {
+		holiday.ID = objectid.New()
+	}
+	holiday.CreatedAt = now
+	holiday.UpdatedAt = now
+	holiday.Version = 1
+
+	if err := txn.Gorm(ctx, r.db).Create(holiday).Error; err != nil {
+		return nil, err
+	}
+	return holiday, nil
+}
+
+func (r *gormHolidayRepository) GetByID(ctx context.Context, id string) (*model.Holiday, error) {
+	var holiday model.Holiday
+	err := txn.Gorm(ctx, r.db).Where("id = ?", id).First(&holiday).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, db.ErrNotFound
+		}
+		return nil, err
+	}
+	return &holiday, nil
+}
+
+func (r *gormHolidayRepository) Update(ctx context.Context, id string, updated *model.Holiday) error {
+	updated.UpdatedAt = time.Now()
+
+	result := txn.Gorm(ctx, r.db).Model(&model.Holiday{}).Where("id = ? AND version = ?", id, updated.Version).Updates(map[string]interface{}{
+		"title":      updated.Title,
+		"start_date": updated.StartDate,
+		"color":      updated.Color,
+		"audiences":  updated.Audiences,
+		"end_date":   updated.EndDate,
+		"updated_at": updated.UpdatedAt,
+		"version":    gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		var exists int64
+		if err := txn.Gorm(ctx, r.db).Model(&model.Holiday{}).Where("id = ?", id).Count(&exists).Error; err != nil {
+			return err
+		}
+		if exists == 0 {
+			return db.ErrNotFound
+		}
+		return db.ErrVersionConflict
+	}
+	updated.Version++
+	return nil
+}
+
+func (r *gormHolidayRepository) Delete(ctx context.Context, id string) error {
+	result := txn.Gorm(ctx, r.db).Where("id = ?", id).Delete(&model.Holiday{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormHolidayRepository) GetAll(ctx context.Context) ([]*model.Holiday, error) {
+	var holidays []*model.Holiday
+	err := txn.Gorm(ctx, r.db).Find(&holidays).Error
+	return holidays, err
+}
+
+func (r *gormHolidayRepository) GetAllByOrgID(ctx context.Context, orgID string) ([]*model.Holiday, error) {
+	var holidays []*model.Holiday
+	err := txn.Gorm(ctx, r.db).Where("organization_id = ?", orgID).Find(&holidays).Error
+	return holidays, err
+}
+
+func (r *gormHolidayRepository) GetAllVisibleByOrgID(ctx context.Context, orgID string, channel string) ([]*model.Holiday, error) {
+	var candidates []*model.Holiday
+	err := publish.GormCandidates(txn.Gorm(ctx, r.db).Where("organization_id = ?", orgID), channel).
+		Order("created_at ASC").
+		Find(&candidates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	holidays := make([]*model.Holiday, 0, len(candidates))
+	for _, h := range candidates {
+		if h.VisibleOn(channel, now) {
+			holidays = append(holidays, h)
+		}
+	}
+	return holidays, nil
+}
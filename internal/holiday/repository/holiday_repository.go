@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"term-service/internal/holiday/model"
+	"term-service/pkg/db"
+	"term-service/pkg/publish"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -19,7 +21,9 @@ type HolidayRepository interface {
 	Delete(ctx context.Context, id string) error
 	GetAll(ctx context.Context) ([]*model.Holiday, error)
 	GetAllByOrgID(ctx context.Context, orgID string) ([]*model.Holiday, error)
-	GetAllByOrgID4App(ctx context.Context, orgID string) ([]*model.Holiday, error)
+	// GetAllVisibleByOrgID lists the organization's holidays visible on
+	// channel now, oldest first.
+	GetAllVisibleByOrgID(ctx context.Context, orgID string, channel string) ([]*model.Holiday, error)
 }
 
 type holidayRepository struct {
@@ -35,6 +39,7 @@ func (r *holidayRepository) Create(ctx context.Context, holiday *model.Holiday)
 	now := time.Now()
 	holiday.CreatedAt = now
 	holiday.UpdatedAt = now
+	holiday.Version = 1
 
 	_, err := r.collection.InsertOne(ctx, holiday)
 	if err != nil {
@@ -53,12 +58,16 @@ func (r *holidayRepository) GetByID(ctx context.Context, id string) (*model.Holi
 	var holiday model.Holiday
 	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&holiday)
 	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, db.ErrNotFound
+		}
 		return nil, err
 	}
 	return &holiday, nil
 }
 
-// Update modifies an existing holiday
+// Update modifies an existing holiday if it is still at updated.Version and
+// bumps the version.
 func (r *holidayRepository) Update(ctx context.Context, id string, updated *model.Holiday) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -69,23 +78,31 @@ func (r *holidayRepository) Update(ctx context.Context, id string, updated *mode
 
 	update := bson.M{
 		"$set": bson.M{
-			"title":             updated.Title,
-			"start_date":        updated.StartDate,
-			"color":             updated.Color,
-			"published_mobile":  updated.PublishedMobile,
-			"published_desktop": updated.PublishedDesktop,
-			"end_date":          updated.EndDate,
-			"updated_at":        updated.UpdatedAt,
+			"title":      updated.Title,
+			"start_date": updated.StartDate,
+			"color":      updated.Color,
+			"audiences":  updated.Audiences,
+			"end_date":   updated.EndDate,
+			"updated_at": updated.UpdatedAt,
 		},
+		"$inc": bson.M{"version": 1},
 	}
 
-	result, err := r.collection.UpdateByID(ctx, objectID, update)
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID, "version": updated.Version}, update)
 	if err != nil {
 		return err
 	}
 	if result.MatchedCount == 0 {
-		return mongo.ErrNoDocuments
+		exists, err := r.collection.CountDocuments(ctx, bson.M{"_id": objectID})
+		if err != nil {
+			return err
+		}
+		if exists == 0 {
+			return db.ErrNotFound
+		}
+		return db.ErrVersionConflict
 	}
+	updated.Version++
 	return nil
 }
 
@@ -101,7 +118,7 @@ func (r *holidayRepository) Delete(ctx context.Context, id string) error {
 		return err
 	}
 	if result.DeletedCount == 0 {
-		return mongo.ErrNoDocuments
+		return db.ErrNotFound
 	}
 	return nil
 }
@@ -144,11 +161,8 @@ func (r *holidayRepository) GetAllByOrgID(ctx context.Context, orgID string) ([]
 	return holidays, nil
 }
 
-func (r *holidayRepository) GetAllByOrgID4App(ctx context.Context, orgID string) ([]*model.Holiday, error) {
-	filter := bson.M{
-		"organization_id":  orgID,
-		"published_mobile": true,
-	}
+func (r *holidayRepository) GetAllVisibleByOrgID(ctx context.Context, orgID string, channel string) ([]*model.Holiday, error) {
+	filter := publish.MongoFilter(bson.M{"organization_id": orgID}, channel, time.Now())
 
 	// sort theo created_at ASC
 	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
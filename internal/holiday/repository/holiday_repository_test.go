@@ -0,0 +1,32 @@
+package repository_test
+
+import (
+	"term-service/internal/holiday/repository"
+	"term-service/internal/holiday/repository/repositorytest"
+	"term-service/pkg/db/dbtest"
+	"testing"
+)
+
+func TestMongoHolidayRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.HolidayRepository {
+		return repository.NewHolidayRepository(dbtest.NewMongo(t).Collection("holidays"))
+	})
+}
+
+func TestGormSQLiteHolidayRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.HolidayRepository {
+		return repository.NewGormHolidayRepository(dbtest.NewSQLite(t))
+	})
+}
+
+func TestGormMySQLHolidayRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.HolidayRepository {
+		return repository.NewGormHolidayRepository(dbtest.NewMySQL(t))
+	})
+}
+
+func TestMemoryHolidayRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.HolidayRepository {
+		return repository.NewMemoryHolidayRepository()
+	})
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"term-service/internal/holiday/model"
+	"term-service/pkg/metrics"
+	"term-service/pkg/tracing"
+	"time"
+)
+
+const repoName = "holiday"
+
+// instrumentedHolidayRepository records timings and a tracing span for every repository call.
+type instrumentedHolidayRepository struct {
+	next HolidayRepository
+}
+
+func NewInstrumentedHolidayRepository(next HolidayRepository) HolidayRepository {
+	return &instrumentedHolidayRepository{next}
+}
+
+func (r *instrumentedHolidayRepository) instrument(ctx context.Context, method string) (context.Context, func(*error)) {
+	start := time.Now()
+	ctx, span := tracing.StartSpan(ctx, "HolidayRepository."+method)
+	return ctx, func(err *error) {
+		metrics.ObserveRepository(repoName, method, start, err)
+		tracing.EndSpan(span, *err)
+	}
+}
+
+func (r *instrumentedHolidayRepository) Create(ctx context.Context, holiday *model.Holiday) (res *model.Holiday, err error) {
+	ctx, done := r.instrument(ctx, "Create")
+	defer done(&err)
+	return r.next.Create(ctx, holiday)
+}
+
+func (r *instrumentedHolidayRepository) GetByID(ctx context.Context, id string) (res *model.Holiday, err error) {
+	ctx, done := r.instrument(ctx, "GetByID")
+	defer done(&err)
+	return r.next.GetByID(ctx, id)
+}
+
+func (r *instrumentedHolidayRepository) Update(ctx context.Context, id string, holiday *model.Holiday) (err error) {
+	ctx, done := r.instrument(ctx, "Update")
+	defer done(&err)
+	return r.next.Update(ctx, id, holiday)
+}
+
+func (r *instrumentedHolidayRepository) Delete(ctx context.Context, id string) (err error) {
+	ctx, done := r.instrument(ctx, "Delete")
+	defer done(&err)
+	return r.next.Delete(ctx, id)
+}
+
+func (r *instrumentedHolidayRepository) GetAll(ctx context.Context) (res []*model.Holiday, err error) {
+	ctx, done := r.instrument(ctx, "GetAll")
+	defer done(&err)
+	return r.next.GetAll(ctx)
+}
+
+func (r *instrumentedHolidayRepository) GetAllByOrgID(ctx context.Context, orgID string) (res []*model.Holiday, err error) {
+	ctx, done := r.instrument(ctx, "GetAllByOrgID")
+	defer done(&err)
+	return r.next.GetAllByOrgID(ctx, orgID)
+}
+
+func (r *instrumentedHolidayRepository) GetAllVisibleByOrgID(ctx context.Context, orgID string, channel string) (res []*model.Holiday, err error) {
+	ctx, done := r.instrument(ctx, "GetAllVisibleByOrgID")
+	defer done(&err)
+	return r.next.GetAllVisibleByOrgID(ctx, orgID, channel)
+}
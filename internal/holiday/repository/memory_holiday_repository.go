@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"term-service/internal/holiday/model"
+	"term-service/pkg/db"
+	"term-service/pkg/objectid"
+	"time"
+)
+
+type memoryHolidayRepository struct {
+	mu       sync.RWMutex
+	holidays map[objectid.ID]model.Holiday
+}
+
+// NewMemoryHolidayRepository returns a HolidayRepository kept in process
+// memory, for tests and local runs without a database.
+func NewMemoryHolidayRepository() HolidayRepository {
+	return &memoryHolidayRepository{holidays: make(map[objectid.ID]model.Holiday)}
+}
+
+func (r *memoryHolidayRepository) Create(ctx context.Context, holiday *model.Holiday) (*model.Holiday, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if holiday.ID.IsZero() {
+		holiday.ID = objectid.New()
+	}
+	holiday.CreatedAt = now
+	holiday.UpdatedAt = now
+	holiday.Version = 1
+
+	r.holidays[holiday.ID] = *holiday
+	return holiday, nil
+}
+
+func (r *memoryHolidayRepository) GetByID(ctx context.Context, id string) (*model.Holiday, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	holiday, ok := r.holidays[objectid.ID(id)]
+	if !ok {
+		return nil, db.ErrNotFound
+	}
+	return &holiday, nil
+}
+
+func (r *memoryHolidayRepository) Update(ctx context.Context, id string, updated *model.Holiday) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.holidays[objectid.ID(id)]
+	if !ok {
+		return db.ErrNotFound
+	}
+	if existing.Version != updated.Version {
+		return db.ErrVersionConflict
+	}
+
+	updated.UpdatedAt = time.Now()
+	updated.Version++
+	existing.Title = updated.Title
+	existing.StartDate = updated.StartDate
+	existing.Color = updated.Color
+	existing.Audiences = updated.Audiences
+	existing.EndDate = updated.EndDate
+	existing.UpdatedAt = updated.UpdatedAt
+	existing.Version = updated.Version
+	r.holidays[existing.ID] = existing
+	return nil
+}
+
+func (r *memoryHolidayRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.holidays[objectid.ID(id)]; !ok {
+		return db.ErrNotFound
+	}
+	delete(r.holidays, objectid.ID(id))
+	return nil
+}
+
+func (r *memoryHolidayRepository) GetAll(ctx context.Context) ([]*model.Holiday, error) {
+	return r.filter(func(*model.Holiday) bool { return true }), nil
+}
+
+func (r *memoryHolidayRepository) GetAllByOrgID(ctx context.Context, orgID string) ([]*model.Holiday, error) {
+	return r.filter(func(h *model.Holiday) bool { return h.OrganizationID == orgID }), nil
+}
+
+func (r *memoryHolidayRepository) GetAllVisibleByOrgID(ctx context.Context, orgID string, channel string) ([]*model.Holiday, error) {
+	now := time.Now()
+	return r.filter(func(h *model.Holiday) bool { return h.OrganizationID == orgID && h.VisibleOn(channel, now) }), nil
+}
+
+// filter returns copies of the matching holidays, oldest first.
+func (r *memoryHolidayRepository) filter(match func(*model.Holiday) bool) []*model.Holiday {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	holidays := make([]*model.Holiday, 0)
+	for _, h := range r.holidays {
+		h := h
+		if match(&h) {
+			holidays = append(holidays, &h)
+		}
+	}
+	sort.SliceStable(holidays, func(i, j int) bool { return holidays[i].CreatedAt.Before(holidays[j].CreatedAt) })
+	return holidays
+}
@@ -0,0 +1,159 @@
+// Package repositorytest holds the behaviour every HolidayRepository backend
+// must share. Backend tests call Run with a constructor for an empty store.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"term-service/internal/holiday/model"
+	"term-service/internal/holiday/repository"
+	"term-service/pkg/db"
+	"term-service/pkg/objectid"
+	"term-service/pkg/publish"
+	"testing"
+	"time"
+)
+
+// Run executes the conformance suite; newRepo must return an empty repository.
+func Run(t *testing.T, newRepo func(t *testing.T) repository.HolidayRepository) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo repository.HolidayRepository)
+	}{
+		{"CreateAndGetByID", testCreateAndGetByID},
+		{"UpdateAndDelete", testUpdateAndDelete},
+		{"ListByOrg", testListByOrg},
+		{"ScheduledPublishing", testScheduledPublishing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func create(t *testing.T, repo repository.HolidayRepository, holiday *model.Holiday) *model.Holiday {
+	t.Helper()
+	created, err := repo.Create(context.Background(), holiday)
+	if err != nil {
+		t.Fatalf("Create(%s): %v", holiday.Title, err)
+	}
+	return created
+}
+
+func testCreateAndGetByID(t *testing.T, repo repository.HolidayRepository) {
+	ctx := context.Background()
+	created := create(t, repo, &model.Holiday{
+		OrganizationID: "org-1",
+		Title:          "Tet",
+		Color:          "#ff0000",
+		Audiences:      publish.Audiences{{Channel: publish.Mobile}},
+		StartDate:      date(2025, 1, 27),
+		EndDate:        date(2025, 2, 2),
+	})
+	if created.ID.IsZero() {
+		t.Fatal("Create did not assign an ID")
+	}
+
+	got, err := repo.GetByID(ctx, created.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Title != "Tet" || got.OrganizationID != "org-1" || len(got.Audiences) != 1 || got.Audiences[0].Channel != publish.Mobile || !got.StartDate.Equal(date(2025, 1, 27)) {
+		t.Fatalf("GetByID returned %+v", got)
+	}
+
+	if _, err := repo.GetByID(ctx, objectid.New().Hex()); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("GetByID(unknown) error = %v, want db.ErrNotFound", err)
+	}
+}
+
+func testUpdateAndDelete(t *testing.T, repo repository.HolidayRepository) {
+	ctx := context.Background()
+	created := create(t, repo, &model.Holiday{OrganizationID: "org-1", Title: "Tet", StartDate: date(2025, 1, 27), EndDate: date(2025, 2, 2)})
+
+	created.Title = "Lunar New Year"
+	created.Audiences = publish.Audiences{{Channel: publish.Desktop}, {Channel: publish.Kiosk}}
+	if err := repo.Update(ctx, created.ID.Hex(), created); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	got, err := repo.GetByID(ctx, created.ID.Hex())
+	if err != nil || got.Title != "Lunar New Year" || len(got.Audiences) != 2 || got.Version != 2 {
+		t.Fatalf("Update not persisted: %+v, %v", got, err)
+	}
+	stale := *got
+	stale.Version = 1
+	if err := repo.Update(ctx, created.ID.Hex(), &stale); !errors.Is(err, db.ErrVersionConflict) {
+		t.Fatalf("Update(stale version) error = %v, want db.ErrVersionConflict", err)
+	}
+	if err := repo.Update(ctx, objectid.New().Hex(), created); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("Update(unknown) error = %v, want db.ErrNotFound", err)
+	}
+
+	if err := repo.Delete(ctx, created.ID.Hex()); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := repo.Delete(ctx, created.ID.Hex()); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("second Delete error = %v, want db.ErrNotFound", err)
+	}
+}
+
+func testListByOrg(t *testing.T, repo repository.HolidayRepository) {
+	ctx := context.Background()
+	visible := create(t, repo, &model.Holiday{OrganizationID: "org-1", Title: "Visible", Audiences: publish.Audiences{{Channel: publish.Mobile}}, StartDate: date(2025, 1, 27), EndDate: date(2025, 2, 2)})
+	create(t, repo, &model.Holiday{OrganizationID: "org-1", Title: "Hidden", StartDate: date(2025, 4, 30), EndDate: date(2025, 5, 1)})
+	create(t, repo, &model.Holiday{OrganizationID: "org-2", Title: "Other", Audiences: publish.Audiences{{Channel: publish.Mobile}}, StartDate: date(2025, 1, 27), EndDate: date(2025, 2, 2)})
+
+	all, err := repo.GetAllByOrgID(ctx, "org-1")
+	if err != nil || len(all) != 2 {
+		t.Fatalf("GetAllByOrgID = %d holidays, %v; want 2", len(all), err)
+	}
+
+	app, err := repo.GetAllVisibleByOrgID(ctx, "org-1", publish.Mobile)
+	if err != nil || len(app) != 1 || app[0].ID != visible.ID {
+		t.Fatalf("GetAllVisibleByOrgID(mobile) = %+v, %v; want only %s", app, err, visible.ID)
+	}
+	if kiosk, err := repo.GetAllVisibleByOrgID(ctx, "org-1", publish.Kiosk); err != nil || len(kiosk) != 0 {
+		t.Fatalf("GetAllVisibleByOrgID(kiosk) = %+v, %v; want none", kiosk, err)
+	}
+
+	everything, err := repo.GetAll(ctx)
+	if err != nil || len(everything) != 3 {
+		t.Fatalf("GetAll = %d holidays, %v; want 3", len(everything), err)
+	}
+}
+
+func testScheduledPublishing(t *testing.T, repo repository.HolidayRepository) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	open := create(t, repo, &model.Holiday{OrganizationID: "org-1", Title: "Open", Audiences: publish.Audiences{{Channel: publish.Mobile, PublishAt: &past}}, StartDate: date(2025, 1, 27), EndDate: date(2025, 2, 2)})
+	later := create(t, repo, &model.Holiday{OrganizationID: "org-1", Title: "Later", Audiences: publish.Audiences{{Channel: publish.Mobile, PublishAt: &future}}, StartDate: date(2026, 2, 16), EndDate: date(2026, 2, 22)})
+	create(t, repo, &model.Holiday{OrganizationID: "org-1", Title: "Withdrawn", Audiences: publish.Audiences{{Channel: publish.Mobile, UnpublishAt: &past}}, StartDate: date(2024, 2, 8), EndDate: date(2024, 2, 14)})
+
+	app, err := repo.GetAllVisibleByOrgID(ctx, "org-1", publish.Mobile)
+	if err != nil || len(app) != 1 || app[0].ID != open.ID {
+		t.Fatalf("GetAllVisibleByOrgID(mobile) = %+v, %v; want only %s", app, err, open.ID)
+	}
+
+	later.Audiences = publish.Audiences{{Channel: publish.Mobile, UnpublishAt: &future}}
+	if err := repo.Update(ctx, later.ID.Hex(), later); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	got, err := repo.GetByID(ctx, later.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if a, ok := got.Audiences.Find(publish.Mobile); !ok || a.PublishAt != nil || a.UnpublishAt == nil || !a.UnpublishAt.Equal(future) {
+		t.Fatalf("mobile audience = %+v, want window open..%v", got.Audiences, future)
+	}
+	if app, err := repo.GetAllVisibleByOrgID(ctx, "org-1", publish.Mobile); err != nil || len(app) != 2 {
+		t.Fatalf("GetAllVisibleByOrgID(mobile) after update = %d holidays, %v; want 2", len(app), err)
+	}
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"term-service/internal/holiday/model"
+	"term-service/pkg/revision"
+)
+
+// revisionedHolidayRepository records holiday history the way
+// revisionedTermRepository does for terms.
+type revisionedHolidayRepository struct {
+	HolidayRepository
+	revisions revision.Store
+}
+
+func NewRevisionedHolidayRepository(next HolidayRepository, revisions revision.Store) HolidayRepository {
+	return &revisionedHolidayRepository{HolidayRepository: next, revisions: revisions}
+}
+
+func (r *revisionedHolidayRepository) Create(ctx context.Context, holiday *model.Holiday) (*model.Holiday, error) {
+	created, err := r.HolidayRepository.Create(ctx, holiday)
+	if err != nil {
+		return nil, err
+	}
+	return created, r.record(ctx, created, false)
+}
+
+func (r *revisionedHolidayRepository) Update(ctx context.Context, id string, holiday *model.Holiday) error {
+	if err := r.HolidayRepository.Update(ctx, id, holiday); err != nil {
+		return err
+	}
+	stored, err := r.HolidayRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return r.record(ctx, stored, false)
+}
+
+func (r *revisionedHolidayRepository) Delete(ctx context.Context, id string) error {
+	existing, err := r.HolidayRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.HolidayRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	existing.Version++
+	return r.record(ctx, existing, true)
+}
+
+func (r *revisionedHolidayRepository) record(ctx context.Context, holiday *model.Holiday, deleted bool) error {
+	rev, err := revision.New(revision.Holiday, holiday.ID.Hex(), holiday.OrganizationID, holiday.Version, deleted, holiday)
+	if err != nil {
+		return err
+	}
+	return r.revisions.Append(ctx, rev)
+}
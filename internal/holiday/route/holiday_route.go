@@ -1,21 +1,25 @@
 package route
 
 import (
+	"term-service/internal/gateway"
 	"term-service/internal/holiday/handler"
+	"term-service/internal/policy"
 	"term-service/internal/term/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterHolidayRoutes(r *gin.Engine, h *handler.HolidayHandler) {
+// authLimit throttles per user and organization and runs after authorization
+// has resolved them; idempotent guards uploads against retried requests.
+func RegisterHolidayRoutes(r *gin.Engine, h *handler.HolidayHandler, userGW gateway.UserGateway, authLimit, idempotent gin.HandlerFunc) {
 	// Admin routes
 	adminGroup := r.Group("/api/v1/admin")
 	adminGroup.Use(middleware.Secured())
 	{
 		holidaysAdmin := adminGroup.Group("/holidays")
 		{
-			holidaysAdmin.POST("", h.UploadHolidays)
-			holidaysAdmin.GET("", h.GetHolidays4Web)
+			holidaysAdmin.POST("", middleware.Authorize(userGW, policy.HolidayWrite, middleware.AdminTarget), authLimit, idempotent, h.UploadHolidays)
+			holidaysAdmin.GET("", middleware.Authorize(userGW, policy.HolidayList, middleware.AdminTarget), authLimit, h.GetHolidays4Web)
 		}
 	}
 }
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"term-service/internal/holiday/model"
+	"term-service/pkg/revision"
+	"time"
+)
+
+// holidaysAsOf rebuilds the organization's holidays as they were at asOf,
+// oldest first.
+func (s *holidayService) holidaysAsOf(ctx context.Context, orgID string, asOf time.Time) ([]*model.Holiday, error) {
+	revs, err := s.revisions.AsOf(ctx, revision.Holiday, orgID, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("get holiday revisions as of %s: %w", asOf.Format(time.RFC3339), err)
+	}
+
+	holidays := make([]*model.Holiday, 0, len(revs))
+	for i := range revs {
+		var holiday model.Holiday
+		if err := revs[i].Decode(&holiday); err != nil {
+			return nil, err
+		}
+		holidays = append(holidays, &holiday)
+	}
+	sort.SliceStable(holidays, func(i, j int) bool {
+		return holidays[i].CreatedAt.Before(holidays[j].CreatedAt)
+	})
+	return holidays, nil
+}
@@ -11,30 +11,41 @@ import (
 	"term-service/internal/holiday/mapper"
 	"term-service/internal/holiday/model"
 	"term-service/internal/holiday/repository"
+	"term-service/internal/policy"
 	"term-service/pkg/constants"
+	"term-service/pkg/db"
+	"term-service/pkg/db/txn"
 	"term-service/pkg/helper"
 	pkg_helpder "term-service/pkg/helper"
+	"term-service/pkg/objectid"
+	"term-service/pkg/outbox"
+	"term-service/pkg/revision"
 	"time"
-
-	"go.mongodb.org/mongo-driver/bson/primitive"
-	"gorm.io/gorm"
 )
 
 type HolidayService interface {
 	UploadHolidays(ctx context.Context, req request.UploadHolidayRequest) error
-	GetHolidays4Web(ctx context.Context) (*response.GetHolidays4WebResDTO, error)
+	// GetHolidays4Web lists the organization's holidays, as they were at
+	// asOf unless it is zero.
+	GetHolidays4Web(ctx context.Context, organizationID string, asOf time.Time) (*response.GetHolidays4WebResDTO, error)
 }
 
 type holidayService struct {
 	repo                   repository.HolidayRepository
+	revisions              revision.Store
+	tx                     txn.Transactor
+	events                 outbox.Recorder
 	userGateway            gateway.UserGateway
 	orgGateway             gateway.OrganizationGateway
 	messageLanguageGateway gateway.MessageLanguageGateway
 }
 
-func NewHolidayService(repo repository.HolidayRepository, userGateway gateway.UserGateway, orgGateway gateway.OrganizationGateway, messageLanguageGateway gateway.MessageLanguageGateway) HolidayService {
+func NewHolidayService(repo repository.HolidayRepository, revisions revision.Store, tx txn.Transactor, events outbox.Recorder, userGateway gateway.UserGateway, orgGateway gateway.OrganizationGateway, messageLanguageGateway gateway.MessageLanguageGateway) HolidayService {
 	return &holidayService{
 		repo:                   repo,
+		revisions:              revisions,
+		tx:                     tx,
+		events:                 events,
 		userGateway:            userGateway,
 		orgGateway:             orgGateway,
 		messageLanguageGateway: messageLanguageGateway,
@@ -48,23 +59,44 @@ func (s *holidayService) UploadHolidays(ctx context.Context, req request.UploadH
 		return fmt.Errorf("get current user info failed")
 	}
 
-	// check is super admin & check org admin
-	if currentUser.IsSuperAdmin || currentUser.OrganizationAdmin.ID == "" {
-		return fmt.Errorf("access denied: super admin cannot perform this action")
+	organizationAdminID := policy.TargetOrganization(currentUser, req.OrganizationID)
+	if err := policy.Authorize(currentUser, policy.HolidayWrite, organizationAdminID); err != nil {
+		return err
 	}
-	organizationAdminID := currentUser.OrganizationAdmin.ID
+
+	// Check every delete and every version before the first write, so a bad
+	// or stale item leaves the whole upload unapplied.
 
 	// 1. Handle delete
+	deletes := make([]*model.Holiday, 0, len(req.DeleteIds))
 	for _, id := range req.DeleteIds {
-		err := s.repo.Delete(ctx, id)
+		existing, err := s.repo.GetByID(ctx, id)
 		if err != nil {
-			return fmt.Errorf("failed to delete holiday")
+			if errors.Is(err, db.ErrNotFound) {
+				return fmt.Errorf("holiday not found: %s", id)
+			}
+			return fmt.Errorf("failed to get holiday: %w", err)
+		}
+		if err := policy.Authorize(currentUser, policy.HolidayWrite, existing.OrganizationID); err != nil {
+			return err
+		}
+		deletes = append(deletes, existing)
+	}
+
+	updates := 0
+	for _, t := range req.Holidays {
+		if t.ID != "" {
+			updates++
 		}
-		// goi GW xoa message lang
-		s.messageLanguageGateway.DeleleByTypeAndTypeID(ctx, string(constants.HolidayType), id)
 	}
 
 	// 2. Handle upsert (create or update)
+	type holidayWrite struct {
+		holiday *model.Holiday
+		item    request.UploadHolidayItem
+		created bool
+	}
+	writes := make([]holidayWrite, 0, len(req.Holidays))
 	for _, t := range req.Holidays {
 		startDate, err := time.Parse("2006-01-02", t.StartDate)
 		if err != nil {
@@ -80,75 +112,135 @@ func (s *holidayService) UploadHolidays(ctx context.Context, req request.UploadH
 			return fmt.Errorf("start_date must be before or equal to end_date for holiday %s", t.Title)
 		}
 
+		audiences := mapper.MapUploadHolidayItemToAudiences(t)
+		if err := audiences.Validate(); err != nil {
+			return fmt.Errorf("invalid audiences for holiday %s: %w", t.Title, err)
+		}
+
 		if t.ID != "" {
 			// Update existing holiday
 			existing, err := s.repo.GetByID(ctx, t.ID)
 			if err != nil {
-				if errors.Is(err, gorm.ErrRecordNotFound) {
+				if errors.Is(err, db.ErrNotFound) {
 					return fmt.Errorf("holiday not found: %s", t.ID)
 				}
 				return fmt.Errorf("failed to get holiday: %w", err)
 			}
+			if err := policy.Authorize(currentUser, policy.HolidayWrite, existing.OrganizationID); err != nil {
+				return err
+			}
+
+			version, err := pkg_helpder.ExpectedVersion(t.Version, req.IfMatchVersion, updates)
+			if err != nil {
+				return fmt.Errorf("holiday %s: %w", t.ID, err)
+			}
+			if existing.Version != version {
+				return holidayConflict(existing)
+			}
 
 			existing.Title = t.Title
 			existing.Color = t.Color
-			existing.PublishedMobile = t.PublishedMobile
-			existing.PublishedDesktop = t.PublishedDesktop
+			existing.Audiences = audiences
 			existing.StartDate = startDate
 			existing.EndDate = endDate
 			existing.UpdatedAt = time.Now()
-
-			if err := s.repo.Update(ctx, t.ID, existing); err != nil {
-				return fmt.Errorf("failed to update holiday %s: %w", t.ID, err)
-			}
-
-			// goi messs lang gw upload message
-			err = s.uploadMessages(ctx, helper.BuildHolidayMessagesUpload(existing.ID.Hex(), t, req.LanguageID))
-			if err != nil {
-				return fmt.Errorf("upload department messages failed")
-			}
+			writes = append(writes, holidayWrite{holiday: existing, item: t})
 
 		} else {
 			// Create new Holiday
-			newHoliday := &model.Holiday{
-				ID:               primitive.NewObjectID(),
-				OrganizationID:   organizationAdminID,
-				Title:            t.Title,
-				Color:            t.Color,
-				PublishedMobile:  t.PublishedMobile,
-				PublishedDesktop: t.PublishedDesktop,
-				StartDate:        startDate,
-				EndDate:          endDate,
-				CreatedAt:        time.Now(),
-				UpdatedAt:        time.Now(),
+			writes = append(writes, holidayWrite{item: t, created: true, holiday: &model.Holiday{
+				ID:             objectid.New(),
+				OrganizationID: organizationAdminID,
+				Title:          t.Title,
+				Color:          t.Color,
+				Audiences:      audiences,
+				StartDate:      startDate,
+				EndDate:        endDate,
+				CreatedAt:      time.Now(),
+				UpdatedAt:      time.Now(),
+			}})
+		}
+	}
+
+	// 3. Apply the upload as a whole
+	var conflicted string
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, existing := range deletes {
+			if err := s.repo.Delete(ctx, existing.ID.Hex()); err != nil {
+				return fmt.Errorf("failed to delete holiday %s: %w", existing.ID.Hex(), err)
 			}
+			if err := s.recordHoliday(ctx, outbox.HolidayDeleted, existing); err != nil {
+				return err
+			}
+		}
 
-			if _, err := s.repo.Create(ctx, newHoliday); err != nil {
-				return fmt.Errorf("failed to create holiday %s: %w", t.Title, err)
+		for _, w := range writes {
+			if w.created {
+				if _, err := s.repo.Create(ctx, w.holiday); err != nil {
+					return fmt.Errorf("failed to create holiday %s: %w", w.holiday.Title, err)
+				}
+				if err := s.recordHoliday(ctx, outbox.HolidayCreated, w.holiday); err != nil {
+					return err
+				}
+				continue
 			}
 
-			// goi messs lang gw upload message
-			err = s.uploadMessages(ctx, helper.BuildHolidayMessagesUpload(newHoliday.ID.Hex(), t, req.LanguageID))
+			id := w.holiday.ID.Hex()
+			if err := s.repo.Update(ctx, id, w.holiday); err != nil {
+				if errors.Is(err, db.ErrVersionConflict) {
+					conflicted = id
+				}
+				return fmt.Errorf("failed to update holiday %s: %w", id, err)
+			}
+			if err := s.recordHoliday(ctx, outbox.HolidayUpdated, w.holiday); err != nil {
+				return err
+			}
+		}
 
-			if err != nil {
-				return fmt.Errorf("upload department messages failed")
+		// goi GW xoa / upload message lang, once the holidays are committed; a
+		// failure then must not fail the upload and invite a duplicating retry
+		txn.AfterCommit(ctx, func(ctx context.Context) error {
+			for _, existing := range deletes {
+				s.messageLanguageGateway.DeleleByTypeAndTypeID(ctx, string(constants.HolidayType), existing.ID.Hex())
+			}
+			var errs []error
+			for _, w := range writes {
+				if err := s.uploadMessages(ctx, helper.BuildHolidayMessagesUpload(w.holiday.ID.Hex(), w.item, req.LanguageID)); err != nil {
+					errs = append(errs, fmt.Errorf("upload messages of holiday %s: %w", w.holiday.ID.Hex(), err))
+				}
+			}
+			return errors.Join(errs...)
+		})
+		return nil
+	})
+	if err != nil {
+		if conflicted != "" {
+			// changed between our read and write
+			if current, getErr := s.repo.GetByID(ctx, conflicted); getErr == nil {
+				return holidayConflict(current)
 			}
 		}
+		return err
 	}
 
 	return nil
 }
 
-func (s *holidayService) GetHolidays4Web(ctx context.Context) (*response.GetHolidays4WebResDTO, error) {
+func (s *holidayService) GetHolidays4Web(ctx context.Context, organizationID string, asOf time.Time) (*response.GetHolidays4WebResDTO, error) {
 	currentUser, err := s.userGateway.GetCurrentUser(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("get current user info failed: %w", err)
 	}
 
+	scope := policy.TargetOrganization(currentUser, organizationID)
+	if err := policy.Authorize(currentUser, policy.HolidayList, scope); err != nil {
+		return nil, err
+	}
+
 	var result = make([]response.HolidaysByOrgRes, 0)
 
 	// if is super admin return []
-	if currentUser.IsSuperAdmin {
+	if scope == policy.AllOrganizations {
 		// // Lấy toàn bộ org từ Gateway
 		// orgs, err := s.orgGateway.GetAllOrg(ctx)
 		// if err != nil {
@@ -181,10 +273,15 @@ func (s *holidayService) GetHolidays4Web(ctx context.Context) (*response.GetHoli
 			HolidaysOrg: result,
 		}, nil
 
-	} else if currentUser.OrganizationAdmin.ID != "" {
+	} else {
 		// User là org admin → chỉ lấy org của mình
-		orgID := currentUser.OrganizationAdmin.ID
-		holidays, err := s.repo.GetAllByOrgID(ctx, orgID)
+		orgID := scope
+		var holidays []*model.Holiday
+		if asOf.IsZero() {
+			holidays, err = s.repo.GetAllByOrgID(ctx, orgID)
+		} else {
+			holidays, err = s.holidaysAsOf(ctx, orgID, asOf)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("get holidays by orgID %s failed: %w", orgID, err)
 		}
@@ -209,9 +306,6 @@ func (s *holidayService) GetHolidays4Web(ctx context.Context) (*response.GetHoli
 			OrganizationName: orgInfo.OrganizationName,
 			Holidays:         holidayDTOs,
 		})
-
-	} else {
-		return nil, fmt.Errorf("access denied: user is not an organization admin")
 	}
 
 	return &response.GetHolidays4WebResDTO{
@@ -228,3 +322,25 @@ func (s *holidayService) uploadMessages(ctx context.Context, req dto.UploadMessa
 
 	return nil
 }
+
+// recordHoliday adds a holiday event to the outbox; call it inside the
+// transaction that changed the holiday.
+func (s *holidayService) recordHoliday(ctx context.Context, eventType string, holiday *model.Holiday) error {
+	e, err := outbox.NewEvent(eventType, "holiday", holiday.ID.Hex(), holiday.OrganizationID, mapper.MapHolidayToEventData(holiday))
+	if err != nil {
+		return err
+	}
+	if err := s.events.Record(ctx, e); err != nil {
+		return fmt.Errorf("record %s event: %w", eventType, err)
+	}
+	return nil
+}
+
+// holidayConflict reports that current has moved past the version an update
+// was based on.
+func holidayConflict(current *model.Holiday) error {
+	return &pkg_helpder.ConflictError{
+		Err:     fmt.Errorf("holiday %s is at version %d: %w", current.ID.Hex(), current.Version, db.ErrVersionConflict),
+		Current: mapper.MapHolidayToResDTO(current),
+	}
+}
@@ -0,0 +1,13 @@
+package event
+
+// TransitionData is the payload of term.starting_soon, term.ended and
+// holiday.starting_soon events.
+type TransitionData struct {
+	ObjectType     string `json:"object_type"` // "term" or "holiday"
+	ObjectID       string `json:"object_id"`
+	OrganizationID string `json:"organization_id"`
+	Title          string `json:"title"`
+	StartDate      string `json:"start_date"`
+	EndDate        string `json:"end_date"`
+	DaysUntil      int    `json:"days_until"` // to start_date, or to end_date for term.ended (negative)
+}
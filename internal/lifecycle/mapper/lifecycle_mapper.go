@@ -0,0 +1,19 @@
+package mapper
+
+import (
+	"term-service/internal/lifecycle/dto/event"
+	"term-service/internal/lifecycle/model"
+	"term-service/pkg/helper"
+)
+
+func MapTransitionToEventData(t model.Transition) event.TransitionData {
+	return event.TransitionData{
+		ObjectType:     t.AggregateType,
+		ObjectID:       t.AggregateID,
+		OrganizationID: t.OrganizationID,
+		Title:          t.Title,
+		StartDate:      helper.FormatDate(t.StartDate),
+		EndDate:        helper.FormatDate(t.EndDate),
+		DaysUntil:      t.DaysUntil,
+	}
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"term-service/pkg/outbox"
+	"time"
+)
+
+// Transition is one term or holiday crossing a date boundary.
+type Transition struct {
+	Type           string // outbox.TermStartingSoon, outbox.TermEnded or outbox.HolidayStartingSoon
+	AggregateType  string // "term" or "holiday"
+	AggregateID    string
+	OrganizationID string
+	Title          string
+	StartDate      time.Time
+	EndDate        time.Time
+	// DaysUntil counts the days from today to the boundary crossed: StartDate,
+	// or EndDate for outbox.TermEnded, where it is negative (-1 the day after
+	// the term ended).
+	DaysUntil int
+}
+
+// Key identifies a transition for deduplication. It includes the boundary
+// date, so moving a term to another start date notifies again.
+func (t Transition) Key() string {
+	boundary := t.StartDate
+	if t.Type == outbox.TermEnded {
+		boundary = t.EndDate
+	}
+	return t.Type + "|" + t.AggregateID + "|" + boundary.Format("2006-01-02")
+}
+
+// FiredTransition records that a transition has been notified.
+type FiredTransition struct {
+	Key            string    `bson:"_id" gorm:"column:transition_key;primaryKey;size:128"`
+	Type           string    `bson:"type" gorm:"size:64"`
+	AggregateID    string    `bson:"aggregate_id" gorm:"size:64"`
+	OrganizationID string    `bson:"organization_id" gorm:"size:64"`
+	FiredAt        time.Time `bson:"fired_at" gorm:"index:idx_lifecycle_fired_at"`
+}
+
+func (FiredTransition) TableName() string {
+	return "lifecycle_fired"
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"term-service/internal/lifecycle/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FiredRepository remembers which transitions have been notified.
+type FiredRepository interface {
+	// MarkFired saves rec and reports whether it is new; false means the
+	// transition was already fired.
+	MarkFired(ctx context.Context, rec *model.FiredTransition) (bool, error)
+	// Release forgets key so the transition fires again on the next run.
+	Release(ctx context.Context, key string) error
+	// Prune deletes records fired before the given time.
+	Prune(ctx context.Context, firedBefore time.Time) error
+}
+
+type firedRepository struct {
+	collection *mongo.Collection
+}
+
+func NewFiredRepository(collection *mongo.Collection) FiredRepository {
+	return &firedRepository{collection}
+}
+
+func (r *firedRepository) MarkFired(ctx context.Context, rec *model.FiredTransition) (bool, error) {
+	if rec.FiredAt.IsZero() {
+		rec.FiredAt = time.Now()
+	}
+
+	// An upsert rather than an insert: a duplicate key error would abort a
+	// surrounding transaction.
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": rec.Key},
+		bson.M{"$setOnInsert": bson.M{
+			"type":            rec.Type,
+			"aggregate_id":    rec.AggregateID,
+			"organization_id": rec.OrganizationID,
+			"fired_at":        rec.FiredAt,
+		}},
+		options.Update().SetUpsert(true))
+	if err != nil {
+		return false, err
+	}
+	return result.UpsertedCount == 1, nil
+}
+
+func (r *firedRepository) Release(ctx context.Context, key string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": key})
+	return err
+}
+
+func (r *firedRepository) Prune(ctx context.Context, firedBefore time.Time) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"fired_at": bson.M{"$lt": firedBefore}})
+	return err
+}
@@ -0,0 +1,32 @@
+package repository_test
+
+import (
+	"term-service/internal/lifecycle/repository"
+	"term-service/internal/lifecycle/repository/repositorytest"
+	"term-service/pkg/db/dbtest"
+	"testing"
+)
+
+func TestMongoFiredRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.FiredRepository {
+		return repository.NewFiredRepository(dbtest.NewMongo(t).Collection("lifecycle_fired"))
+	})
+}
+
+func TestGormSQLiteFiredRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.FiredRepository {
+		return repository.NewGormFiredRepository(dbtest.NewSQLite(t))
+	})
+}
+
+func TestGormMySQLFiredRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.FiredRepository {
+		return repository.NewGormFiredRepository(dbtest.NewMySQL(t))
+	})
+}
+
+func TestMemoryFiredRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.FiredRepository {
+		return repository.NewMemoryFiredRepository()
+	})
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"term-service/internal/lifecycle/model"
+	"term-service/pkg/db/txn"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormFiredRepository struct {
+	db *gorm.DB
+}
+
+// NewGormFiredRepository returns a FiredRepository backed by the
+// lifecycle_fired table.
+func NewGormFiredRepository(db *gorm.DB) FiredRepository {
+	return &gormFiredRepository{db}
+}
+
+func (r *gormFiredRepository) MarkFired(ctx context.Context, rec *model.FiredTransition) (bool, error) {
+	if rec.FiredAt.IsZero() {
+		rec.FiredAt = time.Now()
+	}
+
+	result := txn.Gorm(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(rec)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *gormFiredRepository) Release(ctx context.Context, key string) error {
+	return txn.Gorm(ctx, r.db).Where("transition_key = ?", key).Delete(&model.FiredTransition{}).Error
+}
+
+func (r *gormFiredRepository) Prune(ctx context.Context, firedBefore time.Time) error {
+	return txn.Gorm(ctx, r.db).Where("fired_at < ?", firedBefore).Delete(&model.FiredTransition{}).Error
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"term-service/internal/lifecycle/model"
+	"time"
+)
+
+type memoryFiredRepository struct {
+	mu    sync.Mutex
+	fired map[string]model.FiredTransition
+}
+
+// NewMemoryFiredRepository returns a FiredRepository kept in process
+// memory, for tests and local runs without a database.
+func NewMemoryFiredRepository() FiredRepository {
+	return &memoryFiredRepository{fired: make(map[string]model.FiredTransition)}
+}
+
+func (r *memoryFiredRepository) MarkFired(ctx context.Context, rec *model.FiredTransition) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.fired[rec.Key]; ok {
+		return false, nil
+	}
+	if rec.FiredAt.IsZero() {
+		rec.FiredAt = time.Now()
+	}
+	r.fired[rec.Key] = *rec
+	return true, nil
+}
+
+func (r *memoryFiredRepository) Release(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.fired, key)
+	return nil
+}
+
+func (r *memoryFiredRepository) Prune(ctx context.Context, firedBefore time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, rec := range r.fired {
+		if rec.FiredAt.Before(firedBefore) {
+			delete(r.fired, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// Package repositorytest holds the behaviour every lifecycle repository
+// backend must share. Backend tests call Run with a constructor for an
+// empty store.
+package repositorytest
+
+import (
+	"context"
+	"term-service/internal/lifecycle/model"
+	"term-service/internal/lifecycle/repository"
+	"term-service/pkg/outbox"
+	"testing"
+	"time"
+)
+
+// Run executes the conformance suite; newRepo must return an empty
+// repository.
+func Run(t *testing.T, newRepo func(t *testing.T) repository.FiredRepository) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo repository.FiredRepository)
+	}{
+		{"MarkFiredOnce", testMarkFiredOnce},
+		{"Release", testRelease},
+		{"Prune", testPrune},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+func record(key string, firedAt time.Time) *model.FiredTransition {
+	return &model.FiredTransition{
+		Key:            key,
+		Type:           outbox.TermStartingSoon,
+		AggregateID:    "term-1",
+		OrganizationID: "org-a",
+		FiredAt:        firedAt,
+	}
+}
+
+func mustMark(t *testing.T, repo repository.FiredRepository, rec *model.FiredTransition, want bool) {
+	t.Helper()
+	fresh, err := repo.MarkFired(context.Background(), rec)
+	if err != nil {
+		t.Fatalf("MarkFired(%s): %v", rec.Key, err)
+	}
+	if fresh != want {
+		t.Fatalf("MarkFired(%s) = %v, want %v", rec.Key, fresh, want)
+	}
+}
+
+func testMarkFiredOnce(t *testing.T, repo repository.FiredRepository) {
+	mustMark(t, repo, record("a", time.Time{}), true)
+	mustMark(t, repo, record("a", time.Time{}), false)
+	mustMark(t, repo, record("b", time.Time{}), true)
+}
+
+func testRelease(t *testing.T, repo repository.FiredRepository) {
+	mustMark(t, repo, record("a", time.Time{}), true)
+	if err := repo.Release(context.Background(), "a"); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	mustMark(t, repo, record("a", time.Time{}), true)
+
+	if err := repo.Release(context.Background(), "missing"); err != nil {
+		t.Fatalf("Release of an unknown key: %v", err)
+	}
+}
+
+func testPrune(t *testing.T, repo repository.FiredRepository) {
+	now := time.Now().UTC().Truncate(time.Second)
+	mustMark(t, repo, record("old", now.Add(-48*time.Hour)), true)
+	mustMark(t, repo, record("new", now), true)
+
+	if err := repo.Prune(context.Background(), now.Add(-time.Hour)); err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+	mustMark(t, repo, record("old", now), true)
+	mustMark(t, repo, record("new", now), false)
+}
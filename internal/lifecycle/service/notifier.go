@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"term-service/internal/lifecycle/mapper"
+	"term-service/internal/lifecycle/model"
+	"term-service/pkg/outbox"
+)
+
+// Notifier tells the outside world about a transition. The scheduler calls
+// it in the transaction that marks the transition fired.
+type Notifier interface {
+	Notify(ctx context.Context, t model.Transition) error
+}
+
+type eventNotifier struct {
+	events outbox.Recorder
+}
+
+// NewEventNotifier records each transition as an outbox event, so it
+// reaches the event sink and matching webhook subscriptions.
+func NewEventNotifier(events outbox.Recorder) Notifier {
+	return &eventNotifier{events: events}
+}
+
+func (n *eventNotifier) Notify(ctx context.Context, t model.Transition) error {
+	e, err := outbox.NewEvent(t.Type, t.AggregateType, t.AggregateID, t.OrganizationID, mapper.MapTransitionToEventData(t))
+	if err != nil {
+		return err
+	}
+	if err := n.events.Record(ctx, e); err != nil {
+		return fmt.Errorf("record %s event: %w", t.Type, err)
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"term-service/internal/gateway"
+	holiday_model "term-service/internal/holiday/model"
+	holiday_repo "term-service/internal/holiday/repository"
+	"term-service/internal/lifecycle/model"
+	"term-service/internal/lifecycle/repository"
+	term_model "term-service/internal/term/model"
+	term_repo "term-service/internal/term/repository"
+	"term-service/pkg/db/txn"
+	"term-service/pkg/outbox"
+	"term-service/pkg/zap"
+	"time"
+)
+
+// Config tunes the scheduler; zero fields take the defaults below.
+type Config struct {
+	Interval          time.Duration  // pause between scans
+	Location          *time.Location // decides which day "today" is
+	TermLeadDays      int            // notify when a term starts within this many days
+	HolidayLeadDays   int            // notify when a holiday starts within this many days
+	EndedLookbackDays int            // a term that ended up to this many days ago is still reported, covering missed scans
+	Retention         time.Duration  // fired records older than this are pruned
+}
+
+func (c Config) withDefaults() Config {
+	if c.Interval <= 0 {
+		c.Interval = time.Hour
+	}
+	if c.Location == nil {
+		c.Location = time.UTC
+	}
+	if c.TermLeadDays <= 0 {
+		c.TermLeadDays = 1
+	}
+	if c.HolidayLeadDays <= 0 {
+		c.HolidayLeadDays = 3
+	}
+	if c.EndedLookbackDays <= 0 {
+		c.EndedLookbackDays = 3
+	}
+	if c.Retention <= 0 {
+		c.Retention = 90 * 24 * time.Hour
+	}
+	return c
+}
+
+// Scheduler scans every organization's terms and holidays and notifies
+// each transition once.
+type Scheduler struct {
+	orgs     gateway.OrganizationGateway
+	terms    term_repo.TermRepository
+	holidays holiday_repo.HolidayRepository
+	fired    repository.FiredRepository
+	tx       txn.Transactor
+	notifier Notifier
+	cfg      Config
+}
+
+func NewScheduler(orgs gateway.OrganizationGateway, terms term_repo.TermRepository, holidays holiday_repo.HolidayRepository,
+	fired repository.FiredRepository, tx txn.Transactor, notifier Notifier, cfg Config) *Scheduler {
+	return &Scheduler{
+		orgs:     orgs,
+		terms:    terms,
+		holidays: holidays,
+		fired:    fired,
+		tx:       tx,
+		notifier: notifier,
+		cfg:      cfg.withDefaults(),
+	}
+}
+
+// Run scans immediately and then every Interval until ctx is cancelled.
+func (s *Scheduler) Run(ctx context.Context) {
+	logger := zap.FromContext(ctx)
+	logger.Infow("lifecycle scheduler started")
+
+	for {
+		n, err := s.RunOnce(ctx, time.Now())
+		if err != nil && ctx.Err() == nil {
+			logger.Errorw("lifecycle scan failed", "error", err)
+		}
+		if n > 0 {
+			logger.Infow("lifecycle transitions notified", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Infow("lifecycle scheduler stopped")
+			return
+		case <-time.After(s.cfg.Interval):
+		}
+	}
+}
+
+// RunOnce notifies the transitions due at now that have not fired yet and
+// returns how many it notified. A failing organization does not stop the
+// others.
+func (s *Scheduler) RunOnce(ctx context.Context, now time.Time) (int, error) {
+	orgs, err := s.orgs.GetAllOrg(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list organizations: %w", err)
+	}
+
+	notified := 0
+	var errs []error
+	for _, org := range orgs {
+		transitions, err := s.detect(ctx, org.ID, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("organization %s: %w", org.ID, err))
+			continue
+		}
+		for _, t := range transitions {
+			fired, err := s.fire(ctx, t)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s %s: %w", t.Type, t.AggregateID, err))
+				continue
+			}
+			if fired {
+				notified++
+			}
+		}
+	}
+
+	if err := s.fired.Prune(ctx, now.Add(-s.cfg.Retention)); err != nil {
+		errs = append(errs, fmt.Errorf("prune fired transitions: %w", err))
+	}
+	return notified, errors.Join(errs...)
+}
+
+// detect lists the transitions of one organization as of now. Terms and
+// holidays not visible on any channel yet are drafts and are skipped.
+func (s *Scheduler) detect(ctx context.Context, orgID string, now time.Time) ([]model.Transition, error) {
+	terms, err := s.terms.GetAllByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("get terms: %w", err)
+	}
+	holidays, err := s.holidays.GetAllByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("get holidays: %w", err)
+	}
+
+	today := civilDay(now.In(s.cfg.Location))
+	var transitions []model.Transition
+	for _, term := range terms {
+		if !term.Audiences.VisibleOnAny(now) {
+			continue
+		}
+		startsIn := daysBetween(today, civilDay(term.StartDate.UTC()))
+		endedAgo := daysBetween(civilDay(term.EndDate.UTC()), today)
+
+		if startsIn >= 0 && startsIn <= s.cfg.TermLeadDays {
+			transitions = append(transitions, termTransition(outbox.TermStartingSoon, term, startsIn))
+		}
+		if endedAgo >= 1 && endedAgo <= s.cfg.EndedLookbackDays {
+			transitions = append(transitions, termTransition(outbox.TermEnded, term, -endedAgo))
+		}
+	}
+
+	for _, holiday := range holidays {
+		if !holiday.Audiences.VisibleOnAny(now) {
+			continue
+		}
+		startsIn := daysBetween(today, civilDay(holiday.StartDate.UTC()))
+		if startsIn >= 0 && startsIn <= s.cfg.HolidayLeadDays {
+			transitions = append(transitions, holidayTransition(holiday, startsIn))
+		}
+	}
+	return transitions, nil
+}
+
+// fire marks t fired and notifies it in one transaction, reporting false
+// when t had already fired.
+func (s *Scheduler) fire(ctx context.Context, t model.Transition) (bool, error) {
+	rec := &model.FiredTransition{
+		Key:            t.Key(),
+		Type:           t.Type,
+		AggregateID:    t.AggregateID,
+		OrganizationID: t.OrganizationID,
+		FiredAt:        time.Now(),
+	}
+
+	fresh := false
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		fresh, err = s.fired.MarkFired(ctx, rec)
+		if err != nil || !fresh {
+			return err
+		}
+		return s.notifier.Notify(ctx, t)
+	})
+	if err != nil {
+		// Without transactions (standalone Mongo, memory) the record
+		// outlives the failed notification; drop it so the next scan
+		// retries.
+		if fresh {
+			if releaseErr := s.fired.Release(context.WithoutCancel(ctx), rec.Key); releaseErr != nil {
+				zap.FromContext(ctx).Warnw("release fired transition", "key", rec.Key, "error", releaseErr)
+			}
+		}
+		return false, err
+	}
+	return fresh, nil
+}
+
+// termTransition builds a term transition; daysUntil counts to the boundary
+// crossed, as in model.Transition.
+func termTransition(eventType string, term *term_model.Term, daysUntil int) model.Transition {
+	return model.Transition{
+		Type:           eventType,
+		AggregateType:  "term",
+		AggregateID:    term.ID.Hex(),
+		OrganizationID: term.OrganizationID,
+		Title:          term.Title,
+		StartDate:      term.StartDate,
+		EndDate:        term.EndDate,
+		DaysUntil:      daysUntil,
+	}
+}
+
+func holidayTransition(holiday *holiday_model.Holiday, startsIn int) model.Transition {
+	return model.Transition{
+		Type:           outbox.HolidayStartingSoon,
+		AggregateType:  "holiday",
+		AggregateID:    holiday.ID.Hex(),
+		OrganizationID: holiday.OrganizationID,
+		Title:          holiday.Title,
+		StartDate:      holiday.StartDate,
+		EndDate:        holiday.EndDate,
+		DaysUntil:      startsIn,
+	}
+}
+
+// civilDay returns the calendar day of t, read in t's own location, as
+// midnight UTC, so days can be subtracted without daylight saving skew.
+// Term and holiday dates are stored as midnight UTC and are read in UTC;
+// only "today" is read in the configured location.
+func civilDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
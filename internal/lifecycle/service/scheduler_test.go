@@ -0,0 +1,216 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"term-service/internal/gateway/dto"
+	"term-service/internal/gateway/gatewaytest"
+	holiday_model "term-service/internal/holiday/model"
+	holiday_repo "term-service/internal/holiday/repository"
+	"term-service/internal/lifecycle/dto/event"
+	"term-service/internal/lifecycle/model"
+	"term-service/internal/lifecycle/repository"
+	"term-service/internal/lifecycle/service"
+	term_model "term-service/internal/term/model"
+	term_repo "term-service/internal/term/repository"
+	"term-service/pkg/db/txn"
+	"term-service/pkg/outbox"
+	"term-service/pkg/publish"
+)
+
+var today = time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC)
+
+func day(offset int) time.Time {
+	return time.Date(2025, 9, 1+offset, 0, 0, 0, 0, time.UTC)
+}
+
+type harness struct {
+	terms    term_repo.TermRepository
+	holidays holiday_repo.HolidayRepository
+	fired    repository.FiredRepository
+}
+
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+	return &harness{
+		terms:    term_repo.NewMemoryTermRepository(),
+		holidays: holiday_repo.NewMemoryHolidayRepository(),
+		fired:    repository.NewMemoryFiredRepository(),
+	}
+}
+
+func (h *harness) scheduler(notifier service.Notifier) *service.Scheduler {
+	orgs := gatewaytest.NewOrganizationGateway(dto.OrganizationInfo{ID: "org-a"}, dto.OrganizationInfo{ID: "org-b"})
+	return service.NewScheduler(orgs, h.terms, h.holidays, h.fired, txn.NewNoopTransactor(), notifier, service.Config{
+		TermLeadDays:      1,
+		HolidayLeadDays:   3,
+		EndedLookbackDays: 2,
+	})
+}
+
+func (h *harness) addTerm(t *testing.T, orgID, title string, start, end time.Time, published bool) {
+	t.Helper()
+	var audiences publish.Audiences
+	if published {
+		audiences = publish.Audiences{{Channel: publish.Teacher}}
+	}
+	if _, err := h.terms.Create(context.Background(), &term_model.Term{
+		OrganizationID: orgID,
+		Title:          title,
+		StartDate:      start,
+		EndDate:        end,
+		Audiences:      audiences,
+	}); err != nil {
+		t.Fatalf("create term: %v", err)
+	}
+}
+
+func (h *harness) addHoliday(t *testing.T, orgID, title string, start, end time.Time) {
+	t.Helper()
+	if _, err := h.holidays.Create(context.Background(), &holiday_model.Holiday{
+		OrganizationID: orgID,
+		Title:          title,
+		StartDate:      start,
+		EndDate:        end,
+		Audiences:      publish.Audiences{{Channel: publish.Mobile}},
+	}); err != nil {
+		t.Fatalf("create holiday: %v", err)
+	}
+}
+
+func TestRunOnceNotifiesEachTransitionOnce(t *testing.T) {
+	h := newHarness(t)
+	h.addTerm(t, "org-a", "Starts tomorrow", day(1), day(90), true)
+	h.addTerm(t, "org-a", "Starts next week", day(7), day(90), true)
+	h.addTerm(t, "org-a", "Draft", day(1), day(90), false)
+	h.addTerm(t, "org-b", "Ended yesterday", day(-90), day(-1), true)
+	h.addTerm(t, "org-b", "Ended long ago", day(-200), day(-100), true)
+	h.addHoliday(t, "org-b", "In three days", day(3), day(5))
+	h.addHoliday(t, "org-b", "In four days", day(4), day(5))
+
+	store := outbox.NewMemoryStore()
+	s := h.scheduler(service.NewEventNotifier(store))
+
+	n, err := s.RunOnce(context.Background(), today)
+	if err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if n != 3 {
+		t.Fatalf("notified %d transitions, want 3", n)
+	}
+
+	type notified struct {
+		orgID     string
+		daysUntil int
+	}
+	got := map[string]notified{}
+	for _, e := range store.Events() {
+		var data event.TransitionData
+		if err := json.Unmarshal(e.Payload, &data); err != nil {
+			t.Fatalf("decode %s payload: %v", e.Type, err)
+		}
+		got[e.Type] = notified{e.OrganizationID, data.DaysUntil}
+	}
+	want := map[string]notified{
+		outbox.TermStartingSoon:    {"org-a", 1},
+		outbox.TermEnded:           {"org-b", -1}, // counted to the end date
+		outbox.HolidayStartingSoon: {"org-b", 3},
+	}
+	for eventType, w := range want {
+		if got[eventType] != w {
+			t.Errorf("%s event = %+v, want %+v", eventType, got[eventType], w)
+		}
+	}
+
+	n, err = s.RunOnce(context.Background(), today.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("second RunOnce: %v", err)
+	}
+	if n != 0 || len(store.Events()) != 3 {
+		t.Fatalf("second scan notified %d, outbox has %d events; want nothing new", n, len(store.Events()))
+	}
+}
+
+// flakyNotifier fails with err while it is set and passes transitions on
+// otherwise.
+type flakyNotifier struct {
+	err  error
+	next service.Notifier
+}
+
+func (n *flakyNotifier) Notify(ctx context.Context, t model.Transition) error {
+	if n.err != nil {
+		return n.err
+	}
+	return n.next.Notify(ctx, t)
+}
+
+func TestRunOnceRetriesFailedNotification(t *testing.T) {
+	h := newHarness(t)
+	h.addTerm(t, "org-a", "Starts tomorrow", day(1), day(90), true)
+
+	store := outbox.NewMemoryStore()
+	notifier := &flakyNotifier{err: errors.New("outbox unavailable"), next: service.NewEventNotifier(store)}
+	s := h.scheduler(notifier)
+
+	if n, err := s.RunOnce(context.Background(), today); err == nil || n != 0 {
+		t.Fatalf("RunOnce = %d, %v; want 0 and an error", n, err)
+	}
+
+	notifier.err = nil
+	if n, err := s.RunOnce(context.Background(), today); err != nil || n != 1 {
+		t.Fatalf("RunOnce after recovery = %d, %v; want 1", n, err)
+	}
+
+	events := store.Events()
+	if len(events) != 1 || events[0].Type != outbox.TermStartingSoon {
+		t.Fatalf("events = %+v, want one %s", events, outbox.TermStartingSoon)
+	}
+}
+
+func TestRunOnceUsesConfiguredTimezone(t *testing.T) {
+	// Dates are stored as midnight UTC and name that calendar day wherever
+	// the scheduler runs; only "today" follows the configured location.
+	for _, tc := range []struct {
+		name   string
+		loc    *time.Location
+		early  time.Time // still the day before the lead window in loc
+		due    time.Time // the day before the term starts in loc
+		starts time.Time
+	}{
+		{
+			name:   "west of UTC",
+			loc:    time.FixedZone("EST", -5*60*60),
+			early:  time.Date(2025, 9, 1, 3, 0, 0, 0, time.UTC), // 08-31 22:00 local
+			due:    time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC), // 09-01 03:00 local
+			starts: day(1),
+		},
+		{
+			name:   "east of UTC",
+			loc:    time.FixedZone("ICT", 7*60*60),
+			early:  time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC),  // 09-01 15:00 local
+			due:    time.Date(2025, 9, 1, 20, 0, 0, 0, time.UTC), // 09-02 03:00 local, still 09-01 in UTC
+			starts: day(2),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			h := newHarness(t)
+			h.addTerm(t, "org-a", "Local term", tc.starts, day(90), true)
+
+			orgs := gatewaytest.NewOrganizationGateway(dto.OrganizationInfo{ID: "org-a"})
+			s := service.NewScheduler(orgs, h.terms, h.holidays, h.fired, txn.NewNoopTransactor(),
+				service.NewEventNotifier(outbox.NewMemoryStore()), service.Config{Location: tc.loc})
+
+			if n, err := s.RunOnce(context.Background(), tc.early); err != nil || n != 0 {
+				t.Fatalf("RunOnce at %s = %d, %v; want 0", tc.early.In(tc.loc), n, err)
+			}
+			if n, err := s.RunOnce(context.Background(), tc.due); err != nil || n != 1 {
+				t.Fatalf("RunOnce at %s = %d, %v; want 1", tc.due.In(tc.loc), n, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+
+	"term-service/internal/gateway/dto"
+)
+
+func TestAuthorize(t *testing.T) {
+	superAdmin := &dto.CurrentUser{ID: "root", IsSuperAdmin: true}
+	superAdminByRole := &dto.CurrentUser{ID: "root2", Roles: &[]dto.Role{{RoleName: "Teacher"}, {RoleName: " superadmin"}}}
+	orgAdmin := &dto.CurrentUser{ID: "admin", OrganizationAdmin: &dto.OrganizationAdmin{ID: "org-a"}}
+	member := &dto.CurrentUser{ID: "member", Organization: []string{"org-a"}}
+	activeMember := &dto.CurrentUser{ID: "active", OrganizationIdActive: "org-a", OrganizationAdmin: &dto.OrganizationAdmin{}}
+	outsider := &dto.CurrentUser{ID: "outsider", Organization: []string{"org-b"}, Roles: &[]dto.Role{{RoleName: "Teacher"}}}
+
+	const (
+		allow = iota
+		forbid
+		unauthenticated
+	)
+
+	cases := []struct {
+		name   string
+		user   *dto.CurrentUser
+		action Action
+		org    string
+		want   int
+	}{
+		{"no user", nil, TermRead, "org-a", unauthenticated},
+		{"unknown action", superAdmin, Action("term:explode"), "org-a", forbid},
+		{"empty organization", superAdmin, TermRead, "", forbid},
+
+		{"super admin reads any org", superAdmin, TermRead, "org-b", allow},
+		{"super admin writes any org", superAdmin, TermWrite, "org-b", allow},
+		{"super admin lists all orgs", superAdmin, TermList, AllOrganizations, allow},
+		{"super admin cannot write all orgs", superAdmin, TermWrite, AllOrganizations, forbid},
+		{"super admin cannot assign across orgs", superAdmin, TermAssign, AllOrganizations, forbid},
+		{"super admin cannot write holidays across orgs", superAdmin, HolidayWrite, AllOrganizations, forbid},
+		{"super admin by role", superAdminByRole, HolidayWrite, "org-a", allow},
+
+		{"org admin reads own org", orgAdmin, TermRead, "org-a", allow},
+		{"org admin lists own org", orgAdmin, TermList, "org-a", allow},
+		{"org admin writes own org", orgAdmin, TermWrite, "org-a", allow},
+		{"org admin assigns own org", orgAdmin, TermAssign, "org-a", allow},
+		{"org admin writes own holidays", orgAdmin, HolidayWrite, "org-a", allow},
+		{"org admin manages own webhooks", orgAdmin, WebhookAdmin, "org-a", allow},
+		{"org admin manages other org webhooks", orgAdmin, WebhookAdmin, "org-b", forbid},
+		{"org admin approves own drafts", orgAdmin, CalendarApprove, "org-a", allow},
+		{"org admin reads other org", orgAdmin, TermRead, "org-b", forbid},
+		{"org admin writes other org", orgAdmin, TermWrite, "org-b", forbid},
+		{"org admin lists all orgs", orgAdmin, TermList, AllOrganizations, forbid},
+
+		{"member reads own org", member, TermRead, "org-a", allow},
+		{"member reads own holidays", member, HolidayRead, "org-a", allow},
+		{"active member reads own org", activeMember, TermRead, "org-a", allow},
+		{"member cannot list", member, TermList, "org-a", forbid},
+		{"member cannot write", member, TermWrite, "org-a", forbid},
+		{"member cannot write holidays", activeMember, HolidayWrite, "org-a", forbid},
+		{"member cannot manage webhooks", member, WebhookAdmin, "org-a", forbid},
+		{"member drafts own calendar", member, CalendarDraft, "org-a", allow},
+		{"member cannot approve drafts", member, CalendarApprove, "org-a", forbid},
+		{"member drafts other org", member, CalendarDraft, "org-b", forbid},
+		{"member reads other org", member, TermRead, "org-b", forbid},
+
+		{"outsider reads org", outsider, TermRead, "org-a", forbid},
+		{"outsider reads all orgs", outsider, HolidayRead, AllOrganizations, forbid},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Authorize(tc.user, tc.action, tc.org)
+			switch tc.want {
+			case allow:
+				if err != nil {
+					t.Fatalf("Authorize = %v, want allowed", err)
+				}
+			case forbid:
+				if !errors.Is(err, ErrForbidden) {
+					t.Fatalf("Authorize = %v, want ErrForbidden", err)
+				}
+			case unauthenticated:
+				if !errors.Is(err, ErrUnauthenticated) {
+					t.Fatalf("Authorize = %v, want ErrUnauthenticated", err)
+				}
+			}
+		})
+	}
+}
+
+func TestAdminScope(t *testing.T) {
+	if got := AdminScope(&dto.CurrentUser{OrganizationAdmin: &dto.OrganizationAdmin{ID: "org-a"}}); got != "org-a" {
+		t.Fatalf("AdminScope(org admin) = %q", got)
+	}
+	if got := AdminScope(&dto.CurrentUser{IsSuperAdmin: true}); got != AllOrganizations {
+		t.Fatalf("AdminScope(super admin) = %q", got)
+	}
+	if got := AdminScope(&dto.CurrentUser{OrganizationAdmin: &dto.OrganizationAdmin{}}); got != AllOrganizations {
+		t.Fatalf("AdminScope(member) = %q", got)
+	}
+}
+
+func TestTargetOrganization(t *testing.T) {
+	superAdmin := &dto.CurrentUser{ID: "root", IsSuperAdmin: true}
+	orgAdmin := &dto.CurrentUser{ID: "admin", OrganizationAdmin: &dto.OrganizationAdmin{ID: "org-a"}}
+
+	if got := TargetOrganization(superAdmin, "org-b"); got != "org-b" || !IsImpersonating(superAdmin, got) {
+		t.Fatalf("super admin target = %q, impersonating = %v", got, IsImpersonating(superAdmin, got))
+	}
+	if got := TargetOrganization(superAdmin, ""); got != AllOrganizations || IsImpersonating(superAdmin, got) {
+		t.Fatalf("super admin default target = %q", got)
+	}
+	if got := TargetOrganization(orgAdmin, ""); got != "org-a" || IsImpersonating(orgAdmin, got) {
+		t.Fatalf("org admin default target = %q", got)
+	}
+	if err := Authorize(orgAdmin, TermWrite, TargetOrganization(orgAdmin, "org-b")); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("org admin targeting another organization = %v, want ErrForbidden", err)
+	}
+}
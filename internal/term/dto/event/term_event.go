@@ -0,0 +1,44 @@
+package event
+
+import (
+	"term-service/pkg/publish"
+	"time"
+)
+
+// TermData is the payload of term.created, term.updated and term.deleted
+// events. For deletions it is the term as it was last stored.
+type TermData struct {
+	ID               string            `json:"id"`
+	OrganizationID   string            `json:"organization_id"`
+	Title            string            `json:"title"`
+	Color            string            `json:"color"`
+	PublishedMobile  bool              `json:"published_mobile"`
+	PublishedDesktop bool              `json:"published_desktop"`
+	PublishedTeacher bool              `json:"published_teacher"`
+	PublishedParent  bool              `json:"published_parent"`
+	StartDate        string            `json:"start_date"`
+	EndDate          string            `json:"end_date"`
+	Version          int64             `json:"version"`
+	Audiences        publish.Audiences `json:"audiences"`
+	Periods          []PeriodData      `json:"periods"`
+
+	// The published_* flags and windows mirror Audiences for clients that
+	// predate it.
+	PublishMobileAt    *time.Time `json:"publish_mobile_at,omitempty"`
+	UnpublishMobileAt  *time.Time `json:"unpublish_mobile_at,omitempty"`
+	PublishDesktopAt   *time.Time `json:"publish_desktop_at,omitempty"`
+	UnpublishDesktopAt *time.Time `json:"unpublish_desktop_at,omitempty"`
+	PublishTeacherAt   *time.Time `json:"publish_teacher_at,omitempty"`
+	UnpublishTeacherAt *time.Time `json:"unpublish_teacher_at,omitempty"`
+	PublishParentAt    *time.Time `json:"publish_parent_at,omitempty"`
+	UnpublishParentAt  *time.Time `json:"unpublish_parent_at,omitempty"`
+}
+
+// PeriodData is a sub-period of a term event.
+type PeriodData struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
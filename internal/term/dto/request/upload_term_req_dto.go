@@ -1,5 +1,10 @@
 package request
 
+import (
+	"term-service/pkg/publish"
+	"time"
+)
+
 type UploadTermItem struct {
 	ID               string `json:"id,omitempty"`
 	Title            string `json:"title" bninding:"required"`
@@ -10,10 +15,44 @@ type UploadTermItem struct {
 	PublishedParent  bool   `json:"published_parent"`
 	StartDate        string `json:"start_date" bninding:"required"`
 	EndDate          string `json:"end_date" binding:"required"`
+	// Audiences lists the channels the term is published to. When empty,
+	// the legacy published_* flags and publish_*_at/unpublish_*_at windows
+	// (RFC 3339) below are used instead.
+	Audiences          []publish.Audience `json:"audiences,omitempty"`
+	PublishMobileAt    *time.Time         `json:"publish_mobile_at,omitempty"`
+	UnpublishMobileAt  *time.Time         `json:"unpublish_mobile_at,omitempty"`
+	PublishDesktopAt   *time.Time         `json:"publish_desktop_at,omitempty"`
+	UnpublishDesktopAt *time.Time         `json:"unpublish_desktop_at,omitempty"`
+	PublishTeacherAt   *time.Time         `json:"publish_teacher_at,omitempty"`
+	UnpublishTeacherAt *time.Time         `json:"unpublish_teacher_at,omitempty"`
+	PublishParentAt    *time.Time         `json:"publish_parent_at,omitempty"`
+	UnpublishParentAt  *time.Time         `json:"unpublish_parent_at,omitempty"`
+	// Periods replaces the term's sub-periods. Leaving it out keeps them;
+	// an empty list removes them.
+	Periods []UploadPeriodItem `json:"periods,omitempty"`
+	// Version is the version the update is based on; required with ID
+	// unless the request sends If-Match.
+	Version int64 `json:"version,omitempty"`
+}
+
+// UploadPeriodItem is a sub-period of an uploaded term. Items with an ID
+// update that period of the term; items without one are new.
+type UploadPeriodItem struct {
+	ID        string `json:"id,omitempty"`
+	Type      string `json:"type" binding:"required"` // exam, break, orientation or custom
+	Title     string `json:"title"`
+	StartDate string `json:"start_date" binding:"required"`
+	EndDate   string `json:"end_date" binding:"required"`
 }
 
 type UploadTermRequest struct {
-	LanguageID uint             `json:"language_id" binding:"required"`
-	Word       string           `json:"word" binding:"required"`
-	Terms      []UploadTermItem `json:"terms" binding:"required"`
+	// OrganizationID lets a super admin upload on behalf of an organization.
+	OrganizationID string           `json:"organization_id,omitempty"`
+	LanguageID     uint             `json:"language_id" binding:"required"`
+	Word           string           `json:"word" binding:"required"`
+	Terms          []UploadTermItem `json:"terms" binding:"required"`
+
+	// IfMatchVersion comes from the If-Match header and applies when the
+	// request updates a single term.
+	IfMatchVersion int64 `json:"-"`
 }
@@ -1,16 +1,43 @@
 package response
 
+import (
+	"term-service/pkg/publish"
+	"time"
+)
+
 type TermResDTO struct {
-	ID               string `json:"id"`
-	Title            string `json:"title"`
-	Color            string `json:"color"`
-	PublishedMobile  bool   `json:"published_mobile"`
-	PublishedDesktop bool   `json:"published_desktop"`
-	PublishedTeacher bool   `json:"published_teacher"`
-	PublishedParent  bool   `json:"published_parent"`
-	StartDate        string `json:"start_date"`
-	EndDate          string `json:"end_date"`
-	CreatedAt        string `json:"created_at"`
+	ID               string            `json:"id"`
+	Title            string            `json:"title"`
+	Color            string            `json:"color"`
+	PublishedMobile  bool              `json:"published_mobile"`
+	PublishedDesktop bool              `json:"published_desktop"`
+	PublishedTeacher bool              `json:"published_teacher"`
+	PublishedParent  bool              `json:"published_parent"`
+	StartDate        string            `json:"start_date"`
+	EndDate          string            `json:"end_date"`
+	CreatedAt        string            `json:"created_at"`
+	Version          int64             `json:"version"` // send back when updating
+	Audiences        publish.Audiences `json:"audiences"`
+	Periods          []PeriodResDTO    `json:"periods"`
+
+	// The published_* flags and windows mirror Audiences for clients that
+	// predate it.
+	PublishMobileAt    *time.Time `json:"publish_mobile_at,omitempty"`
+	UnpublishMobileAt  *time.Time `json:"unpublish_mobile_at,omitempty"`
+	PublishDesktopAt   *time.Time `json:"publish_desktop_at,omitempty"`
+	UnpublishDesktopAt *time.Time `json:"unpublish_desktop_at,omitempty"`
+	PublishTeacherAt   *time.Time `json:"publish_teacher_at,omitempty"`
+	UnpublishTeacherAt *time.Time `json:"unpublish_teacher_at,omitempty"`
+	PublishParentAt    *time.Time `json:"publish_parent_at,omitempty"`
+	UnpublishParentAt  *time.Time `json:"unpublish_parent_at,omitempty"`
+}
+
+type PeriodResDTO struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
 }
 
 type TermsByStudentResDTO struct {
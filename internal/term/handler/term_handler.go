@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -11,15 +12,19 @@ import (
 	"term-service/internal/term/mappers"
 	"term-service/internal/term/model"
 	"term-service/internal/term/service"
+	"term-service/pkg/db"
 	"term-service/pkg/helper"
 )
 
 type TermHandler struct {
-	service service.TermService
+	service        service.TermService
+	maxUploadItems int
 }
 
-func NewHandler(s service.TermService) *TermHandler {
-	return &TermHandler{service: s}
+// NewHandler builds the term handler. maxUploadItems caps the terms in one
+// upload; zero means no cap.
+func NewHandler(s service.TermService, maxUploadItems int) *TermHandler {
+	return &TermHandler{service: s, maxUploadItems: maxUploadItems}
 }
 
 func (h *TermHandler) CreateTerm(c *gin.Context) {
@@ -53,7 +58,7 @@ func (h *TermHandler) CreateTerm(c *gin.Context) {
 
 	res, err := h.service.CreateTerm(c.Request.Context(), term)
 	if err != nil {
-		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInvalidOperation)
+		helper.SendServiceError(c, http.StatusInternalServerError, err, helper.ErrInvalidOperation)
 		return
 	}
 
@@ -63,7 +68,7 @@ func (h *TermHandler) CreateTerm(c *gin.Context) {
 func (h *TermHandler) GetTerms4Web(c *gin.Context) {
 	terms, err := h.service.GetTerms4Web(c.Request.Context())
 	if err != nil {
-		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInvalidOperation)
+		helper.SendServiceError(c, http.StatusInternalServerError, err, helper.ErrInvalidOperation)
 		return
 	}
 
@@ -93,7 +98,7 @@ func (h *TermHandler) GetCurrentTerm(c *gin.Context) {
 
 	term, err := h.service.GetCurrentTermByOrg(c.Request.Context(), organizationID)
 	if err != nil {
-		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		helper.SendServiceError(c, http.StatusInternalServerError, err, helper.ErrInternal)
 		return
 	}
 
@@ -106,9 +111,22 @@ func (h *TermHandler) UploadTerm(c *gin.Context) {
 		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
 		return
 	}
+	if h.maxUploadItems > 0 && len(req.Terms) > h.maxUploadItems {
+		helper.SendError(c, http.StatusRequestEntityTooLarge, fmt.Errorf("upload has %d terms, at most %d allowed", len(req.Terms), h.maxUploadItems), helper.ErrPayloadTooLarge)
+		return
+	}
+	if req.OrganizationID == "" {
+		req.OrganizationID = helper.RequestedOrganizationID(c)
+	}
+	ifMatch, err := helper.IfMatchVersion(c)
+	if err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+	req.IfMatchVersion = ifMatch
 
 	if err := h.service.UploadTerms(c.Request.Context(), req); err != nil {
-		helper.SendError(c, http.StatusInternalServerError, err, err.Error())
+		helper.SendServiceError(c, http.StatusInternalServerError, err, err.Error())
 		return
 	}
 
@@ -122,9 +140,15 @@ func (h *TermHandler) GetTermsByOrgID(c *gin.Context) {
 		return
 	}
 
-	terms, err := h.service.GetTermsByOrgID(c.Request.Context(), orgID)
+	asOf, err := helper.AsOf(c)
+	if err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	terms, err := h.service.GetTermsByOrgID(c.Request.Context(), orgID, asOf)
 	if err != nil {
-		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInvalidOperation)
+		helper.SendServiceError(c, http.StatusInternalServerError, err, helper.ErrInvalidOperation)
 		return
 	}
 
@@ -140,7 +164,7 @@ func (h *TermHandler) GetTermsByStudent4App(c *gin.Context) {
 
 	terms, err := h.service.GetTermsByStudent4App(c.Request.Context(), studentID)
 	if err != nil {
-		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInvalidOperation)
+		helper.SendServiceError(c, http.StatusInternalServerError, err, helper.ErrInvalidOperation)
 		return
 	}
 
@@ -156,7 +180,7 @@ func (h *TermHandler) GetTermsByStudent4Web(c *gin.Context) {
 
 	terms, err := h.service.GetTermsByStudent4Web(c.Request.Context(), studentID)
 	if err != nil {
-		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInvalidOperation)
+		helper.SendServiceError(c, http.StatusInternalServerError, err, helper.ErrInvalidOperation)
 		return
 	}
 
@@ -172,7 +196,7 @@ func (h *TermHandler) GetTerms4App(c *gin.Context) {
 
 	terms, err := h.service.GetTerms4App(c.Request.Context(), organizationID)
 	if err != nil {
-		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		helper.SendServiceError(c, http.StatusInternalServerError, err, helper.ErrInternal)
 		return
 	}
 
@@ -185,9 +209,23 @@ func (h *TermHandler) GetTerm4Gw(c *gin.Context) {
 		helper.SendError(c, http.StatusBadRequest, fmt.Errorf("missing term_id in"), helper.ErrInvalidOperation)
 		return
 	}
-	res, err := h.service.GetTerm4Gw(c.Request.Context(), termID)
+	organizationID := c.Query("organization_id")
+	if organizationID == "" {
+		helper.SendError(c, http.StatusBadRequest, fmt.Errorf("missing organization_id in"), helper.ErrInvalidOperation)
+		return
+	}
+	asOf, err := helper.AsOf(c)
+	if err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+	res, err := h.service.GetTerm4Gw(c.Request.Context(), organizationID, termID, asOf)
+	if errors.Is(err, db.ErrNotFound) {
+		helper.SendError(c, http.StatusNotFound, err, helper.ErrNotFount)
+		return
+	}
 	if err != nil {
-		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		helper.SendServiceError(c, http.StatusInternalServerError, err, helper.ErrInternal)
 		return
 	}
 	helper.SendSuccess(c, http.StatusOK, "Success", res)
@@ -202,7 +240,7 @@ func (h *TermHandler) GetTermsByOrg4App(c *gin.Context) {
 
 	terms, err := h.service.GetTermsByOrg4App(c.Request.Context(), orgID)
 	if err != nil {
-		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInvalidOperation)
+		helper.SendServiceError(c, http.StatusInternalServerError, err, helper.ErrInvalidOperation)
 		return
 	}
 
@@ -222,7 +260,7 @@ func (h *TermHandler) GetPreviousTerm4GW(c *gin.Context) {
 	}
 	res, err := h.service.GetPreviousTerm4GW(c.Request.Context(), organizationID, termID)
 	if err != nil {
-		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		helper.SendServiceError(c, http.StatusInternalServerError, err, helper.ErrInternal)
 		return
 	}
 	helper.SendSuccess(c, http.StatusOK, "Success", res)
@@ -241,7 +279,7 @@ func (h *TermHandler) GetPreviousTerms4GW(c *gin.Context) {
 	}
 	res, err := h.service.GetPreviousTerms4GW(c.Request.Context(), organizationID, termID)
 	if err != nil {
-		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		helper.SendServiceError(c, http.StatusInternalServerError, err, helper.ErrInternal)
 		return
 	}
 	helper.SendSuccess(c, http.StatusOK, "Success", res)
@@ -249,9 +287,9 @@ func (h *TermHandler) GetPreviousTerms4GW(c *gin.Context) {
 
 func (h *TermHandler) GetTerms2Assign4Web(c *gin.Context) {
 
-	res, err := h.service.GetTerms2Assign4Web(c.Request.Context())
+	res, err := h.service.GetTerms2Assign4Web(c.Request.Context(), helper.RequestedOrganizationID(c))
 	if err != nil {
-		helper.SendError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+		helper.SendServiceError(c, http.StatusInternalServerError, err, helper.ErrInternal)
 		return
 	}
 	helper.SendSuccess(c, http.StatusOK, "Success", res)
@@ -1,25 +1,32 @@
 package mappers
 
 import (
+	"term-service/internal/term/dto/event"
+	"term-service/internal/term/dto/request"
 	"term-service/internal/term/dto/response"
 	"term-service/internal/term/model"
 	"term-service/pkg/helper"
+	"term-service/pkg/publish"
 	"time"
 )
 
 func MapTermToResDTO(term *model.Term) response.TermResDTO {
-	return response.TermResDTO{
-		ID:               term.ID.Hex(),
-		Title:            term.Title,
-		Color:            term.Color,
-		PublishedMobile:  term.PublishedMobile,
-		PublishedDesktop: term.PublishedDesktop,
-		PublishedTeacher: term.PublishedTeacher,
-		PublishedParent:  term.PublishedParent,
-		StartDate:        helper.FormatDate(term.StartDate),
-		EndDate:          helper.FormatDate(term.EndDate),
-		CreatedAt:        helper.FormatDate(term.CreatedAt),
+	res := response.TermResDTO{
+		ID:        term.ID.Hex(),
+		Title:     term.Title,
+		Color:     term.Color,
+		StartDate: helper.FormatDate(term.StartDate),
+		EndDate:   helper.FormatDate(term.EndDate),
+		CreatedAt: helper.FormatDate(term.CreatedAt),
+		Version:   term.Version,
+		Audiences: nonNilAudiences(term.Audiences),
+		Periods:   MapPeriodsToResDTO(term.Periods),
 	}
+	res.PublishedMobile, res.PublishMobileAt, res.UnpublishMobileAt = term.Audiences.Flag(publish.Mobile)
+	res.PublishedDesktop, res.PublishDesktopAt, res.UnpublishDesktopAt = term.Audiences.Flag(publish.Desktop)
+	res.PublishedTeacher, res.PublishTeacherAt, res.UnpublishTeacherAt = term.Audiences.Flag(publish.Teacher)
+	res.PublishedParent, res.PublishParentAt, res.UnpublishParentAt = term.Audiences.Flag(publish.Parent)
+	return res
 }
 
 func MapTermListToResDTO(terms []*model.Term) []response.TermResDTO {
@@ -40,7 +47,7 @@ func MapTermToCurrentResDTO(term *model.Term, word string) response.CurrentTermR
 		remaining = 0
 	}
 	// gert current wweek
-	currentWeek := calculateCurrentWeek(term.StartDate, term.EndDate, now)
+	currentWeek := calculateCurrentWeek(term, now)
 
 	return response.CurrentTermResDTO{
 		ID:           term.ID.Hex(),
@@ -51,7 +58,22 @@ func MapTermToCurrentResDTO(term *model.Term, word string) response.CurrentTermR
 		CreatedAt:    term.CreatedAt.Format(layout),
 		RemaningDate: helper.FormatRemainingDays(remaining),
 		CurrentWeek:  currentWeek,
+		Periods:      MapPeriodsToResDTO(term.Periods),
+	}
+}
+
+func MapPeriodsToResDTO(periods model.Periods) []response.PeriodResDTO {
+	res := make([]response.PeriodResDTO, 0, len(periods))
+	for _, p := range periods {
+		res = append(res, response.PeriodResDTO{
+			ID:        p.ID,
+			Type:      p.Type,
+			Title:     p.Title,
+			StartDate: helper.FormatDate(p.StartDate),
+			EndDate:   helper.FormatDate(p.EndDate),
+		})
 	}
+	return res
 }
 
 func daysBetweenDateOnly(start, end time.Time) int {
@@ -65,26 +87,23 @@ func daysBetweenDateOnly(start, end time.Time) int {
 	return int(endDate.Sub(startDate).Hours() / 24)
 }
 
-func calculateCurrentWeek(start, end, now time.Time) int {
-	loc := end.Location()
-	sy, sm, sd := start.In(loc).Date()
-	ey, em, ed := end.In(loc).Date()
+// calculateCurrentWeek returns the number of the term's week containing
+// now, or of the last one before it when that week is a break.
+func calculateCurrentWeek(term *model.Term, now time.Time) int {
+	loc := term.EndDate.Location()
 	ny, nm, nd := now.In(loc).Date()
-
-	startDate := time.Date(sy, sm, sd, 0, 0, 0, 0, loc)
-	endDate := time.Date(ey, em, ed, 0, 0, 0, 0, loc)
 	nowDate := time.Date(ny, nm, nd, 0, 0, 0, 0, loc)
 
-	if nowDate.Before(startDate) {
-		return 0
-	}
-	if nowDate.After(endDate) {
-		totalDays := int(endDate.Sub(startDate).Hours() / 24)
-		return (totalDays / 7) + 1
+	current := 0
+	for _, w := range term.Weeks() {
+		if w.StartDate.After(nowDate) {
+			break
+		}
+		if w.Number > 0 {
+			current = w.Number
+		}
 	}
-
-	daysPassed := int(nowDate.Sub(startDate).Hours() / 24)
-	return (daysPassed / 7) + 1
+	return current
 }
 
 func MapTermsByStudentToResDTO(terms []*model.Term, word string) []response.TermsByStudentResDTO {
@@ -104,14 +123,13 @@ func MapTermListToCurrentResDTO(terms []*model.Term, word string) []response.Cur
 		return []response.CurrentTermResDTO{}
 	}
 
+	now := time.Now()
 	res := make([]response.CurrentTermResDTO, 0, len(terms))
 	for _, t := range terms {
-		if !t.PublishedMobile {
+		if t == nil || !t.VisibleOn(publish.Mobile, now) {
 			continue
 		}
-		if t != nil {
-			res = append(res, MapTermToCurrentResDTO(t, word))
-		}
+		res = append(res, MapTermToCurrentResDTO(t, word))
 	}
 	return res
 }
@@ -126,9 +144,10 @@ func MapTermToRes4GwResponse(term *model.Term, word string) *response.Term4GwRes
 }
 
 func MapTermsByToRes4App(terms []*model.Term, word string) []response.TermResponse4App {
+	now := time.Now()
 	result := make([]response.TermResponse4App, 0, len(terms))
 	for _, term := range terms {
-		if !term.PublishedTeacher {
+		if !term.VisibleOn(publish.Teacher, now) {
 			continue
 		}
 		result = append(result, response.TermResponse4App{
@@ -156,3 +175,52 @@ func MapTermToRes4WebResponse(term *model.Term, word string) *response.TermRespo
 		EndDate:   helper.FormatDate(term.EndDate),
 	}
 }
+
+func MapTermToEventData(term *model.Term) event.TermData {
+	data := event.TermData{
+		ID:             term.ID.Hex(),
+		OrganizationID: term.OrganizationID,
+		Title:          term.Title,
+		Color:          term.Color,
+		StartDate:      helper.FormatDate(term.StartDate),
+		EndDate:        helper.FormatDate(term.EndDate),
+		Version:        term.Version,
+		Audiences:      nonNilAudiences(term.Audiences),
+		Periods:        make([]event.PeriodData, 0, len(term.Periods)),
+	}
+	for _, p := range term.Periods {
+		data.Periods = append(data.Periods, event.PeriodData{
+			ID:        p.ID,
+			Type:      p.Type,
+			Title:     p.Title,
+			StartDate: helper.FormatDate(p.StartDate),
+			EndDate:   helper.FormatDate(p.EndDate),
+		})
+	}
+	data.PublishedMobile, data.PublishMobileAt, data.UnpublishMobileAt = term.Audiences.Flag(publish.Mobile)
+	data.PublishedDesktop, data.PublishDesktopAt, data.UnpublishDesktopAt = term.Audiences.Flag(publish.Desktop)
+	data.PublishedTeacher, data.PublishTeacherAt, data.UnpublishTeacherAt = term.Audiences.Flag(publish.Teacher)
+	data.PublishedParent, data.PublishParentAt, data.UnpublishParentAt = term.Audiences.Flag(publish.Parent)
+	return data
+}
+
+// MapUploadTermItemToAudiences returns the audiences of an uploaded term,
+// falling back to the legacy per-channel flags when none are listed.
+func MapUploadTermItemToAudiences(t request.UploadTermItem) publish.Audiences {
+	if len(t.Audiences) > 0 {
+		return t.Audiences
+	}
+	return publish.Audiences(nil).
+		WithFlag(publish.Mobile, t.PublishedMobile, t.PublishMobileAt, t.UnpublishMobileAt).
+		WithFlag(publish.Desktop, t.PublishedDesktop, t.PublishDesktopAt, t.UnpublishDesktopAt).
+		WithFlag(publish.Teacher, t.PublishedTeacher, t.PublishTeacherAt, t.UnpublishTeacherAt).
+		WithFlag(publish.Parent, t.PublishedParent, t.PublishParentAt, t.UnpublishParentAt)
+}
+
+// nonNilAudiences keeps "audiences" an array in JSON.
+func nonNilAudiences(as publish.Audiences) publish.Audiences {
+	if as == nil {
+		return publish.Audiences{}
+	}
+	return as
+}
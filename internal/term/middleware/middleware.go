@@ -1,14 +1,20 @@
 package middleware
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
 	"term-service/internal/gateway"
-	"term-service/logger"
+	"term-service/internal/gateway/dto"
+	"term-service/internal/policy"
 	"term-service/pkg/constants"
 	"term-service/pkg/helper"
+	"term-service/pkg/servicetoken"
+	"term-service/pkg/zap"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
@@ -39,6 +45,10 @@ func Secured() gin.HandlerFunc {
 
 		token, _, _ := new(jwt.Parser).ParseUnverified(tokenString, jwt.MapClaims{})
 
+		if token == nil {
+			// not a JWT; the user service refuses it
+			token = &jwt.Token{}
+		}
 		if claims, ok := token.Claims.(jwt.MapClaims); ok {
 			// --- UserID ---
 			if userId, ok := claims[constants.UserID.String()].(string); ok {
@@ -56,6 +66,13 @@ func Secured() gin.HandlerFunc {
 				c.Request = c.Request.WithContext(ctx)
 			}
 
+			// --- Organization ---
+			if orgID, ok := claims[constants.OrganizationID.String()].(string); ok {
+				c.Set(constants.OrganizationID.String(), orgID)
+				ctx := context.WithValue(c.Request.Context(), constants.OrganizationID, orgID)
+				c.Request = c.Request.WithContext(ctx)
+			}
+
 			// --- Roles ---
 			if userRoles, ok := claims[constants.UserRoles.String()].(string); ok {
 				c.Set(constants.UserRoles.String(), userRoles)
@@ -73,6 +90,41 @@ func Secured() gin.HandlerFunc {
 	}
 }
 
+// ServiceAuth admits only internal services presenting a valid service
+// token. The caller is recorded under constants.ServiceName; no end-user
+// token is placed in the context, so onward gateway calls use this
+// service's own token.
+func ServiceAuth(verifier servicetoken.Verifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		appLanguage := helper.ParseAppLanguage(c.GetHeader("X-App-Language"), 1)
+		c.Writer.Header().Set("X-App-Language", strconv.Itoa(int(appLanguage)))
+		c.Set(constants.AppLanguage.String(), appLanguage)
+		ctx := context.WithValue(c.Request.Context(), constants.AppLanguage, appLanguage)
+		c.Request = c.Request.WithContext(ctx)
+
+		authorizationHeader := c.GetHeader("Authorization")
+		if !strings.HasPrefix(authorizationHeader, "Bearer ") {
+			helper.SendError(c, http.StatusUnauthorized, nil, helper.ErrUnauthorized)
+			c.Abort()
+			return
+		}
+
+		claims, err := verifier.Verify(strings.TrimPrefix(authorizationHeader, "Bearer "))
+		if err != nil {
+			helper.SendError(c, http.StatusUnauthorized, err, helper.ErrUnauthorized)
+			c.Abort()
+			return
+		}
+
+		c.Set(constants.ServiceName.String(), claims.Caller())
+		c.Request = c.Request.WithContext(
+			context.WithValue(c.Request.Context(), constants.ServiceName, claims.Caller()),
+		)
+
+		c.Next()
+	}
+}
+
 func SecuredV2(userGW gateway.UserGateway) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authorizationHeader := c.GetHeader("Authorization")
@@ -95,6 +147,10 @@ func SecuredV2(userGW gateway.UserGateway) gin.HandlerFunc {
 
 		// parse unverified để extract claims nếu cần
 		token, _, _ := new(jwt.Parser).ParseUnverified(tokenString, jwt.MapClaims{})
+		if token == nil {
+			// not a JWT; the user service refuses it
+			token = &jwt.Token{}
+		}
 		if claims, ok := token.Claims.(jwt.MapClaims); ok {
 			// optional: bạn vẫn có thể lấy claim trước khi call user-service
 			if userId, ok := claims[constants.UserID.String()].(string); ok {
@@ -107,9 +163,7 @@ func SecuredV2(userGW gateway.UserGateway) gin.HandlerFunc {
 			context.WithValue(c.Request.Context(), constants.Token, tokenString),
 		)
 		if err != nil {
-			logger.WriteLogEx("error", "failed to get current user", map[string]any{
-				"error": err.Error(),
-			})
+			zap.FromContext(c.Request.Context()).Errorw("failed to get current user", "error", err.Error())
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"error": "unauthorized",
 			})
@@ -123,6 +177,14 @@ func SecuredV2(userGW gateway.UserGateway) gin.HandlerFunc {
 		ctx := context.WithValue(c.Request.Context(), constants.CurrentUserKey, currentUser)
 		c.Request = c.Request.WithContext(ctx)
 
+		// organization cho log correlation
+		if orgID := currentUserOrganizationID(currentUser); orgID != "" {
+			c.Set(constants.OrganizationID.String(), orgID)
+			c.Request = c.Request.WithContext(
+				context.WithValue(c.Request.Context(), constants.OrganizationID, orgID),
+			)
+		}
+
 		// cũng set token để reuse
 		c.Set(constants.Token.String(), tokenString)
 		c.Request = c.Request.WithContext(
@@ -133,76 +195,120 @@ func SecuredV2(userGW gateway.UserGateway) gin.HandlerFunc {
 	}
 }
 
-func RequireAdmin() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		rolesAny, exists := c.Get(constants.UserRoles.String())
-		if !exists {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Roles not found"})
-			return
-		}
+func currentUserOrganizationID(u *dto.CurrentUser) string {
+	if orgID := policy.AdminOrganization(u); orgID != "" {
+		return orgID
+	}
+	return u.OrganizationIdActive
+}
 
-		rolesStr, ok := rolesAny.(string)
-		if !ok {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Invalid roles format"})
-			return
-		}
+// OrganizationResolver picks the organization a request acts on.
+type OrganizationResolver func(c *gin.Context, user *dto.CurrentUser) string
 
-		// ví dụ roles: "SuperAdmin, Teacher"
-		roles := strings.Split(rolesStr, ",")
-		isAdmin := false
-		for _, role := range roles {
-			if strings.TrimSpace(role) == "SuperAdmin" {
-				isAdmin = true
-				break
-			}
-		}
+// AdminScope targets the organization the caller administers, or every
+// organization for super admins without one.
+func AdminScope(c *gin.Context, user *dto.CurrentUser) string {
+	return policy.AdminScope(user)
+}
 
-		if !isAdmin {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
-			return
-		}
+// AdminTarget targets the organization explicitly requested through the
+// X-Organization-ID header, organization_id query or JSON body field, and
+// otherwise behaves like AdminScope.
+func AdminTarget(c *gin.Context, user *dto.CurrentUser) string {
+	requested := helper.RequestedOrganizationID(c)
+	if requested == "" {
+		requested = bodyOrganizationID(c)
+	}
+	return policy.TargetOrganization(user, requested)
+}
 
-		c.Next()
+// MemberTarget is AdminTarget for actions members may perform: without an
+// explicit organization it targets the caller's own.
+func MemberTarget(c *gin.Context, user *dto.CurrentUser) string {
+	requested := helper.RequestedOrganizationID(c)
+	if requested == "" {
+		requested = bodyOrganizationID(c)
+	}
+	if requested == "" && user != nil {
+		requested = currentUserOrganizationID(user)
+	}
+	return policy.TargetOrganization(user, requested)
+}
+
+// bodyOrganizationID peeks at organization_id in a JSON body and restores
+// the body for the handler.
+func bodyOrganizationID(c *gin.Context) string {
+	if c.Request.Body == nil || c.ContentType() != "application/json" {
+		return ""
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+
+	var payload struct {
+		OrganizationID string `json:"organization_id"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(payload.OrganizationID)
+}
+
+// OrganizationParam targets the organization named by a path parameter.
+func OrganizationParam(name string) OrganizationResolver {
+	return func(c *gin.Context, user *dto.CurrentUser) string {
+		return c.Param(name)
 	}
 }
 
-func RequireIsSuperAdminOrOrgAdmin() gin.HandlerFunc {
+// OrganizationQuery targets the organization named by a query parameter.
+func OrganizationQuery(name string) OrganizationResolver {
+	return func(c *gin.Context, user *dto.CurrentUser) string {
+		return c.Query(name)
+	}
+}
+
+// Authorize loads the current user (unless SecuredV2 already did) and lets
+// the request through only if policy allows action on the resolved
+// organization. It must run after Secured or SecuredV2.
+func Authorize(userGW gateway.UserGateway, action policy.Action, resolve OrganizationResolver) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		currentUser, ok := helper.CurrentUserFromCtx(c.Request.Context())
 		if !ok || currentUser == nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "current user not found in context",
-			})
-			return
-		}
-
-		// nếu user là SuperAdmin thì pass ngay
-		if currentUser.IsSuperAdmin {
-			c.Next()
-			return
+			u, err := userGW.GetCurrentUser(c.Request.Context())
+			if err != nil {
+				helper.SendError(c, http.StatusUnauthorized, err, helper.ErrUnauthorized)
+				c.Abort()
+				return
+			}
+			currentUser = u
+			c.Set(string(constants.CurrentUserKey), currentUser)
+			c.Request = c.Request.WithContext(
+				context.WithValue(c.Request.Context(), constants.CurrentUserKey, currentUser),
+			)
 		}
 
-		// kiểm tra Roles slice
-		if currentUser.Roles == nil || len(*currentUser.Roles) == 0 {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"error": "roles not found for current user",
-			})
+		organizationID := resolve(c, currentUser)
+		if err := policy.Authorize(currentUser, action, organizationID); err != nil {
+			helper.SendServiceError(c, http.StatusForbidden, err, helper.ErrForbidden)
+			c.Abort()
 			return
 		}
 
-		isAllowed := false
-		for _, role := range *currentUser.Roles {
-			if strings.EqualFold(role.RoleName, "SuperAdmin") {
-				isAllowed = true
-				break
-			}
-		}
-
-		if !isAllowed {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"error": "permission denied: require SuperAdmin or OrgAdmin",
-			})
-			return
+		c.Set(constants.CallerID.String(), currentUser.ID)
+		c.Set(constants.TargetOrgID.String(), organizationID)
+
+		if policy.IsImpersonating(currentUser, organizationID) {
+			zap.FromContext(c.Request.Context()).Infow("super admin acting on behalf of organization",
+				"actor_id", currentUser.ID,
+				"target_organization_id", organizationID,
+				"action", string(action),
+				"method", c.Request.Method,
+				"path", c.FullPath(),
+			)
 		}
 
 		c.Next()
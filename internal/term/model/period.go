@@ -0,0 +1,112 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Period types.
+const (
+	PeriodExam        = "exam"
+	PeriodBreak       = "break"
+	PeriodOrientation = "orientation"
+	PeriodCustom      = "custom"
+)
+
+// PeriodTypes lists every known period type.
+var PeriodTypes = []string{PeriodExam, PeriodBreak, PeriodOrientation, PeriodCustom}
+
+// Period is a typed date range inside a term, such as an exam window or a
+// mid-term break. Both dates are inclusive.
+type Period struct {
+	ID        string    `bson:"id" json:"id"`
+	Type      string    `bson:"type" json:"type"`
+	Title     string    `bson:"title" json:"title"`
+	StartDate time.Time `bson:"start_date" json:"start_date"`
+	EndDate   time.Time `bson:"end_date" json:"end_date"`
+}
+
+// Contains reports whether day falls within the period.
+func (p Period) Contains(day time.Time) bool {
+	return !day.Before(p.StartDate) && !day.After(p.EndDate)
+}
+
+// Periods are the sub-periods of a term.
+type Periods []Period
+
+// Validate rejects unknown types, untitled custom periods and periods that
+// end before they start or leave [start, end].
+func (ps Periods) Validate(start, end time.Time) error {
+	var errs []error
+	for _, p := range ps {
+		switch {
+		case !slices.Contains(PeriodTypes, p.Type):
+			errs = append(errs, fmt.Errorf("unknown period type %q", p.Type))
+		case p.Type == PeriodCustom && p.Title == "":
+			errs = append(errs, errors.New("custom period needs a title"))
+		case p.EndDate.Before(p.StartDate):
+			errs = append(errs, fmt.Errorf("%s period %q ends before it starts", p.Type, p.Title))
+		case p.StartDate.Before(start) || p.EndDate.After(end):
+			errs = append(errs, fmt.Errorf("%s period %q lies outside the term", p.Type, p.Title))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Find returns the period with id.
+func (ps Periods) Find(id string) (Period, bool) {
+	for _, p := range ps {
+		if p.ID == id {
+			return p, true
+		}
+	}
+	return Period{}, false
+}
+
+// Equal reports whether ps and other hold the same periods in the same order.
+func (ps Periods) Equal(other Periods) bool {
+	return slices.EqualFunc(ps, other, func(a, b Period) bool {
+		return a.ID == b.ID && a.Type == b.Type && a.Title == b.Title &&
+			a.StartDate.Equal(b.StartDate) && a.EndDate.Equal(b.EndDate)
+	})
+}
+
+// InBreak reports whether day falls within a break.
+func (ps Periods) InBreak(day time.Time) bool {
+	for _, p := range ps {
+		if p.Type == PeriodBreak && p.Contains(day) {
+			return true
+		}
+	}
+	return false
+}
+
+// Value stores periods as JSON text in SQL databases.
+func (ps Periods) Value() (driver.Value, error) {
+	b, err := json.Marshal(ps)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (ps *Periods) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*ps = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, ps)
+	case string:
+		return json.Unmarshal([]byte(v), ps)
+	}
+	return fmt.Errorf("scan periods from %T", src)
+}
+
+func (Periods) GormDataType() string {
+	return "text"
+}
@@ -1,22 +1,26 @@
 package model
 
 import (
+	"term-service/pkg/objectid"
+	"term-service/pkg/publish"
 	"time"
-
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type Term struct {
-	ID               primitive.ObjectID `bson:"_id,omitempty"`
-	OrganizationID   string             `bson:"organization_id"`
-	Title            string             `bson:"title"`
-	Color            string             `bson:"color"`
-	PublishedMobile  bool               `bson:"published_mobile"`
-	PublishedDesktop bool               `bson:"published_desktop"`
-	PublishedTeacher bool               `bson:"published_teacher"`
-	PublishedParent  bool               `bson:"published_parent"`
-	StartDate        time.Time          `bson:"start_date"`
-	EndDate          time.Time          `bson:"end_date"`
-	CreatedAt        time.Time          `bson:"created_at"`
-	UpdatedAt        time.Time          `bson:"updated_at"`
+	ID             objectid.ID       `bson:"_id,omitempty" gorm:"primaryKey;size:24"`
+	OrganizationID string            `bson:"organization_id" gorm:"size:64;index:idx_terms_org_start,priority:1"`
+	Title          string            `bson:"title"`
+	Color          string            `bson:"color" gorm:"size:32"`
+	Audiences      publish.Audiences `bson:"audiences"` // channels the term is published to
+	StartDate      time.Time         `bson:"start_date" gorm:"index:idx_terms_org_start,priority:2"`
+	EndDate        time.Time         `bson:"end_date"`
+	Periods        Periods           `bson:"periods"` // exam windows, breaks and the like
+	CreatedAt      time.Time         `bson:"created_at"`
+	UpdatedAt      time.Time         `bson:"updated_at"`
+	Version        int64             `bson:"version" gorm:"not null;default:1"` // bumped by every update
+}
+
+// VisibleOn reports whether the term is shown on channel at now.
+func (t *Term) VisibleOn(channel string, now time.Time) bool {
+	return t.Audiences.VisibleOn(channel, now)
 }
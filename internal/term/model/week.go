@@ -0,0 +1,55 @@
+package model
+
+import "time"
+
+// Week is a 7-day block of a term, counted from its start date; the last
+// one ends with the term. Weeks whose weekdays all lie within breaks are
+// not counted and have Number 0.
+type Week struct {
+	Number    int
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// Weeks splits the term into weeks, in the location of its end date.
+func (t *Term) Weeks() []Week {
+	loc := t.EndDate.Location()
+	start := dateIn(t.StartDate, loc)
+	end := dateIn(t.EndDate, loc)
+
+	var weeks []Week
+	number := 0
+	for from := start; !from.After(end); from = from.AddDate(0, 0, 7) {
+		to := from.AddDate(0, 0, 6)
+		if to.After(end) {
+			to = end
+		}
+		w := Week{StartDate: from, EndDate: to}
+		if !t.Periods.allBreak(from, to) {
+			number++
+			w.Number = number
+		}
+		weeks = append(weeks, w)
+	}
+	return weeks
+}
+
+// allBreak reports whether the days from from to to include a break and
+// every weekday among them falls within one.
+func (ps Periods) allBreak(from, to time.Time) bool {
+	inBreak := false
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		switch {
+		case ps.InBreak(day):
+			inBreak = true
+		case day.Weekday() != time.Saturday && day.Weekday() != time.Sunday:
+			return false
+		}
+	}
+	return inBreak
+}
+
+func dateIn(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,33 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"term-service/internal/term/model"
+)
+
+func TestWeeksSkipsBreakWeeks(t *testing.T) {
+	date := func(month time.Month, d int) time.Time { return time.Date(2025, month, d, 0, 0, 0, 0, time.UTC) }
+	term := &model.Term{StartDate: date(1, 6), EndDate: date(2, 9), Periods: model.Periods{
+		// Monday to Friday only; the weekend around it is not part of the break.
+		{Type: model.PeriodBreak, StartDate: date(1, 13), EndDate: date(1, 17)},
+		// Part of a week still counts.
+		{Type: model.PeriodBreak, StartDate: date(1, 27), EndDate: date(1, 29)},
+		{Type: model.PeriodExam, StartDate: date(2, 3), EndDate: date(2, 7)},
+	}}
+
+	var numbers []int
+	for _, w := range term.Weeks() {
+		numbers = append(numbers, w.Number)
+	}
+	want := []int{1, 0, 2, 3, 4}
+	if len(numbers) != len(want) {
+		t.Fatalf("week numbers = %v, want %v", numbers, want)
+	}
+	for i := range want {
+		if numbers[i] != want[i] {
+			t.Fatalf("week numbers = %v, want %v", numbers, want)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"term-service/internal/term/model"
+	"term-service/pkg/db"
+	"term-service/pkg/db/txn"
+	"term-service/pkg/objectid"
+	"term-service/pkg/publish"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type gormTermRepository struct {
+	db *gorm.DB
+}
+
+// NewGormTermRepository returns a TermRepository backed by MySQL (or any
+// other GORM dialect).
+func NewGormTermRepository(db *gorm.DB) TermRepository {
+	return &gormTermRepository{db}
+}
+
+func (r *gormTermRepository) Create(ctx context.Context, term *model.Term) (*model.Term, error) {
+	now := time.Now()
+	if term.ID.IsZero() {
+		term.ID = objectid.New()
+	}
+	term.CreatedAt = now
+	term.UpdatedAt = now
+	term.Version = 1
+
+	if err := txn.Gorm(ctx, r.db).Create(term).Error; err != nil {
+		return nil, err
+	}
+	return term, nil
+}
+
+func (r *gormTermRepository) GetByID(ctx context.Context, id string) (*model.Term, error) {
+	var term model.Term
+	err := txn.Gorm(ctx, r.db).Where("id = ?", id).First(&term).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, db.ErrNotFound
+		}
+		return nil, err
+	}
+	return &term, nil
+}
+
+func (r *gormTermRepository) Update(ctx context.Context, id string, updated *model.Term) error {
+	updated.UpdatedAt = time.Now()
+
+	result := txn.Gorm(ctx, r.db).Model(&model.Term{}).Where("id = ? AND version = ?", id, updated.Version).Updates(map[string]interface{}{
+		"title":      updated.Title,
+		"start_date": updated.StartDate,
+		"color":      updated.Color,
+		"audiences":  updated.Audiences,
+		"periods":    updated.Periods,
+		"end_date":   updated.EndDate,
+		"updated_at": updated.UpdatedAt,
+		"version":    gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		var exists int64
+		if err := txn.Gorm(ctx, r.db).Model(&model.Term{}).Where("id = ?", id).Count(&exists).Error; err != nil {
+			return err
+		}
+		if exists == 0 {
+			return db.ErrNotFound
+		}
+		return db.ErrVersionConflict
+	}
+	updated.Version++
+	return nil
+}
+
+func (r *gormTermRepository) Delete(ctx context.Context, id string) error {
+	result := txn.Gorm(ctx, r.db).Where("id = ?", id).Delete(&model.Term{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormTermRepository) GetAll(ctx context.Context) ([]*model.Term, error) {
+	var terms []*model.Term
+	err := txn.Gorm(ctx, r.db).Find(&terms).Error
+	return terms, err
+}
+
+func (r *gormTermRepository) GetCurrentTerm(ctx context.Context) (*model.Term, error) {
+	now := time.Now()
+	return r.first(txn.Gorm(ctx, r.db).Where("start_date <= ? AND end_date >= ?", now, now))
+}
+
+func (r *gormTermRepository) GetCurrentTermByOrg(ctx context.Context, organizationID string) (*model.Term, error) {
+	now := time.Now()
+	return r.first(txn.Gorm(ctx, r.db).
+		Where("organization_id = ? AND start_date <= ? AND end_date >= ?", organizationID, now, now))
+}
+
+func (r *gormTermRepository) GetAllByOrgID(ctx context.Context, orgID string) ([]*model.Term, error) {
+	var terms []*model.Term
+	err := txn.Gorm(ctx, r.db).
+		Where("organization_id = ?", orgID).
+		Order("start_date ASC").
+		Find(&terms).Error
+	return terms, err
+}
+
+func (r *gormTermRepository) GetAllVisibleByOrgID(ctx context.Context, orgID string, channel string) ([]*model.Term, error) {
+	var candidates []*model.Term
+	err := publish.GormCandidates(txn.Gorm(ctx, r.db).Where("organization_id = ?", orgID), channel).
+		Order("created_at ASC").
+		Find(&candidates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	terms := make([]*model.Term, 0, len(candidates))
+	for _, t := range candidates {
+		if t.VisibleOn(channel, now) {
+			terms = append(terms, t)
+		}
+	}
+	return terms, nil
+}
+
+func (r *gormTermRepository) GetPreviousTerm(ctx context.Context, orgID string, termID string) (*model.Term, error) {
+	current, err := r.first(txn.Gorm(ctx, r.db).Where("id = ? AND organization_id = ?", termID, orgID))
+	if err != nil || current == nil {
+		return nil, err
+	}
+
+	return r.first(txn.Gorm(ctx, r.db).
+		Where("organization_id = ? AND start_date < ?", orgID, current.StartDate).
+		Order("start_date DESC"))
+}
+
+func (r *gormTermRepository) GetPreviousTerms(ctx context.Context, orgID string, termID string) ([]model.Term, error) {
+	current, err := r.first(txn.Gorm(ctx, r.db).Where("id = ? AND organization_id = ?", termID, orgID))
+	if err != nil || current == nil {
+		return nil, err
+	}
+
+	var previousTerms []model.Term
+	err = txn.Gorm(ctx, r.db).
+		Where("organization_id = ? AND start_date < ?", orgID, current.StartDate).
+		Order("start_date DESC").
+		Find(&previousTerms).Error
+	return previousTerms, err
+}
+
+// first returns nil, nil when nothing matches, like the Mongo lookups.
+func (r *gormTermRepository) first(query *gorm.DB) (*model.Term, error) {
+	var term model.Term
+	err := query.First(&term).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &term, nil
+}
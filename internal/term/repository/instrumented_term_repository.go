@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"term-service/internal/term/model"
+	"term-service/pkg/metrics"
+	"term-service/pkg/tracing"
+	"time"
+)
+
+const repoName = "term"
+
+// instrumentedTermRepository records timings and a tracing span for every repository call.
+type instrumentedTermRepository struct {
+	next TermRepository
+}
+
+func NewInstrumentedTermRepository(next TermRepository) TermRepository {
+	return &instrumentedTermRepository{next}
+}
+
+func (r *instrumentedTermRepository) instrument(ctx context.Context, method string) (context.Context, func(*error)) {
+	start := time.Now()
+	ctx, span := tracing.StartSpan(ctx, "TermRepository."+method)
+	return ctx, func(err *error) {
+		metrics.ObserveRepository(repoName, method, start, err)
+		tracing.EndSpan(span, *err)
+	}
+}
+
+func (r *instrumentedTermRepository) Create(ctx context.Context, term *model.Term) (res *model.Term, err error) {
+	ctx, done := r.instrument(ctx, "Create")
+	defer done(&err)
+	return r.next.Create(ctx, term)
+}
+
+func (r *instrumentedTermRepository) GetByID(ctx context.Context, id string) (res *model.Term, err error) {
+	ctx, done := r.instrument(ctx, "GetByID")
+	defer done(&err)
+	return r.next.GetByID(ctx, id)
+}
+
+func (r *instrumentedTermRepository) Update(ctx context.Context, id string, term *model.Term) (err error) {
+	ctx, done := r.instrument(ctx, "Update")
+	defer done(&err)
+	return r.next.Update(ctx, id, term)
+}
+
+func (r *instrumentedTermRepository) Delete(ctx context.Context, id string) (err error) {
+	ctx, done := r.instrument(ctx, "Delete")
+	defer done(&err)
+	return r.next.Delete(ctx, id)
+}
+
+func (r *instrumentedTermRepository) GetAll(ctx context.Context) (res []*model.Term, err error) {
+	ctx, done := r.instrument(ctx, "GetAll")
+	defer done(&err)
+	return r.next.GetAll(ctx)
+}
+
+func (r *instrumentedTermRepository) GetCurrentTerm(ctx context.Context) (res *model.Term, err error) {
+	ctx, done := r.instrument(ctx, "GetCurrentTerm")
+	defer done(&err)
+	return r.next.GetCurrentTerm(ctx)
+}
+
+func (r *instrumentedTermRepository) GetAllByOrgID(ctx context.Context, orgID string) (res []*model.Term, err error) {
+	ctx, done := r.instrument(ctx, "GetAllByOrgID")
+	defer done(&err)
+	return r.next.GetAllByOrgID(ctx, orgID)
+}
+
+func (r *instrumentedTermRepository) GetCurrentTermByOrg(ctx context.Context, organizationID string) (res *model.Term, err error) {
+	ctx, done := r.instrument(ctx, "GetCurrentTermByOrg")
+	defer done(&err)
+	return r.next.GetCurrentTermByOrg(ctx, organizationID)
+}
+
+func (r *instrumentedTermRepository) GetAllVisibleByOrgID(ctx context.Context, orgID string, channel string) (res []*model.Term, err error) {
+	ctx, done := r.instrument(ctx, "GetAllVisibleByOrgID")
+	defer done(&err)
+	return r.next.GetAllVisibleByOrgID(ctx, orgID, channel)
+}
+
+func (r *instrumentedTermRepository) GetPreviousTerm(ctx context.Context, orgID string, termID string) (res *model.Term, err error) {
+	ctx, done := r.instrument(ctx, "GetPreviousTerm")
+	defer done(&err)
+	return r.next.GetPreviousTerm(ctx, orgID, termID)
+}
+
+func (r *instrumentedTermRepository) GetPreviousTerms(ctx context.Context, orgID string, termID string) (res []model.Term, err error) {
+	ctx, done := r.instrument(ctx, "GetPreviousTerms")
+	defer done(&err)
+	return r.next.GetPreviousTerms(ctx, orgID, termID)
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"term-service/internal/term/model"
+	"term-service/pkg/db"
+	"term-service/pkg/objectid"
+	"time"
+)
+
+type memoryTermRepository struct {
+	mu    sync.RWMutex
+	terms map[objectid.ID]model.Term
+}
+
+// NewMemoryTermRepository returns a TermRepository kept in process memory,
+// for tests and local runs without a database.
+func NewMemoryTermRepository() TermRepository {
+	return &memoryTermRepository{terms: make(map[objectid.ID]model.Term)}
+}
+
+func (r *memoryTermRepository) Create(ctx context.Context, term *model.Term) (*model.Term, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if term.ID.IsZero() {
+		term.ID = objectid.New()
+	}
+	term.CreatedAt = now
+	term.UpdatedAt = now
+	term.Version = 1
+
+	r.terms[term.ID] = *term
+	return term, nil
+}
+
+func (r *memoryTermRepository) GetByID(ctx context.Context, id string) (*model.Term, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	term, ok := r.terms[objectid.ID(id)]
+	if !ok {
+		return nil, db.ErrNotFound
+	}
+	return &term, nil
+}
+
+func (r *memoryTermRepository) Update(ctx context.Context, id string, updated *model.Term) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.terms[objectid.ID(id)]
+	if !ok {
+		return db.ErrNotFound
+	}
+	if existing.Version != updated.Version {
+		return db.ErrVersionConflict
+	}
+
+	updated.UpdatedAt = time.Now()
+	updated.Version++
+	existing.Title = updated.Title
+	existing.StartDate = updated.StartDate
+	existing.Color = updated.Color
+	existing.Audiences = updated.Audiences
+	existing.Periods = updated.Periods
+	existing.EndDate = updated.EndDate
+	existing.UpdatedAt = updated.UpdatedAt
+	existing.Version = updated.Version
+	r.terms[existing.ID] = existing
+	return nil
+}
+
+func (r *memoryTermRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.terms[objectid.ID(id)]; !ok {
+		return db.ErrNotFound
+	}
+	delete(r.terms, objectid.ID(id))
+	return nil
+}
+
+func (r *memoryTermRepository) GetAll(ctx context.Context) ([]*model.Term, error) {
+	return r.filter(func(*model.Term) bool { return true }, byCreatedAt), nil
+}
+
+func (r *memoryTermRepository) GetCurrentTerm(ctx context.Context) (*model.Term, error) {
+	now := time.Now()
+	return first(r.filter(func(t *model.Term) bool {
+		return !t.StartDate.After(now) && !t.EndDate.Before(now)
+	}, byCreatedAt)), nil
+}
+
+func (r *memoryTermRepository) GetCurrentTermByOrg(ctx context.Context, organizationID string) (*model.Term, error) {
+	now := time.Now()
+	return first(r.filter(func(t *model.Term) bool {
+		return t.OrganizationID == organizationID && !t.StartDate.After(now) && !t.EndDate.Before(now)
+	}, byCreatedAt)), nil
+}
+
+func (r *memoryTermRepository) GetAllByOrgID(ctx context.Context, orgID string) ([]*model.Term, error) {
+	return r.filter(func(t *model.Term) bool { return t.OrganizationID == orgID }, byStartDate), nil
+}
+
+func (r *memoryTermRepository) GetAllVisibleByOrgID(ctx context.Context, orgID string, channel string) ([]*model.Term, error) {
+	now := time.Now()
+	return r.filter(func(t *model.Term) bool { return t.OrganizationID == orgID && t.VisibleOn(channel, now) }, byCreatedAt), nil
+}
+
+func (r *memoryTermRepository) GetPreviousTerm(ctx context.Context, orgID string, termID string) (*model.Term, error) {
+	previous := r.previous(orgID, termID)
+	return first(previous), nil
+}
+
+func (r *memoryTermRepository) GetPreviousTerms(ctx context.Context, orgID string, termID string) ([]model.Term, error) {
+	previous := r.previous(orgID, termID)
+	if previous == nil {
+		return nil, nil
+	}
+
+	terms := make([]model.Term, 0, len(previous))
+	for _, t := range previous {
+		terms = append(terms, *t)
+	}
+	return terms, nil
+}
+
+// previous lists the org's terms starting before termID, newest first.
+func (r *memoryTermRepository) previous(orgID, termID string) []*model.Term {
+	r.mu.RLock()
+	current, ok := r.terms[objectid.ID(termID)]
+	r.mu.RUnlock()
+	if !ok || current.OrganizationID != orgID {
+		return nil
+	}
+
+	terms := r.filter(func(t *model.Term) bool {
+		return t.OrganizationID == orgID && t.StartDate.Before(current.StartDate)
+	}, byStartDate)
+	for i, j := 0, len(terms)-1; i < j; i, j = i+1, j-1 {
+		terms[i], terms[j] = terms[j], terms[i]
+	}
+	return terms
+}
+
+// filter returns copies of the matching terms sorted by less.
+func (r *memoryTermRepository) filter(match func(*model.Term) bool, less func(a, b *model.Term) bool) []*model.Term {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	terms := make([]*model.Term, 0)
+	for _, t := range r.terms {
+		t := t
+		if match(&t) {
+			terms = append(terms, &t)
+		}
+	}
+	sort.SliceStable(terms, func(i, j int) bool { return less(terms[i], terms[j]) })
+	return terms
+}
+
+func byStartDate(a, b *model.Term) bool { return a.StartDate.Before(b.StartDate) }
+
+func byCreatedAt(a, b *model.Term) bool { return a.CreatedAt.Before(b.CreatedAt) }
+
+func first(terms []*model.Term) *model.Term {
+	if len(terms) == 0 {
+		return nil
+	}
+	return terms[0]
+}
@@ -0,0 +1,340 @@
+// Package repositorytest holds the behaviour every TermRepository backend
+// must share. Backend tests call Run with a constructor for an empty store.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"term-service/internal/term/model"
+	"term-service/internal/term/repository"
+	"term-service/pkg/db"
+	"term-service/pkg/objectid"
+	"term-service/pkg/publish"
+	"testing"
+	"time"
+)
+
+// Run executes the conformance suite; newRepo must return an empty repository.
+func Run(t *testing.T, newRepo func(t *testing.T) repository.TermRepository) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo repository.TermRepository)
+	}{
+		{"CreateAndGetByID", testCreateAndGetByID},
+		{"GetByIDNotFound", testGetByIDNotFound},
+		{"Update", testUpdate},
+		{"Delete", testDelete},
+		{"GetAllByOrgID", testGetAllByOrgID},
+		{"PublishedFilters", testPublishedFilters},
+		{"ScheduledPublishing", testScheduledPublishing},
+		{"CurrentTerm", testCurrentTerm},
+		{"PreviousTerms", testPreviousTerms},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func create(t *testing.T, repo repository.TermRepository, term *model.Term) *model.Term {
+	t.Helper()
+	created, err := repo.Create(context.Background(), term)
+	if err != nil {
+		t.Fatalf("Create(%s): %v", term.Title, err)
+	}
+	return created
+}
+
+// on publishes to channels without a window.
+func on(channels ...string) publish.Audiences {
+	as := make(publish.Audiences, 0, len(channels))
+	for _, c := range channels {
+		as = append(as, publish.Audience{Channel: c})
+	}
+	return as
+}
+
+func ids(terms []*model.Term) []string {
+	out := make([]string, 0, len(terms))
+	for _, term := range terms {
+		out = append(out, term.ID.Hex())
+	}
+	return out
+}
+
+func sameSet(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	seen := make(map[string]int, len(got))
+	for _, id := range got {
+		seen[id]++
+	}
+	for _, id := range want {
+		if seen[id] == 0 {
+			return false
+		}
+		seen[id]--
+	}
+	return true
+}
+
+func testCreateAndGetByID(t *testing.T, repo repository.TermRepository) {
+	ctx := context.Background()
+	created := create(t, repo, &model.Term{
+		OrganizationID: "org-1",
+		Title:          "Spring",
+		Color:          "#00ff00",
+		Audiences:      on(publish.Mobile),
+		StartDate:      date(2025, 1, 6),
+		EndDate:        date(2025, 3, 28),
+		Periods:        model.Periods{{ID: "p1", Type: model.PeriodBreak, Title: "Tet", StartDate: date(2025, 1, 27), EndDate: date(2025, 2, 2)}},
+	})
+
+	if created.ID.IsZero() {
+		t.Fatal("Create did not assign an ID")
+	}
+	if created.CreatedAt.IsZero() || created.UpdatedAt.IsZero() {
+		t.Fatal("Create did not set timestamps")
+	}
+
+	got, err := repo.GetByID(ctx, created.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.ID != created.ID || got.Title != "Spring" || got.Color != "#00ff00" || got.OrganizationID != "org-1" {
+		t.Fatalf("GetByID returned %+v", got)
+	}
+	if len(got.Audiences) != 1 || got.Audiences[0].Channel != publish.Mobile {
+		t.Fatalf("audiences not round-tripped: %+v", got.Audiences)
+	}
+	if !got.StartDate.Equal(date(2025, 1, 6)) || !got.EndDate.Equal(date(2025, 3, 28)) {
+		t.Fatalf("dates not round-tripped: %v - %v", got.StartDate, got.EndDate)
+	}
+	if len(got.Periods) != 1 || got.Periods[0].Type != model.PeriodBreak || !got.Periods[0].StartDate.Equal(date(2025, 1, 27)) {
+		t.Fatalf("periods not round-tripped: %+v", got.Periods)
+	}
+}
+
+func testGetByIDNotFound(t *testing.T, repo repository.TermRepository) {
+	_, err := repo.GetByID(context.Background(), objectid.New().Hex())
+	if !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("GetByID(unknown) error = %v, want db.ErrNotFound", err)
+	}
+}
+
+func testUpdate(t *testing.T, repo repository.TermRepository) {
+	ctx := context.Background()
+	created := create(t, repo, &model.Term{OrganizationID: "org-1", Title: "Spring", StartDate: date(2025, 1, 6), EndDate: date(2025, 3, 28)})
+
+	created.Title = "Spring (revised)"
+	created.Audiences = on(publish.Teacher, publish.Kiosk)
+	created.EndDate = date(2025, 4, 4)
+	created.Periods = model.Periods{{ID: "p1", Type: model.PeriodExam, Title: "Finals", StartDate: date(2025, 3, 24), EndDate: date(2025, 4, 4)}}
+	if err := repo.Update(ctx, created.ID.Hex(), created); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, created.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Title != "Spring (revised)" || !got.VisibleOn(publish.Kiosk, time.Now()) || !got.EndDate.Equal(date(2025, 4, 4)) {
+		t.Fatalf("Update not persisted: %+v", got)
+	}
+	if len(got.Periods) != 1 || got.Periods[0].Type != model.PeriodExam {
+		t.Fatalf("periods not updated: %+v", got.Periods)
+	}
+	if got.Version != 2 || created.Version != 2 {
+		t.Fatalf("version after update = %d (stored) / %d (caller), want 2", got.Version, created.Version)
+	}
+
+	stale := *got
+	stale.Version = 1
+	stale.Title = "Lost update"
+	if err := repo.Update(ctx, created.ID.Hex(), &stale); !errors.Is(err, db.ErrVersionConflict) {
+		t.Fatalf("Update(stale version) error = %v, want db.ErrVersionConflict", err)
+	}
+	if got, _ := repo.GetByID(ctx, created.ID.Hex()); got.Title != "Spring (revised)" || got.Version != 2 {
+		t.Fatalf("stale update was applied: %+v", got)
+	}
+
+	if err := repo.Update(ctx, objectid.New().Hex(), created); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("Update(unknown) error = %v, want db.ErrNotFound", err)
+	}
+}
+
+func testDelete(t *testing.T, repo repository.TermRepository) {
+	ctx := context.Background()
+	created := create(t, repo, &model.Term{OrganizationID: "org-1", Title: "Spring", StartDate: date(2025, 1, 6), EndDate: date(2025, 3, 28)})
+
+	if err := repo.Delete(ctx, created.ID.Hex()); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, created.ID.Hex()); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("GetByID after Delete error = %v, want db.ErrNotFound", err)
+	}
+	if err := repo.Delete(ctx, created.ID.Hex()); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("second Delete error = %v, want db.ErrNotFound", err)
+	}
+}
+
+func testGetAllByOrgID(t *testing.T, repo repository.TermRepository) {
+	ctx := context.Background()
+	autumn := create(t, repo, &model.Term{OrganizationID: "org-1", Title: "Autumn", StartDate: date(2025, 9, 1), EndDate: date(2025, 12, 19)})
+	spring := create(t, repo, &model.Term{OrganizationID: "org-1", Title: "Spring", StartDate: date(2025, 1, 6), EndDate: date(2025, 3, 28)})
+	create(t, repo, &model.Term{OrganizationID: "org-2", Title: "Other", StartDate: date(2025, 1, 6), EndDate: date(2025, 3, 28)})
+
+	terms, err := repo.GetAllByOrgID(ctx, "org-1")
+	if err != nil {
+		t.Fatalf("GetAllByOrgID: %v", err)
+	}
+	got := ids(terms)
+	want := []string{spring.ID.Hex(), autumn.ID.Hex()}
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("GetAllByOrgID = %v, want %v ordered by start_date", got, want)
+	}
+
+	all, err := repo.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("GetAll returned %d terms, want 3", len(all))
+	}
+}
+
+func testPublishedFilters(t *testing.T, repo repository.TermRepository) {
+	ctx := context.Background()
+	mobile := create(t, repo, &model.Term{OrganizationID: "org-1", Title: "Mobile", Audiences: on(publish.Mobile), StartDate: date(2025, 1, 6), EndDate: date(2025, 3, 28)})
+	desktop := create(t, repo, &model.Term{OrganizationID: "org-1", Title: "Desktop", Audiences: on(publish.Desktop, publish.Kiosk), StartDate: date(2025, 4, 7), EndDate: date(2025, 6, 27)})
+	teacher := create(t, repo, &model.Term{OrganizationID: "org-1", Title: "Teacher", Audiences: on(publish.Teacher, publish.Parent), StartDate: date(2025, 9, 1), EndDate: date(2025, 12, 19)})
+	create(t, repo, &model.Term{OrganizationID: "org-1", Title: "Hidden", StartDate: date(2026, 1, 5), EndDate: date(2026, 3, 27)})
+	create(t, repo, &model.Term{OrganizationID: "org-2", Title: "Elsewhere", Audiences: on(publish.Channels...), StartDate: date(2025, 1, 6), EndDate: date(2025, 3, 28)})
+
+	want := map[string][]string{
+		publish.Mobile:  {mobile.ID.Hex()},
+		publish.Desktop: {desktop.ID.Hex()},
+		publish.Teacher: {teacher.ID.Hex()},
+		publish.Parent:  {teacher.ID.Hex()},
+		publish.Kiosk:   {desktop.ID.Hex()},
+	}
+	for channel, ws := range want {
+		terms, err := repo.GetAllVisibleByOrgID(ctx, "org-1", channel)
+		if err != nil {
+			t.Fatalf("GetAllVisibleByOrgID(%s): %v", channel, err)
+		}
+		if got := ids(terms); !sameSet(got, ws) {
+			t.Errorf("GetAllVisibleByOrgID(%s) = %v, want %v", channel, got, ws)
+		}
+	}
+}
+
+func testScheduledPublishing(t *testing.T, repo repository.TermRepository) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+	window := func(channel string, publishAt, unpublishAt *time.Time) publish.Audiences {
+		return publish.Audiences{{Channel: channel, PublishAt: publishAt, UnpublishAt: unpublishAt}}
+	}
+
+	open := create(t, repo, &model.Term{OrganizationID: "org-1", Title: "Open", Audiences: window(publish.Mobile, &past, &future), StartDate: date(2025, 1, 6), EndDate: date(2025, 3, 28)})
+	create(t, repo, &model.Term{OrganizationID: "org-1", Title: "Not yet", Audiences: window(publish.Mobile, &future, nil), StartDate: date(2026, 1, 5), EndDate: date(2026, 3, 27)})
+	create(t, repo, &model.Term{OrganizationID: "org-1", Title: "Withdrawn", Audiences: window(publish.Mobile, nil, &past), StartDate: date(2024, 1, 8), EndDate: date(2024, 3, 29)})
+	teacher := create(t, repo, &model.Term{OrganizationID: "org-1", Title: "Teacher later", Audiences: window(publish.Teacher, &future, nil), StartDate: date(2025, 9, 1), EndDate: date(2025, 12, 19)})
+
+	terms, err := repo.GetAllVisibleByOrgID(ctx, "org-1", publish.Mobile)
+	if err != nil {
+		t.Fatalf("GetAllVisibleByOrgID(mobile): %v", err)
+	}
+	if got := ids(terms); !sameSet(got, []string{open.ID.Hex()}) {
+		t.Errorf("GetAllVisibleByOrgID(mobile) = %v, want only %s", got, open.ID.Hex())
+	}
+	if terms, err := repo.GetAllVisibleByOrgID(ctx, "org-1", publish.Teacher); err != nil || len(terms) != 0 {
+		t.Errorf("GetAllVisibleByOrgID(teacher) = %v, %v; want nothing before publish_at", ids(terms), err)
+	}
+
+	// Reveal the teacher term now and check the window round-trips.
+	teacher.Audiences = window(publish.Teacher, &past, nil)
+	if err := repo.Update(ctx, teacher.ID.Hex(), teacher); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	got, err := repo.GetByID(ctx, teacher.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if a, ok := got.Audiences.Find(publish.Teacher); !ok || a.PublishAt == nil || !a.PublishAt.Equal(past) || a.UnpublishAt != nil {
+		t.Errorf("teacher audience = %+v, want %v..open", got.Audiences, past)
+	}
+	if terms, err := repo.GetAllVisibleByOrgID(ctx, "org-1", publish.Teacher); err != nil || !sameSet(ids(terms), []string{teacher.ID.Hex()}) {
+		t.Errorf("GetAllVisibleByOrgID(teacher) = %v, %v; want %s", ids(terms), err, teacher.ID.Hex())
+	}
+}
+
+func testCurrentTerm(t *testing.T, repo repository.TermRepository) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	current := create(t, repo, &model.Term{OrganizationID: "org-1", Title: "Now", StartDate: now.AddDate(0, 0, -7), EndDate: now.AddDate(0, 0, 7)})
+	create(t, repo, &model.Term{OrganizationID: "org-1", Title: "Past", StartDate: now.AddDate(0, -6, 0), EndDate: now.AddDate(0, -3, 0)})
+	other := create(t, repo, &model.Term{OrganizationID: "org-2", Title: "Other now", StartDate: now.AddDate(0, 0, -1), EndDate: now.AddDate(0, 0, 1)})
+
+	got, err := repo.GetCurrentTermByOrg(ctx, "org-1")
+	if err != nil {
+		t.Fatalf("GetCurrentTermByOrg: %v", err)
+	}
+	if got == nil || got.ID != current.ID {
+		t.Fatalf("GetCurrentTermByOrg(org-1) = %+v, want %s", got, current.ID)
+	}
+
+	got, err = repo.GetCurrentTermByOrg(ctx, "org-2")
+	if err != nil || got == nil || got.ID != other.ID {
+		t.Fatalf("GetCurrentTermByOrg(org-2) = %+v, %v", got, err)
+	}
+
+	got, err = repo.GetCurrentTermByOrg(ctx, "org-without-terms")
+	if err != nil || got != nil {
+		t.Fatalf("GetCurrentTermByOrg(empty org) = %+v, %v; want nil, nil", got, err)
+	}
+
+	any, err := repo.GetCurrentTerm(ctx)
+	if err != nil || any == nil {
+		t.Fatalf("GetCurrentTerm = %+v, %v", any, err)
+	}
+}
+
+func testPreviousTerms(t *testing.T, repo repository.TermRepository) {
+	ctx := context.Background()
+	first := create(t, repo, &model.Term{OrganizationID: "org-1", Title: "First", StartDate: date(2025, 1, 6), EndDate: date(2025, 3, 28)})
+	second := create(t, repo, &model.Term{OrganizationID: "org-1", Title: "Second", StartDate: date(2025, 4, 7), EndDate: date(2025, 6, 27)})
+	third := create(t, repo, &model.Term{OrganizationID: "org-1", Title: "Third", StartDate: date(2025, 9, 1), EndDate: date(2025, 12, 19)})
+	create(t, repo, &model.Term{OrganizationID: "org-2", Title: "Other", StartDate: date(2025, 5, 1), EndDate: date(2025, 6, 1)})
+
+	prev, err := repo.GetPreviousTerm(ctx, "org-1", third.ID.Hex())
+	if err != nil || prev == nil || prev.ID != second.ID {
+		t.Fatalf("GetPreviousTerm(third) = %+v, %v; want %s", prev, err, second.ID)
+	}
+
+	prev, err = repo.GetPreviousTerm(ctx, "org-1", first.ID.Hex())
+	if err != nil || prev != nil {
+		t.Fatalf("GetPreviousTerm(first) = %+v, %v; want nil, nil", prev, err)
+	}
+
+	prev, err = repo.GetPreviousTerm(ctx, "org-2", third.ID.Hex())
+	if err != nil || prev != nil {
+		t.Fatalf("GetPreviousTerm(other org) = %+v, %v; want nil, nil", prev, err)
+	}
+
+	list, err := repo.GetPreviousTerms(ctx, "org-1", third.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetPreviousTerms: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != second.ID || list[1].ID != first.ID {
+		t.Fatalf("GetPreviousTerms(third) = %+v, want [second first]", list)
+	}
+}
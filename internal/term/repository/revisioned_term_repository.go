@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"term-service/internal/term/model"
+	"term-service/pkg/revision"
+)
+
+// revisionedTermRepository appends a revision for every write, through the
+// same context so it joins the write's transaction. Reads pass through.
+type revisionedTermRepository struct {
+	TermRepository
+	revisions revision.Store
+}
+
+func NewRevisionedTermRepository(next TermRepository, revisions revision.Store) TermRepository {
+	return &revisionedTermRepository{TermRepository: next, revisions: revisions}
+}
+
+func (r *revisionedTermRepository) Create(ctx context.Context, term *model.Term) (*model.Term, error) {
+	created, err := r.TermRepository.Create(ctx, term)
+	if err != nil {
+		return nil, err
+	}
+	return created, r.record(ctx, created, false)
+}
+
+func (r *revisionedTermRepository) Update(ctx context.Context, id string, term *model.Term) error {
+	if err := r.TermRepository.Update(ctx, id, term); err != nil {
+		return err
+	}
+	// Reload: callers may pass only the fields they change.
+	stored, err := r.TermRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return r.record(ctx, stored, false)
+}
+
+func (r *revisionedTermRepository) Delete(ctx context.Context, id string) error {
+	existing, err := r.TermRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.TermRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	existing.Version++
+	return r.record(ctx, existing, true)
+}
+
+func (r *revisionedTermRepository) record(ctx context.Context, term *model.Term, deleted bool) error {
+	rev, err := revision.New(revision.Term, term.ID.Hex(), term.OrganizationID, term.Version, deleted, term)
+	if err != nil {
+		return err
+	}
+	return r.revisions.Append(ctx, rev)
+}
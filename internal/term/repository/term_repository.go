@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"term-service/internal/term/model"
+	"term-service/pkg/db"
+	"term-service/pkg/publish"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -21,12 +23,11 @@ type TermRepository interface {
 	GetCurrentTerm(ctx context.Context) (*model.Term, error)
 	GetAllByOrgID(ctx context.Context, orgID string) ([]*model.Term, error)
 	GetCurrentTermByOrg(ctx context.Context, organizationID string) (*model.Term, error)
-	GetAllByOrgID4App(ctx context.Context, orgID string) ([]*model.Term, error)
-	GetAllByOrgID4Web(ctx context.Context, orgID string) ([]*model.Term, error)
-	GetAllByOrgIDIsPublishedTeacher(ctx context.Context, orgID string) ([]*model.Term, error)
+	// GetAllVisibleByOrgID lists the organization's terms visible on channel
+	// now, oldest first.
+	GetAllVisibleByOrgID(ctx context.Context, orgID string, channel string) ([]*model.Term, error)
 	GetPreviousTerm(ctx context.Context, orgID string, termID string) (*model.Term, error)
 	GetPreviousTerms(ctx context.Context, orgID string, termID string) ([]model.Term, error)
-	GetAllByOrgIDIsPublishedDesktop(ctx context.Context, orgID string) ([]*model.Term, error)
 }
 
 type termRepository struct {
@@ -42,6 +43,7 @@ func (r *termRepository) Create(ctx context.Context, term *model.Term) (*model.T
 	now := time.Now()
 	term.CreatedAt = now
 	term.UpdatedAt = now
+	term.Version = 1
 
 	_, err := r.collection.InsertOne(ctx, term)
 	if err != nil {
@@ -60,12 +62,16 @@ func (r *termRepository) GetByID(ctx context.Context, id string) (*model.Term, e
 	var term model.Term
 	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&term)
 	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, db.ErrNotFound
+		}
 		return nil, err
 	}
 	return &term, nil
 }
 
-// Update modifies an existing term
+// Update modifies an existing term if it is still at updated.Version and
+// bumps the version.
 func (r *termRepository) Update(ctx context.Context, id string, updated *model.Term) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -76,25 +82,32 @@ func (r *termRepository) Update(ctx context.Context, id string, updated *model.T
 
 	update := bson.M{
 		"$set": bson.M{
-			"title":             updated.Title,
-			"start_date":        updated.StartDate,
-			"color":             updated.Color,
-			"published_mobile":  updated.PublishedMobile,
-			"published_desktop": updated.PublishedDesktop,
-			"published_teacher": updated.PublishedTeacher,
-			"published_parent":  updated.PublishedParent,
-			"end_date":          updated.EndDate,
-			"updated_at":        updated.UpdatedAt,
+			"title":      updated.Title,
+			"start_date": updated.StartDate,
+			"color":      updated.Color,
+			"audiences":  updated.Audiences,
+			"periods":    updated.Periods,
+			"end_date":   updated.EndDate,
+			"updated_at": updated.UpdatedAt,
 		},
+		"$inc": bson.M{"version": 1},
 	}
 
-	result, err := r.collection.UpdateByID(ctx, objectID, update)
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID, "version": updated.Version}, update)
 	if err != nil {
 		return err
 	}
 	if result.MatchedCount == 0 {
-		return mongo.ErrNoDocuments
+		exists, err := r.collection.CountDocuments(ctx, bson.M{"_id": objectID})
+		if err != nil {
+			return err
+		}
+		if exists == 0 {
+			return db.ErrNotFound
+		}
+		return db.ErrVersionConflict
 	}
+	updated.Version++
 	return nil
 }
 
@@ -110,7 +123,7 @@ func (r *termRepository) Delete(ctx context.Context, id string) error {
 		return err
 	}
 	if result.DeletedCount == 0 {
-		return mongo.ErrNoDocuments
+		return db.ErrNotFound
 	}
 	return nil
 }
@@ -197,34 +210,8 @@ func (r *termRepository) GetAllByOrgID(ctx context.Context, orgID string) ([]*mo
 	return terms, nil
 }
 
-func (r *termRepository) GetAllByOrgID4App(ctx context.Context, orgID string) ([]*model.Term, error) {
-	filter := bson.M{
-		"organization_id":  orgID,
-		"published_mobile": true,
-	}
-
-	// sort theo created_at ASC
-	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
-
-	cur, err := r.collection.Find(ctx, filter, findOptions)
-	if err != nil {
-		return nil, err
-	}
-	defer cur.Close(ctx)
-
-	var terms []*model.Term
-	if err := cur.All(ctx, &terms); err != nil {
-		return nil, err
-	}
-
-	return terms, nil
-}
-
-func (r *termRepository) GetAllByOrgID4Web(ctx context.Context, orgID string) ([]*model.Term, error) {
-	filter := bson.M{
-		"organization_id":   orgID,
-		"published_desktop": true,
-	}
+func (r *termRepository) GetAllVisibleByOrgID(ctx context.Context, orgID string, channel string) ([]*model.Term, error) {
+	filter := publish.MongoFilter(bson.M{"organization_id": orgID}, channel, time.Now())
 
 	// sort theo created_at ASC
 	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
@@ -319,49 +306,3 @@ func (r *termRepository) GetPreviousTerms(ctx context.Context, orgID string, ter
 
 	return previousTerms, nil
 }
-
-func (r *termRepository) GetAllByOrgIDIsPublishedTeacher(ctx context.Context, orgID string) ([]*model.Term, error) {
-	filter := bson.M{
-		"organization_id":   orgID,
-		"published_teacher": true,
-	}
-
-	// sort theo created_at ASC
-	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
-
-	cur, err := r.collection.Find(ctx, filter, findOptions)
-	if err != nil {
-		return nil, err
-	}
-	defer cur.Close(ctx)
-
-	var terms []*model.Term
-	if err := cur.All(ctx, &terms); err != nil {
-		return nil, err
-	}
-
-	return terms, nil
-}
-
-func (r *termRepository) GetAllByOrgIDIsPublishedDesktop(ctx context.Context, orgID string) ([]*model.Term, error) {
-	filter := bson.M{
-		"organization_id":   orgID,
-		"published_desktop": true,
-	}
-
-	// sort theo created_at ASC
-	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
-
-	cur, err := r.collection.Find(ctx, filter, findOptions)
-	if err != nil {
-		return nil, err
-	}
-	defer cur.Close(ctx)
-
-	var terms []*model.Term
-	if err := cur.All(ctx, &terms); err != nil {
-		return nil, err
-	}
-
-	return terms, nil
-}
@@ -0,0 +1,32 @@
+package repository_test
+
+import (
+	"term-service/internal/term/repository"
+	"term-service/internal/term/repository/repositorytest"
+	"term-service/pkg/db/dbtest"
+	"testing"
+)
+
+func TestMongoTermRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.TermRepository {
+		return repository.NewTermRepository(dbtest.NewMongo(t).Collection("terms"))
+	})
+}
+
+func TestGormSQLiteTermRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.TermRepository {
+		return repository.NewGormTermRepository(dbtest.NewSQLite(t))
+	})
+}
+
+func TestGormMySQLTermRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.TermRepository {
+		return repository.NewGormTermRepository(dbtest.NewMySQL(t))
+	})
+}
+
+func TestMemoryTermRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.TermRepository {
+		return repository.NewMemoryTermRepository()
+	})
+}
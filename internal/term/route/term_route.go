@@ -1,23 +1,28 @@
 package route
 
 import (
+	"term-service/internal/gateway"
+	"term-service/internal/policy"
 	"term-service/internal/term/handler"
 	"term-service/internal/term/middleware"
+	"term-service/pkg/servicetoken"
 
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterTermRoutes(r *gin.Engine, h *handler.TermHandler) {
+// authLimit throttles per user and organization and runs after authorization
+// has resolved them; idempotent guards uploads against retried requests.
+func RegisterTermRoutes(r *gin.Engine, h *handler.TermHandler, userGW gateway.UserGateway, serviceAuth servicetoken.Verifier, authLimit, idempotent gin.HandlerFunc) {
 	// Admin routes
 	adminGroup := r.Group("/api/v1/admin")
 	adminGroup.Use(middleware.Secured())
 	{
 		termsAdmin := adminGroup.Group("/terms")
 		{
-			termsAdmin.POST("", h.UploadTerm)
-			termsAdmin.GET("", h.GetTerms4Web)
+			termsAdmin.POST("", middleware.Authorize(userGW, policy.TermWrite, middleware.AdminTarget), authLimit, idempotent, h.UploadTerm)
+			termsAdmin.GET("", middleware.Authorize(userGW, policy.TermList, middleware.AdminScope), authLimit, h.GetTerms4Web)
 			termsAdmin.GET("/student/:student_id", h.GetTermsByStudent4Web)
-			termsAdmin.GET("/assign", h.GetTerms2Assign4Web)
+			termsAdmin.GET("/assign", middleware.Authorize(userGW, policy.TermAssign, middleware.AdminTarget), authLimit, h.GetTerms2Assign4Web)
 		}
 	}
 
@@ -25,7 +30,7 @@ func RegisterTermRoutes(r *gin.Engine, h *handler.TermHandler) {
 	orgGroup := r.Group("/api/v1/organization")
 	orgGroup.Use(middleware.Secured())
 	{
-		orgGroup.GET("/:organization_id/terms", h.GetTermsByOrgID)
+		orgGroup.GET("/:organization_id/terms", middleware.Authorize(userGW, policy.TermRead, middleware.OrganizationParam("organization_id")), authLimit, h.GetTermsByOrgID)
 	}
 
 	// User routes
@@ -34,16 +39,16 @@ func RegisterTermRoutes(r *gin.Engine, h *handler.TermHandler) {
 	{
 		termsUser := userGroup.Group("/terms")
 		{
-			termsUser.GET("", h.GetTerms4App)
+			termsUser.GET("", middleware.Authorize(userGW, policy.TermRead, middleware.OrganizationQuery("organization_id")), authLimit, h.GetTerms4App)
 			termsUser.GET("/current", h.GetCurrentTerm)
 			termsUser.GET("/student/:student_id", h.GetTermsByStudent4App)
-			termsUser.GET("/organization/:organization_id", h.GetTermsByOrg4App)
+			termsUser.GET("/organization/:organization_id", middleware.Authorize(userGW, policy.TermRead, middleware.OrganizationParam("organization_id")), authLimit, h.GetTermsByOrg4App)
 		}
 	}
 
-	// gw routes
+	// gw routes: internal services only, authenticated by service token
 	gatewayGroup := r.Group("/api/v1/gateway")
-	gatewayGroup.Use(middleware.Secured())
+	gatewayGroup.Use(middleware.ServiceAuth(serviceAuth))
 	{
 		termsGateway := gatewayGroup.Group("/terms")
 		{
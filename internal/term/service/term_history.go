@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"term-service/internal/term/model"
+	"term-service/pkg/db"
+	"term-service/pkg/revision"
+	"time"
+)
+
+// termsAsOf rebuilds the organization's terms as they were at asOf from
+// their revisions, oldest first like the live listing.
+func (s *termService) termsAsOf(ctx context.Context, orgID string, asOf time.Time) ([]*model.Term, error) {
+	revs, err := s.revisions.AsOf(ctx, revision.Term, orgID, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("get term revisions as of %s: %w", asOf.Format(time.RFC3339), err)
+	}
+
+	terms := make([]*model.Term, 0, len(revs))
+	for i := range revs {
+		var term model.Term
+		if err := revs[i].Decode(&term); err != nil {
+			return nil, err
+		}
+		terms = append(terms, &term)
+	}
+	sort.SliceStable(terms, func(i, j int) bool {
+		return terms[i].CreatedAt.Before(terms[j].CreatedAt)
+	})
+	return terms, nil
+}
+
+// termAsOf returns a term as it was at asOf, or db.ErrNotFound when it did
+// not exist then.
+func (s *termService) termAsOf(ctx context.Context, id string, asOf time.Time) (*model.Term, error) {
+	rev, err := s.revisions.Get(ctx, revision.Term, id, asOf)
+	if err != nil {
+		return nil, err
+	}
+	if rev == nil {
+		return nil, fmt.Errorf("term %s as of %s: %w", id, asOf.Format(time.RFC3339), db.ErrNotFound)
+	}
+
+	var term model.Term
+	if err := rev.Decode(&term); err != nil {
+		return nil, err
+	}
+	return &term, nil
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"fmt"
+	"term-service/internal/term/dto/request"
+	"term-service/internal/term/model"
+	"term-service/pkg/objectid"
+	"time"
+)
+
+// uploadPeriods turns the periods of an uploaded term into the term's new
+// set. Items naming an ID must refer to one of existing; periods left out
+// are removed. A nil items keeps existing unchanged.
+func uploadPeriods(items []request.UploadPeriodItem, existing model.Periods, start, end time.Time) (model.Periods, error) {
+	if items == nil {
+		return existing, existing.Validate(start, end)
+	}
+
+	periods := make(model.Periods, 0, len(items))
+	for _, item := range items {
+		p := model.Period{ID: item.ID, Type: item.Type, Title: item.Title}
+		if p.ID == "" {
+			p.ID = objectid.New().Hex()
+		} else if _, ok := existing.Find(p.ID); !ok {
+			return nil, fmt.Errorf("period not found: %s", p.ID)
+		}
+
+		var err error
+		if p.StartDate, err = time.Parse("2006-01-02", item.StartDate); err != nil {
+			return nil, fmt.Errorf("invalid start_date for %s period %s: %w", p.Type, p.Title, err)
+		}
+		if p.EndDate, err = time.Parse("2006-01-02", item.EndDate); err != nil {
+			return nil, fmt.Errorf("invalid end_date for %s period %s: %w", p.Type, p.Title, err)
+		}
+		periods = append(periods, p)
+	}
+	return periods, periods.Validate(start, end)
+}
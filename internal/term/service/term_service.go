@@ -4,18 +4,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"term-service/internal/gateway"
 	"term-service/internal/gateway/dto"
+	"term-service/internal/policy"
 	"term-service/internal/term/dto/request"
 	"term-service/internal/term/dto/response"
 	"term-service/internal/term/mappers"
 	"term-service/internal/term/model"
 	"term-service/internal/term/repository"
+	"term-service/pkg/db"
+	"term-service/pkg/db/txn"
 	pkg_helpder "term-service/pkg/helper"
+	"term-service/pkg/objectid"
+	"term-service/pkg/outbox"
+	"term-service/pkg/publish"
+	"term-service/pkg/revision"
+	"term-service/pkg/zap"
 	"time"
-
-	"go.mongodb.org/mongo-driver/bson/primitive"
-	"gorm.io/gorm"
 )
 
 type TermService interface {
@@ -26,28 +32,37 @@ type TermService interface {
 	GetTerms4Web(ctx context.Context) (*response.GetTerms4WebResDTO, error)
 	GetCurrentTerm(ctx context.Context) (response.CurrentTermResDTO, error)
 	UploadTerms(ctx context.Context, req request.UploadTermRequest) error
-	GetTermsByOrgID(ctx context.Context, orgID string) (*response.ListTermsResDTO, error)
+	// GetTermsByOrgID lists the organization's terms, as they were at asOf
+	// unless it is zero.
+	GetTermsByOrgID(ctx context.Context, orgID string, asOf time.Time) (*response.ListTermsResDTO, error)
 	GetTermsByStudent4App(ctx context.Context, studentID string) ([]response.TermsByStudentResDTO, error)
 	GetTermsByStudent4Web(ctx context.Context, studentID string) ([]response.TermsByStudentResDTO, error)
 	GetCurrentTermByOrg(ctx context.Context, organizationID string) (response.CurrentTermResDTO, error)
 	GetTerms4App(ctx context.Context, organizationID string) (*response.GetTerms4AppResDTO, error)
-	GetTerm4Gw(ctx context.Context, termId string) (*response.Term4GwResponse, error)
+	// GetTerm4Gw returns a term, as it was at asOf unless it is zero.
+	GetTerm4Gw(ctx context.Context, organizationID string, termId string, asOf time.Time) (*response.Term4GwResponse, error)
 	GetTermsByOrg4App(ctx context.Context, organizationID string) ([]response.TermResponse4App, error)
 	GetPreviousTerm4GW(ctx context.Context, organizationID string, termID string) (*response.Term4GwResponse, error)
 	GetPreviousTerms4GW(ctx context.Context, organizationID string, termID string) ([]*response.Term4GwResponse, error)
-	GetTerms2Assign4Web(ctx context.Context) ([]*response.TermResponse4Web, error)
+	GetTerms2Assign4Web(ctx context.Context, organizationID string) ([]*response.TermResponse4Web, error)
 }
 
 type termService struct {
 	repo                   repository.TermRepository
+	revisions              revision.Store
+	tx                     txn.Transactor
+	events                 outbox.Recorder
 	userGateway            gateway.UserGateway
 	orgGateway             gateway.OrganizationGateway
 	messageLanguageGateway gateway.MessageLanguageGateway
 }
 
-func NewTermService(repo repository.TermRepository, userGateway gateway.UserGateway, orgGateway gateway.OrganizationGateway, messageLanguageGateway gateway.MessageLanguageGateway) TermService {
+func NewTermService(repo repository.TermRepository, revisions revision.Store, tx txn.Transactor, events outbox.Recorder, userGateway gateway.UserGateway, orgGateway gateway.OrganizationGateway, messageLanguageGateway gateway.MessageLanguageGateway) TermService {
 	return &termService{
 		repo:                   repo,
+		revisions:              revisions,
+		tx:                     tx,
+		events:                 events,
 		userGateway:            userGateway,
 		orgGateway:             orgGateway,
 		messageLanguageGateway: messageLanguageGateway,
@@ -55,7 +70,18 @@ func NewTermService(repo repository.TermRepository, userGateway gateway.UserGate
 }
 
 func (s *termService) CreateTerm(ctx context.Context, term *model.Term) (*model.Term, error) {
-	return s.repo.Create(ctx, term)
+	var created *model.Term
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = s.repo.Create(ctx, term); err != nil {
+			return err
+		}
+		return s.recordTerm(ctx, outbox.TermCreated, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
 }
 
 func (s *termService) GetTermByID(ctx context.Context, id string) (*model.Term, error) {
@@ -63,11 +89,38 @@ func (s *termService) GetTermByID(ctx context.Context, id string) (*model.Term,
 }
 
 func (s *termService) UpdateTerm(ctx context.Context, id string, term *model.Term) error {
-	return s.repo.Update(ctx, id, term)
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, id, term); err != nil {
+			return err
+		}
+		return s.recordTerm(ctx, outbox.TermUpdated, term)
+	})
 }
 
 func (s *termService) DeleteTerm(ctx context.Context, id string) error {
-	return s.repo.Delete(ctx, id)
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.recordTerm(ctx, outbox.TermDeleted, existing)
+	})
+}
+
+// recordTerm adds a term event to the outbox; call it inside the
+// transaction that changed the term.
+func (s *termService) recordTerm(ctx context.Context, eventType string, term *model.Term) error {
+	e, err := outbox.NewEvent(eventType, "term", term.ID.Hex(), term.OrganizationID, mappers.MapTermToEventData(term))
+	if err != nil {
+		return err
+	}
+	if err := s.events.Record(ctx, e); err != nil {
+		return fmt.Errorf("record %s event: %w", eventType, err)
+	}
+	return nil
 }
 
 func (s *termService) GetTerms4Web(ctx context.Context) (*response.GetTerms4WebResDTO, error) {
@@ -76,9 +129,14 @@ func (s *termService) GetTerms4Web(ctx context.Context) (*response.GetTerms4WebR
 		return nil, fmt.Errorf("get current user info failed: %w", err)
 	}
 
+	scope := policy.AdminScope(currentUser)
+	if err := policy.Authorize(currentUser, policy.TermList, scope); err != nil {
+		return nil, err
+	}
+
 	var result []response.TermsByOrgRes
 
-	if currentUser.IsSuperAdmin {
+	if scope == policy.AllOrganizations {
 		// Lấy toàn bộ org từ Gateway
 		orgs, err := s.orgGateway.GetAllOrg(ctx)
 		if err != nil {
@@ -104,9 +162,9 @@ func (s *termService) GetTerms4Web(ctx context.Context) (*response.GetTerms4WebR
 			})
 		}
 
-	} else if currentUser.OrganizationAdmin.ID != "" {
+	} else {
 		// User là org admin → chỉ lấy org của mình
-		orgID := currentUser.OrganizationAdmin.ID
+		orgID := scope
 		terms, err := s.repo.GetAllByOrgID(ctx, orgID)
 		if err != nil {
 			return nil, fmt.Errorf("get terms by orgID %s failed: %w", orgID, err)
@@ -128,9 +186,6 @@ func (s *termService) GetTerms4Web(ctx context.Context) (*response.GetTerms4WebR
 			OrganizationName: orgInfo.OrganizationName,
 			Terms:            mappers.MapTermListToResDTO(terms),
 		})
-
-	} else {
-		return nil, fmt.Errorf("access denied: user is not an organization admin")
 	}
 
 	return &response.GetTerms4WebResDTO{
@@ -179,13 +234,25 @@ func (s *termService) UploadTerms(ctx context.Context, req request.UploadTermReq
 		return fmt.Errorf("get current user info failed")
 	}
 
-	// check is super admin & check org admin
-	if currentUser.IsSuperAdmin || currentUser.OrganizationAdmin.ID == "" {
-		return fmt.Errorf("access denied: super admin cannot perform this action")
+	organizationAdminID := policy.TargetOrganization(currentUser, req.OrganizationID)
+	if err := policy.Authorize(currentUser, policy.TermWrite, organizationAdminID); err != nil {
+		return err
 	}
-	organizationAdminID := currentUser.OrganizationAdmin.ID
 
-	// Upsert terms
+	updates := 0
+	for _, t := range req.Terms {
+		if t.ID != "" {
+			updates++
+		}
+	}
+
+	// Validate every term and check every version before the first write,
+	// so a bad or stale item leaves the whole upload unapplied.
+	type termWrite struct {
+		term    *model.Term
+		created bool
+	}
+	writes := make([]termWrite, 0, len(req.Terms))
 	for _, t := range req.Terms {
 		startDate, err := time.Parse("2006-01-02", t.StartDate)
 		if err != nil {
@@ -201,68 +268,135 @@ func (s *termService) UploadTerms(ctx context.Context, req request.UploadTermReq
 			return fmt.Errorf("start_date must be before or equal to end_date for term %s", t.Title)
 		}
 
+		audiences := mappers.MapUploadTermItemToAudiences(t)
+		if err := audiences.Validate(); err != nil {
+			return fmt.Errorf("invalid audiences for term %s: %w", t.Title, err)
+		}
+
 		if t.ID != "" {
 			// Update existing term
 			existing, err := s.repo.GetByID(ctx, t.ID)
 			if err != nil {
-				if errors.Is(err, gorm.ErrRecordNotFound) {
+				if errors.Is(err, db.ErrNotFound) {
 					return fmt.Errorf("term not found: %s", t.ID)
 				}
 				return fmt.Errorf("failed to get term: %w", err)
 			}
+			if err := policy.Authorize(currentUser, policy.TermWrite, existing.OrganizationID); err != nil {
+				return err
+			}
+
+			version, err := pkg_helpder.ExpectedVersion(t.Version, req.IfMatchVersion, updates)
+			if err != nil {
+				return fmt.Errorf("term %s: %w", t.ID, err)
+			}
+			if existing.Version != version {
+				return termConflict(existing)
+			}
+
+			periods, err := uploadPeriods(t.Periods, existing.Periods, startDate, endDate)
+			if err != nil {
+				return fmt.Errorf("invalid periods for term %s: %w", t.Title, err)
+			}
 
 			existing.Title = t.Title
 			existing.Color = t.Color
-			existing.PublishedMobile = t.PublishedMobile
-			existing.PublishedDesktop = t.PublishedDesktop
-			existing.PublishedTeacher = t.PublishedTeacher
-			existing.PublishedParent = t.PublishedParent
+			existing.Audiences = audiences
 			existing.StartDate = startDate
 			existing.EndDate = endDate
+			existing.Periods = periods
 			existing.UpdatedAt = time.Now()
+			writes = append(writes, termWrite{term: existing})
 
-			if err := s.repo.Update(ctx, t.ID, existing); err != nil {
-				return fmt.Errorf("failed to update term %s: %w", t.ID, err)
-			}
-
-			// goi messs lang gw upload message
-			err = s.uploadMessages(ctx, pkg_helpder.BuildTermMessagesUpload(organizationAdminID, req, req.LanguageID))
+		} else {
+			periods, err := uploadPeriods(t.Periods, nil, startDate, endDate)
 			if err != nil {
-				return fmt.Errorf("upload department messages failed")
+				return fmt.Errorf("invalid periods for term %s: %w", t.Title, err)
 			}
 
-		} else {
 			// Create new term
-			newTerm := &model.Term{
-				ID:               primitive.NewObjectID(),
-				OrganizationID:   organizationAdminID,
-				Title:            t.Title,
-				Color:            t.Color,
-				PublishedMobile:  t.PublishedMobile,
-				PublishedDesktop: t.PublishedDesktop,
-				PublishedTeacher: t.PublishedTeacher,
-				PublishedParent:  t.PublishedParent,
-				StartDate:        startDate,
-				EndDate:          endDate,
-				CreatedAt:        time.Now(),
-				UpdatedAt:        time.Now(),
+			writes = append(writes, termWrite{created: true, term: &model.Term{
+				ID:             objectid.New(),
+				OrganizationID: organizationAdminID,
+				Title:          t.Title,
+				Color:          t.Color,
+				Audiences:      audiences,
+				StartDate:      startDate,
+				EndDate:        endDate,
+				Periods:        periods,
+				CreatedAt:      time.Now(),
+				UpdatedAt:      time.Now(),
+			}})
+		}
+	}
+
+	// Apply the upload as a whole.
+	var conflicted string
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, w := range writes {
+			if w.created {
+				if _, err := s.repo.Create(ctx, w.term); err != nil {
+					return fmt.Errorf("failed to create term %s: %w", w.term.Title, err)
+				}
+				if err := s.recordTerm(ctx, outbox.TermCreated, w.term); err != nil {
+					return err
+				}
+				continue
 			}
 
-			if _, err := s.repo.Create(ctx, newTerm); err != nil {
-				return fmt.Errorf("failed to create term %s: %w", t.Title, err)
+			id := w.term.ID.Hex()
+			if err := s.repo.Update(ctx, id, w.term); err != nil {
+				if errors.Is(err, db.ErrVersionConflict) {
+					conflicted = id
+				}
+				return fmt.Errorf("failed to update term %s: %w", id, err)
 			}
+			if err := s.recordTerm(ctx, outbox.TermUpdated, w.term); err != nil {
+				return err
+			}
+		}
 
-			err = s.uploadMessages(ctx, pkg_helpder.BuildTermMessagesUpload(organizationAdminID, req, req.LanguageID))
-			if err != nil {
-				return fmt.Errorf("upload department messages failed")
+		// goi messs lang gw upload message, once the terms are committed; a
+		// failure then must not fail the upload and invite a duplicating retry
+		if len(writes) > 0 {
+			txn.AfterCommit(ctx, func(ctx context.Context) error {
+				if err := s.uploadMessages(ctx, pkg_helpder.BuildTermMessagesUpload(organizationAdminID, req, req.LanguageID)); err != nil {
+					return fmt.Errorf("upload term messages of organization %s: %w", organizationAdminID, err)
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		if conflicted != "" {
+			// changed between our read and write
+			if current, getErr := s.repo.GetByID(ctx, conflicted); getErr == nil {
+				return termConflict(current)
 			}
 		}
+		return err
 	}
 	return nil
 }
 
-func (s *termService) GetTermsByOrgID(ctx context.Context, orgID string) (*response.ListTermsResDTO, error) {
-	terms, err := s.repo.GetAllByOrgID(ctx, orgID)
+// termConflict reports that current has moved past the version an update
+// was based on.
+func termConflict(current *model.Term) error {
+	return &pkg_helpder.ConflictError{
+		Err:     fmt.Errorf("term %s is at version %d: %w", current.ID.Hex(), current.Version, db.ErrVersionConflict),
+		Current: mappers.MapTermToResDTO(current),
+	}
+}
+
+func (s *termService) GetTermsByOrgID(ctx context.Context, orgID string, asOf time.Time) (*response.ListTermsResDTO, error) {
+	var terms []*model.Term
+	var err error
+	if asOf.IsZero() {
+		terms, err = s.repo.GetAllByOrgID(ctx, orgID)
+	} else {
+		terms, err = s.termsAsOf(ctx, orgID, asOf)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("get terms by orgID failed: %w", err)
 	}
@@ -279,14 +413,13 @@ func (s *termService) GetTermsByOrgID(ctx context.Context, orgID string) (*respo
 }
 
 func (s *termService) GetTermsByStudent4App(ctx context.Context, studentID string) ([]response.TermsByStudentResDTO, error) {
-	// get student info
-	student, err := s.userGateway.GetStudentInfo(ctx, studentID)
+	student, err := s.authorizedStudent(ctx, studentID)
 	if err != nil {
-		return nil, fmt.Errorf("get student info failed: %w", err)
+		return nil, err
 	}
 
 	// get terms by orgID
-	terms, err := s.repo.GetAllByOrgID4App(ctx, student.OrganizationID)
+	terms, err := s.repo.GetAllVisibleByOrgID(ctx, student.OrganizationID, publish.Mobile)
 	if err != nil {
 		return nil, fmt.Errorf("get terms by orgID failed: %w", err)
 	}
@@ -308,14 +441,13 @@ func (s *termService) GetTermsByStudent4App(ctx context.Context, studentID strin
 }
 
 func (s *termService) GetTermsByStudent4Web(ctx context.Context, studentID string) ([]response.TermsByStudentResDTO, error) {
-	// get student info
-	student, err := s.userGateway.GetStudentInfo(ctx, studentID)
+	student, err := s.authorizedStudent(ctx, studentID)
 	if err != nil {
-		return nil, fmt.Errorf("get student info failed: %w", err)
+		return nil, err
 	}
 
 	// get terms by orgID
-	terms, err := s.repo.GetAllByOrgID4Web(ctx, student.OrganizationID)
+	terms, err := s.repo.GetAllVisibleByOrgID(ctx, student.OrganizationID, publish.Desktop)
 	if err != nil {
 		return nil, fmt.Errorf("get terms by orgID failed: %w", err)
 	}
@@ -337,7 +469,7 @@ func (s *termService) GetTermsByStudent4Web(ctx context.Context, studentID strin
 }
 
 func (s *termService) GetTerms4App(ctx context.Context, organizationID string) (*response.GetTerms4AppResDTO, error) {
-	terms, err := s.repo.GetAllByOrgID4App(ctx, organizationID)
+	terms, err := s.repo.GetAllVisibleByOrgID(ctx, organizationID, publish.Mobile)
 	if err != nil {
 		return nil, fmt.Errorf("get terms by orgID failed: %w", err)
 	}
@@ -356,6 +488,39 @@ func (s *termService) GetTerms4App(ctx context.Context, organizationID string) (
 	}, nil
 }
 
+// authorizedStudent loads a student whose terms the caller, a member of the
+// student's organization, may read. The caller is authenticated before the
+// student is looked up.
+func (s *termService) authorizedStudent(ctx context.Context, studentID string) (*dto.StudentResponse, error) {
+	currentUser, err := s.userGateway.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get current user info failed: %w", policy.ErrUnauthenticated)
+	}
+
+	student, err := s.userGateway.GetStudentInfo(ctx, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("get student info failed: %w", err)
+	}
+
+	if err := policy.Authorize(currentUser, policy.TermRead, student.OrganizationID); err != nil {
+		if !errors.Is(err, policy.ErrForbidden) || !s.looksAfter(ctx, currentUser.ID, studentID) {
+			return nil, err
+		}
+	}
+	return student, nil
+}
+
+// looksAfter reports whether userID is a guardian or teacher of the student.
+// When the relations cannot be loaded access stays denied.
+func (s *termService) looksAfter(ctx context.Context, userID, studentID string) bool {
+	relations, err := s.userGateway.GetStudentRelations(ctx, studentID)
+	if err != nil {
+		zap.FromContext(ctx).Warnw("get student relations failed", "student_id", studentID, "error", err.Error())
+		return false
+	}
+	return slices.Contains(relations.GuardianIDs, userID) || slices.Contains(relations.TeacherIDs, userID)
+}
+
 func (s *termService) uploadMessages(ctx context.Context, req dto.UploadMessageLanguagesRequest) error {
 	err := s.messageLanguageGateway.UploadMessages(ctx, req)
 
@@ -366,20 +531,24 @@ func (s *termService) uploadMessages(ctx context.Context, req dto.UploadMessageL
 	return nil
 }
 
-func (s *termService) GetTerm4Gw(ctx context.Context, termId string) (*response.Term4GwResponse, error) {
-	// get organization admin from user context
-	currentUser, err := s.userGateway.GetCurrentUser(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("get current user info failed")
+func (s *termService) GetTerm4Gw(ctx context.Context, organizationID string, termId string, asOf time.Time) (*response.Term4GwResponse, error) {
+	var term *model.Term
+	var err error
+	if asOf.IsZero() {
+		term, err = s.repo.GetByID(ctx, termId)
+	} else {
+		term, err = s.termAsOf(ctx, termId, asOf)
 	}
-
-	term, err := s.repo.GetByID(ctx, termId)
 	if err != nil {
 		return nil, fmt.Errorf("get term by id failed: %w", err)
 	}
+	// the caller is a service, not a user: scope by the organization it names
+	if term.OrganizationID != organizationID {
+		return nil, fmt.Errorf("term %s in organization %s: %w", termId, organizationID, db.ErrNotFound)
+	}
 
 	// get word by orgID
-	msg, _ := s.messageLanguageGateway.GetMessageLanguage(ctx, "term", currentUser.OrganizationAdmin.ID)
+	msg, _ := s.messageLanguageGateway.GetMessageLanguage(ctx, "term", organizationID)
 	word := ""
 	if msg.Contents != nil {
 		if val, ok := msg.Contents["word"]; ok {
@@ -393,7 +562,7 @@ func (s *termService) GetTerm4Gw(ctx context.Context, termId string) (*response.
 func (s *termService) GetTermsByOrg4App(ctx context.Context, organizationID string) ([]response.TermResponse4App, error) {
 
 	// get terms by orgID
-	terms, err := s.repo.GetAllByOrgIDIsPublishedTeacher(ctx, organizationID)
+	terms, err := s.repo.GetAllVisibleByOrgID(ctx, organizationID, publish.Teacher)
 	if err != nil {
 		return nil, fmt.Errorf("get terms by orgID failed: %w", err)
 	}
@@ -458,26 +627,26 @@ func (s *termService) GetPreviousTerms4GW(ctx context.Context, organizationID st
 	return mappers.MapTermsToRes4GwResponse(previousTerms, word), nil
 }
 
-func (s *termService) GetTerms2Assign4Web(ctx context.Context) ([]*response.TermResponse4Web, error) {
+func (s *termService) GetTerms2Assign4Web(ctx context.Context, organizationID string) ([]*response.TermResponse4Web, error) {
 	// get organization admin from user context
 	currentUser, err := s.userGateway.GetCurrentUser(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("get current user info failed")
 	}
 
-	// check is super admin & check org admin
-	if currentUser.IsSuperAdmin || currentUser.OrganizationAdmin.ID == "" {
-		return nil, fmt.Errorf("access denied: super admin cannot perform this action")
+	organizationID = policy.TargetOrganization(currentUser, organizationID)
+	if err := policy.Authorize(currentUser, policy.TermAssign, organizationID); err != nil {
+		return nil, err
 	}
 
 	// get terms by orgID
-	terms, err := s.repo.GetAllByOrgIDIsPublishedDesktop(ctx, currentUser.OrganizationAdmin.ID)
+	terms, err := s.repo.GetAllVisibleByOrgID(ctx, organizationID, publish.Desktop)
 	if err != nil {
 		return nil, fmt.Errorf("get terms by orgID failed: %w", err)
 	}
 
 	// get word by orgID
-	msg, _ := s.messageLanguageGateway.GetMessageLanguage(ctx, "term", currentUser.OrganizationAdmin.ID)
+	msg, _ := s.messageLanguageGateway.GetMessageLanguage(ctx, "term", organizationID)
 	word := ""
 	if msg.Contents != nil {
 		if val, ok := msg.Contents["word"]; ok {
@@ -0,0 +1,23 @@
+package request
+
+type CreateSubscriptionRequest struct {
+	// OrganizationID lets a super admin subscribe on behalf of an
+	// organization.
+	OrganizationID string `json:"organization_id,omitempty"`
+	URL            string `json:"url" binding:"required,url,max=2048"`
+	// Secret signs deliveries; one is generated when empty.
+	Secret string `json:"secret,omitempty" binding:"omitempty,min=16,max=128"`
+	// EventTypes limits deliveries to these types; empty means all.
+	EventTypes []string `json:"event_types" binding:"omitempty,dive,oneof=term.created term.updated term.deleted holiday.created holiday.updated holiday.deleted term.starting_soon term.ended holiday.starting_soon"`
+	// Active defaults to true.
+	Active *bool `json:"active"`
+}
+
+type UpdateSubscriptionRequest struct {
+	URL string `json:"url" binding:"required,url,max=2048"`
+	// Secret, when set, replaces the signing secret.
+	Secret     string   `json:"secret,omitempty" binding:"omitempty,min=16,max=128"`
+	EventTypes []string `json:"event_types" binding:"omitempty,dive,oneof=term.created term.updated term.deleted holiday.created holiday.updated holiday.deleted term.starting_soon term.ended holiday.starting_soon"`
+	// Active is left unchanged when omitted.
+	Active *bool `json:"active"`
+}
@@ -0,0 +1,28 @@
+package response
+
+import "time"
+
+type SubscriptionResDTO struct {
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organization_id"`
+	URL            string    `json:"url"`
+	EventTypes     []string  `json:"event_types"`
+	Active         bool      `json:"active"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	// Secret is only returned when it was generated or replaced.
+	Secret string `json:"secret,omitempty"`
+}
+
+type DeliveryResDTO struct {
+	ID             string     `json:"id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	ResponseStatus int        `json:"response_status,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"` // only while pending
+	CreatedAt      time.Time  `json:"created_at"`
+}
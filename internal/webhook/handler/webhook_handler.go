@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"term-service/internal/webhook/dto/request"
+	"term-service/internal/webhook/service"
+	"term-service/pkg/constants"
+	"term-service/pkg/db"
+	"term-service/pkg/helper"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 200
+)
+
+type WebhookHandler struct {
+	service service.WebhookService
+}
+
+func NewHandler(s service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: s}
+}
+
+// organizationID is the organization authorization resolved for the request.
+func organizationID(c *gin.Context) string {
+	return c.GetString(constants.TargetOrgID.String())
+}
+
+func (h *WebhookHandler) CreateSubscription(c *gin.Context) {
+	var req request.CreateSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	res, err := h.service.CreateSubscription(c.Request.Context(), organizationID(c), req)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusCreated, "Success", res)
+}
+
+func (h *WebhookHandler) GetSubscriptions(c *gin.Context) {
+	res, err := h.service.GetSubscriptions(c.Request.Context(), organizationID(c))
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Success", res)
+}
+
+func (h *WebhookHandler) GetSubscription(c *gin.Context) {
+	res, err := h.service.GetSubscription(c.Request.Context(), organizationID(c), c.Param("id"))
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Success", res)
+}
+
+func (h *WebhookHandler) UpdateSubscription(c *gin.Context) {
+	var req request.UpdateSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+		return
+	}
+
+	res, err := h.service.UpdateSubscription(c.Request.Context(), organizationID(c), c.Param("id"), req)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Success", res)
+}
+
+func (h *WebhookHandler) DeleteSubscription(c *gin.Context) {
+	if err := h.service.DeleteSubscription(c.Request.Context(), organizationID(c), c.Param("id")); err != nil {
+		sendError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Deleted webhook subscription successfully", nil)
+}
+
+// PingSubscription answers 200 with the logged delivery whether or not the
+// receiver accepted the ping; its status tells which.
+func (h *WebhookHandler) PingSubscription(c *gin.Context) {
+	res, err := h.service.PingSubscription(c.Request.Context(), organizationID(c), c.Param("id"))
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Success", res)
+}
+
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	limit := defaultDeliveryLimit
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > maxDeliveryLimit {
+			helper.SendError(c, http.StatusBadRequest, fmt.Errorf("limit must be between 1 and %d", maxDeliveryLimit), helper.ErrInvalidRequest)
+			return
+		}
+		limit = n
+	}
+
+	res, err := h.service.GetDeliveries(c.Request.Context(), organizationID(c), c.Param("id"), limit)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+	helper.SendSuccess(c, http.StatusOK, "Success", res)
+}
+
+func sendError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		helper.SendError(c, http.StatusNotFound, err, helper.ErrNotFount)
+	case errors.Is(err, service.ErrInvalidSubscription):
+		helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+	default:
+		helper.SendServiceError(c, http.StatusInternalServerError, err, helper.ErrInternal)
+	}
+}
@@ -0,0 +1,57 @@
+package mapper
+
+import (
+	"term-service/internal/webhook/dto/response"
+	"term-service/internal/webhook/model"
+)
+
+func MapSubscriptionToResDTO(sub *model.Subscription) response.SubscriptionResDTO {
+	eventTypes := sub.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	return response.SubscriptionResDTO{
+		ID:             sub.ID.Hex(),
+		OrganizationID: sub.OrganizationID,
+		URL:            sub.URL,
+		EventTypes:     eventTypes,
+		Active:         sub.Active,
+		CreatedAt:      sub.CreatedAt,
+		UpdatedAt:      sub.UpdatedAt,
+	}
+}
+
+func MapSubscriptionListToResDTO(subs []*model.Subscription) []response.SubscriptionResDTO {
+	result := make([]response.SubscriptionResDTO, 0, len(subs))
+	for _, sub := range subs {
+		result = append(result, MapSubscriptionToResDTO(sub))
+	}
+	return result
+}
+
+func MapDeliveryToResDTO(d *model.Delivery) response.DeliveryResDTO {
+	res := response.DeliveryResDTO{
+		ID:             d.ID.Hex(),
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		LastError:      d.LastError,
+		LastAttemptAt:  d.LastAttemptAt,
+		CreatedAt:      d.CreatedAt,
+	}
+	if d.Status == model.DeliveryPending {
+		next := d.NextAttemptAt
+		res.NextAttemptAt = &next
+	}
+	return res
+}
+
+func MapDeliveryListToResDTO(deliveries []*model.Delivery) []response.DeliveryResDTO {
+	result := make([]response.DeliveryResDTO, 0, len(deliveries))
+	for _, d := range deliveries {
+		result = append(result, MapDeliveryToResDTO(d))
+	}
+	return result
+}
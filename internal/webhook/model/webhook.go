@@ -0,0 +1,69 @@
+package model
+
+import (
+	"term-service/pkg/objectid"
+	"time"
+)
+
+// Subscription asks for an organization's calendar events to be POSTed to
+// URL, signed with Secret.
+type Subscription struct {
+	ID             objectid.ID `bson:"_id,omitempty" gorm:"primaryKey;size:24"`
+	OrganizationID string      `bson:"organization_id" gorm:"size:64;index:idx_webhook_subscriptions_org"`
+	URL            string      `bson:"url" gorm:"size:2048"`
+	Secret         string      `bson:"secret" gorm:"size:128"`
+	EventTypes     []string    `bson:"event_types" gorm:"serializer:json"` // empty means every type
+	Active         bool        `bson:"active"`
+	CreatedAt      time.Time   `bson:"created_at"`
+	UpdatedAt      time.Time   `bson:"updated_at"`
+}
+
+func (Subscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// Wants reports whether the subscription receives events of eventType.
+func (s *Subscription) Wants(eventType string) bool {
+	if !s.Active {
+		return false
+	}
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Delivery states.
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed" // gave up
+)
+
+// Delivery is one event sent, or still to be sent, to one subscription. It
+// doubles as the delivery log.
+type Delivery struct {
+	ID             objectid.ID `bson:"_id,omitempty" gorm:"primaryKey;size:24"`
+	SubscriptionID string      `bson:"subscription_id" gorm:"size:24;index:idx_webhook_deliveries_subscription,priority:1"`
+	OrganizationID string      `bson:"organization_id" gorm:"size:64"`
+	EventID        string      `bson:"event_id" gorm:"size:24"`
+	EventType      string      `bson:"event_type" gorm:"size:64"`
+	Payload        []byte      `bson:"payload"` // the request body
+
+	Status         string     `bson:"status" gorm:"size:16;index:idx_webhook_deliveries_due,priority:1"`
+	Attempts       int        `bson:"attempts"`
+	NextAttemptAt  time.Time  `bson:"next_attempt_at" gorm:"index:idx_webhook_deliveries_due,priority:2"`
+	LastAttemptAt  *time.Time `bson:"last_attempt_at"`
+	ResponseStatus int        `bson:"response_status"` // of the last attempt; 0 when no response
+	LastError      string     `bson:"last_error" gorm:"size:1024"`
+	CreatedAt      time.Time  `bson:"created_at" gorm:"index:idx_webhook_deliveries_subscription,priority:2"`
+}
+
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
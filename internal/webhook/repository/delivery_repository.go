@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"term-service/internal/webhook/model"
+	"term-service/pkg/objectid"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type DeliveryRepository interface {
+	Create(ctx context.Context, deliveries ...*model.Delivery) error
+	// Claim returns up to limit pending deliveries that are due and hides
+	// them from other workers for lease.
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*model.Delivery, error)
+	// Update saves the outcome of an attempt.
+	Update(ctx context.Context, delivery *model.Delivery) error
+	// GetAllBySubscriptionID returns the newest limit deliveries first.
+	GetAllBySubscriptionID(ctx context.Context, subscriptionID string, limit int) ([]*model.Delivery, error)
+	DeleteBySubscriptionID(ctx context.Context, subscriptionID string) error
+}
+
+type deliveryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewDeliveryRepository(collection *mongo.Collection) DeliveryRepository {
+	return &deliveryRepository{collection}
+}
+
+func (r *deliveryRepository) Create(ctx context.Context, deliveries ...*model.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	docs := make([]interface{}, len(deliveries))
+	for i, d := range deliveries {
+		prepareDelivery(d)
+		docs[i] = d
+	}
+	_, err := r.collection.InsertMany(ctx, docs)
+	return err
+}
+
+func (r *deliveryRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*model.Delivery, error) {
+	var claimed []*model.Delivery
+	for len(claimed) < limit {
+		now := time.Now().UTC()
+		var d model.Delivery
+		err := r.collection.FindOneAndUpdate(ctx,
+			bson.M{"status": model.DeliveryPending, "next_attempt_at": bson.M{"$lte": now}},
+			bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}},
+			options.FindOneAndUpdate().
+				SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+				SetReturnDocument(options.After),
+		).Decode(&d)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			break
+		}
+		if err != nil {
+			return claimed, err
+		}
+		claimed = append(claimed, &d)
+	}
+	return claimed, nil
+}
+
+func (r *deliveryRepository) Update(ctx context.Context, d *model.Delivery) error {
+	_, err := r.collection.UpdateByID(ctx, d.ID, bson.M{"$set": bson.M{
+		"status":          d.Status,
+		"attempts":        d.Attempts,
+		"next_attempt_at": d.NextAttemptAt,
+		"last_attempt_at": d.LastAttemptAt,
+		"response_status": d.ResponseStatus,
+		"last_error":      d.LastError,
+	}})
+	return err
+}
+
+func (r *deliveryRepository) GetAllBySubscriptionID(ctx context.Context, subscriptionID string, limit int) ([]*model.Delivery, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"subscription_id": subscriptionID},
+		options.Find().
+			SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+			SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	deliveries := make([]*model.Delivery, 0)
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *deliveryRepository) DeleteBySubscriptionID(ctx context.Context, subscriptionID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"subscription_id": subscriptionID})
+	return err
+}
+
+// prepareDelivery fills in what every backend sets on insert.
+func prepareDelivery(d *model.Delivery) {
+	if d.ID.IsZero() {
+		d.ID = objectid.New()
+	}
+	if d.Status == "" {
+		d.Status = model.DeliveryPending
+	}
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = time.Now()
+	}
+	if d.NextAttemptAt.IsZero() {
+		d.NextAttemptAt = d.CreatedAt
+	}
+}
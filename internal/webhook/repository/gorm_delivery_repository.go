@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"term-service/internal/webhook/model"
+	"term-service/pkg/db/txn"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type gormDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewGormDeliveryRepository returns a DeliveryRepository backed by MySQL (or
+// any other GORM dialect).
+func NewGormDeliveryRepository(db *gorm.DB) DeliveryRepository {
+	return &gormDeliveryRepository{db}
+}
+
+func (r *gormDeliveryRepository) Create(ctx context.Context, deliveries ...*model.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	for _, d := range deliveries {
+		prepareDelivery(d)
+	}
+	return txn.Gorm(ctx, r.db).Create(deliveries).Error
+}
+
+func (r *gormDeliveryRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*model.Delivery, error) {
+	now := time.Now()
+
+	var due []*model.Delivery
+	err := txn.Gorm(ctx, r.db).
+		Where("status = ? AND next_attempt_at <= ?", model.DeliveryPending, now).
+		Order("next_attempt_at").
+		Limit(limit).
+		Find(&due).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Another worker may claim the same rows; whoever moves next_attempt_at
+	// first wins.
+	claimed := due[:0]
+	for _, d := range due {
+		result := txn.Gorm(ctx, r.db).Model(&model.Delivery{}).
+			Where("id = ? AND status = ? AND next_attempt_at = ?", d.ID, model.DeliveryPending, d.NextAttemptAt).
+			Update("next_attempt_at", now.Add(lease))
+		if result.Error != nil {
+			return claimed, result.Error
+		}
+		if result.RowsAffected == 1 {
+			d.NextAttemptAt = now.Add(lease)
+			claimed = append(claimed, d)
+		}
+	}
+	return claimed, nil
+}
+
+func (r *gormDeliveryRepository) Update(ctx context.Context, d *model.Delivery) error {
+	return txn.Gorm(ctx, r.db).Model(&model.Delivery{}).Where("id = ?", d.ID).Updates(map[string]interface{}{
+		"status":          d.Status,
+		"attempts":        d.Attempts,
+		"next_attempt_at": d.NextAttemptAt,
+		"last_attempt_at": d.LastAttemptAt,
+		"response_status": d.ResponseStatus,
+		"last_error":      d.LastError,
+	}).Error
+}
+
+func (r *gormDeliveryRepository) GetAllBySubscriptionID(ctx context.Context, subscriptionID string, limit int) ([]*model.Delivery, error) {
+	deliveries := make([]*model.Delivery, 0)
+	err := txn.Gorm(ctx, r.db).
+		Where("subscription_id = ?", subscriptionID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *gormDeliveryRepository) DeleteBySubscriptionID(ctx context.Context, subscriptionID string) error {
+	return txn.Gorm(ctx, r.db).Where("subscription_id = ?", subscriptionID).Delete(&model.Delivery{}).Error
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"term-service/internal/webhook/model"
+	"term-service/pkg/db"
+	"term-service/pkg/db/txn"
+	"term-service/pkg/objectid"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type gormSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormSubscriptionRepository returns a SubscriptionRepository backed by
+// MySQL (or any other GORM dialect).
+func NewGormSubscriptionRepository(db *gorm.DB) SubscriptionRepository {
+	return &gormSubscriptionRepository{db}
+}
+
+func (r *gormSubscriptionRepository) Create(ctx context.Context, sub *model.Subscription) (*model.Subscription, error) {
+	now := time.Now()
+	if sub.ID.IsZero() {
+		sub.ID = objectid.New()
+	}
+	sub.CreatedAt = now
+	sub.UpdatedAt = now
+
+	if err := txn.Gorm(ctx, r.db).Create(sub).Error; err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (r *gormSubscriptionRepository) GetByID(ctx context.Context, id string) (*model.Subscription, error) {
+	var sub model.Subscription
+	err := txn.Gorm(ctx, r.db).Where("id = ?", id).First(&sub).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, db.ErrNotFound
+		}
+		return nil, err
+	}
+	return &sub, nil
+}
+
+func (r *gormSubscriptionRepository) Update(ctx context.Context, sub *model.Subscription) error {
+	sub.UpdatedAt = time.Now()
+
+	// Select keeps zero values such as active=false and an emptied
+	// event_types list.
+	result := txn.Gorm(ctx, r.db).Model(sub).
+		Select("url", "secret", "event_types", "active", "updated_at").
+		Updates(sub)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormSubscriptionRepository) Delete(ctx context.Context, id string) error {
+	result := txn.Gorm(ctx, r.db).Where("id = ?", id).Delete(&model.Subscription{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormSubscriptionRepository) GetAllByOrgID(ctx context.Context, orgID string) ([]*model.Subscription, error) {
+	subs := make([]*model.Subscription, 0)
+	err := txn.Gorm(ctx, r.db).Where("organization_id = ?", orgID).Order("created_at").Find(&subs).Error
+	return subs, err
+}
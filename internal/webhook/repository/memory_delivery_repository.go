@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"term-service/internal/webhook/model"
+	"term-service/pkg/objectid"
+	"time"
+)
+
+type memoryDeliveryRepository struct {
+	mu         sync.Mutex
+	deliveries map[objectid.ID]model.Delivery
+}
+
+// NewMemoryDeliveryRepository returns a DeliveryRepository kept in process
+// memory, for tests and local runs without a database.
+func NewMemoryDeliveryRepository() DeliveryRepository {
+	return &memoryDeliveryRepository{deliveries: make(map[objectid.ID]model.Delivery)}
+}
+
+func (r *memoryDeliveryRepository) Create(ctx context.Context, deliveries ...*model.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, d := range deliveries {
+		prepareDelivery(d)
+		r.deliveries[d.ID] = *d
+	}
+	return nil
+}
+
+func (r *memoryDeliveryRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*model.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	var due []*model.Delivery
+	for _, d := range r.deliveries {
+		if d.Status == model.DeliveryPending && !d.NextAttemptAt.After(now) {
+			d := d
+			due = append(due, &d)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if due[i].NextAttemptAt.Equal(due[j].NextAttemptAt) {
+			return due[i].ID < due[j].ID
+		}
+		return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	for _, d := range due {
+		d.NextAttemptAt = now.Add(lease)
+		r.deliveries[d.ID] = *d
+	}
+	return due, nil
+}
+
+func (r *memoryDeliveryRepository) Update(ctx context.Context, d *model.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.deliveries[d.ID]
+	if !ok {
+		return nil
+	}
+	existing.Status = d.Status
+	existing.Attempts = d.Attempts
+	existing.NextAttemptAt = d.NextAttemptAt
+	existing.LastAttemptAt = d.LastAttemptAt
+	existing.ResponseStatus = d.ResponseStatus
+	existing.LastError = d.LastError
+	r.deliveries[d.ID] = existing
+	return nil
+}
+
+func (r *memoryDeliveryRepository) GetAllBySubscriptionID(ctx context.Context, subscriptionID string, limit int) ([]*model.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deliveries := make([]*model.Delivery, 0)
+	for _, d := range r.deliveries {
+		if d.SubscriptionID == subscriptionID {
+			d := d
+			deliveries = append(deliveries, &d)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		if deliveries[i].CreatedAt.Equal(deliveries[j].CreatedAt) {
+			return deliveries[i].ID > deliveries[j].ID
+		}
+		return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt)
+	})
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+func (r *memoryDeliveryRepository) DeleteBySubscriptionID(ctx context.Context, subscriptionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, d := range r.deliveries {
+		if d.SubscriptionID == subscriptionID {
+			delete(r.deliveries, id)
+		}
+	}
+	return nil
+}
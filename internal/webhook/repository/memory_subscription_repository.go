@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"term-service/internal/webhook/model"
+	"term-service/pkg/db"
+	"term-service/pkg/objectid"
+	"time"
+)
+
+type memorySubscriptionRepository struct {
+	mu   sync.RWMutex
+	subs map[objectid.ID]model.Subscription
+}
+
+// NewMemorySubscriptionRepository returns a SubscriptionRepository kept in
+// process memory, for tests and local runs without a database.
+func NewMemorySubscriptionRepository() SubscriptionRepository {
+	return &memorySubscriptionRepository{subs: make(map[objectid.ID]model.Subscription)}
+}
+
+func (r *memorySubscriptionRepository) Create(ctx context.Context, sub *model.Subscription) (*model.Subscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if sub.ID.IsZero() {
+		sub.ID = objectid.New()
+	}
+	sub.CreatedAt = now
+	sub.UpdatedAt = now
+
+	stored := *sub
+	stored.EventTypes = append([]string(nil), sub.EventTypes...)
+	r.subs[sub.ID] = stored
+	return sub, nil
+}
+
+func (r *memorySubscriptionRepository) GetByID(ctx context.Context, id string) (*model.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sub, ok := r.subs[objectid.ID(id)]
+	if !ok {
+		return nil, db.ErrNotFound
+	}
+	return &sub, nil
+}
+
+func (r *memorySubscriptionRepository) Update(ctx context.Context, sub *model.Subscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.subs[sub.ID]
+	if !ok {
+		return db.ErrNotFound
+	}
+
+	sub.UpdatedAt = time.Now()
+	existing.URL = sub.URL
+	existing.Secret = sub.Secret
+	existing.EventTypes = append([]string(nil), sub.EventTypes...)
+	existing.Active = sub.Active
+	existing.UpdatedAt = sub.UpdatedAt
+	r.subs[sub.ID] = existing
+	return nil
+}
+
+func (r *memorySubscriptionRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.subs[objectid.ID(id)]; !ok {
+		return db.ErrNotFound
+	}
+	delete(r.subs, objectid.ID(id))
+	return nil
+}
+
+func (r *memorySubscriptionRepository) GetAllByOrgID(ctx context.Context, orgID string) ([]*model.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subs := make([]*model.Subscription, 0)
+	for _, s := range r.subs {
+		if s.OrganizationID == orgID {
+			s := s
+			subs = append(subs, &s)
+		}
+	}
+	sort.Slice(subs, func(i, j int) bool {
+		if subs[i].CreatedAt.Equal(subs[j].CreatedAt) {
+			return subs[i].ID < subs[j].ID
+		}
+		return subs[i].CreatedAt.Before(subs[j].CreatedAt)
+	})
+	return subs, nil
+}
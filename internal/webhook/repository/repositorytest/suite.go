@@ -0,0 +1,179 @@
+// Package repositorytest holds the behaviour every webhook repository
+// backend must share. Backend tests call Run with constructors for empty
+// stores.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"term-service/internal/webhook/model"
+	"term-service/internal/webhook/repository"
+	"term-service/pkg/db"
+	"term-service/pkg/objectid"
+	"testing"
+	"time"
+)
+
+// Repositories is one backend's pair of webhook repositories.
+type Repositories struct {
+	Subscriptions repository.SubscriptionRepository
+	Deliveries    repository.DeliveryRepository
+}
+
+// Run executes the conformance suite; newRepos must return empty
+// repositories.
+func Run(t *testing.T, newRepos func(t *testing.T) Repositories) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repos Repositories)
+	}{
+		{"Subscriptions", testSubscriptions},
+		{"ClaimAndUpdateDeliveries", testClaimAndUpdateDeliveries},
+		{"DeliveryLog", testDeliveryLog},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepos(t))
+		})
+	}
+}
+
+func testSubscriptions(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	subs := repos.Subscriptions
+
+	created, err := subs.Create(ctx, &model.Subscription{
+		OrganizationID: "org-a",
+		URL:            "https://partner.example/hooks",
+		Secret:         "s1",
+		EventTypes:     []string{"term.created", "term.updated"},
+		Active:         true,
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := subs.Create(ctx, &model.Subscription{OrganizationID: "org-b", URL: "https://other.example", Secret: "s2", Active: true}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	got, err := subs.GetByID(ctx, created.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.URL != created.URL || len(got.EventTypes) != 2 || got.EventTypes[1] != "term.updated" || !got.Active {
+		t.Fatalf("GetByID = %+v", got)
+	}
+
+	got.Active = false
+	got.EventTypes = nil
+	got.Secret = "s1-rotated"
+	if err := subs.Update(ctx, got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	got, _ = subs.GetByID(ctx, created.ID.Hex())
+	if got.Active || len(got.EventTypes) != 0 || got.Secret != "s1-rotated" {
+		t.Fatalf("after Update = %+v", got)
+	}
+
+	list, err := subs.GetAllByOrgID(ctx, "org-a")
+	if err != nil || len(list) != 1 || list[0].ID != created.ID {
+		t.Fatalf("GetAllByOrgID = %+v, %v", list, err)
+	}
+
+	if err := subs.Delete(ctx, created.ID.Hex()); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := subs.GetByID(ctx, created.ID.Hex()); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("GetByID after Delete = %v, want ErrNotFound", err)
+	}
+	if err := subs.Delete(ctx, created.ID.Hex()); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("second Delete = %v, want ErrNotFound", err)
+	}
+	if err := subs.Update(ctx, &model.Subscription{ID: objectid.New()}); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("Update of missing = %v, want ErrNotFound", err)
+	}
+}
+
+func testClaimAndUpdateDeliveries(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	deliveries := repos.Deliveries
+	past := time.Now().Add(-time.Minute).Truncate(time.Millisecond)
+
+	due := &model.Delivery{SubscriptionID: "sub-1", EventType: "term.created", Payload: []byte(`{"id":"e1"}`), NextAttemptAt: past}
+	later := &model.Delivery{SubscriptionID: "sub-1", EventType: "term.updated", NextAttemptAt: time.Now().Add(time.Hour)}
+	if err := deliveries.Create(ctx, due, later); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if due.Status != model.DeliveryPending || due.ID.IsZero() {
+		t.Fatalf("created delivery = %+v", due)
+	}
+
+	claimed, err := deliveries.Claim(ctx, 10, time.Minute)
+	if err != nil || len(claimed) != 1 || claimed[0].ID != due.ID {
+		t.Fatalf("Claim = %+v, %v, want the due delivery", claimed, err)
+	}
+	if string(claimed[0].Payload) != `{"id":"e1"}` {
+		t.Fatalf("claimed payload = %s", claimed[0].Payload)
+	}
+	if again, _ := deliveries.Claim(ctx, 10, time.Minute); len(again) != 0 {
+		t.Fatalf("leased delivery claimed again: %+v", again)
+	}
+
+	// a failed attempt due for retry now
+	now := time.Now()
+	d := claimed[0]
+	d.Attempts = 1
+	d.LastAttemptAt = &now
+	d.ResponseStatus = 503
+	d.LastError = "503 Service Unavailable"
+	d.NextAttemptAt = now.Add(-time.Second)
+	if err := deliveries.Update(ctx, d); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	claimed, err = deliveries.Claim(ctx, 10, time.Minute)
+	if err != nil || len(claimed) != 1 || claimed[0].Attempts != 1 || claimed[0].ResponseStatus != 503 {
+		t.Fatalf("Claim after retry scheduled = %+v, %v", claimed, err)
+	}
+
+	claimed[0].Status = model.DeliverySucceeded
+	claimed[0].ResponseStatus = 200
+	if err := deliveries.Update(ctx, claimed[0]); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	claimed[0].NextAttemptAt = past
+	if again, _ := deliveries.Claim(ctx, 10, time.Minute); len(again) != 0 {
+		t.Fatalf("succeeded delivery claimed again: %+v", again)
+	}
+}
+
+func testDeliveryLog(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	deliveries := repos.Deliveries
+	base := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+
+	for i, sub := range []string{"sub-1", "sub-1", "sub-2", "sub-1"} {
+		d := &model.Delivery{SubscriptionID: sub, EventType: "term.updated", CreatedAt: base.Add(time.Duration(i) * time.Minute)}
+		if err := deliveries.Create(ctx, d); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	log, err := deliveries.GetAllBySubscriptionID(ctx, "sub-1", 2)
+	if err != nil {
+		t.Fatalf("GetAllBySubscriptionID: %v", err)
+	}
+	if len(log) != 2 || !log[0].CreatedAt.Equal(base.Add(3*time.Minute)) || !log[1].CreatedAt.Equal(base.Add(time.Minute)) {
+		t.Fatalf("log = %+v, want the two newest sub-1 deliveries, newest first", log)
+	}
+
+	if err := deliveries.DeleteBySubscriptionID(ctx, "sub-1"); err != nil {
+		t.Fatalf("DeleteBySubscriptionID: %v", err)
+	}
+	if log, _ := deliveries.GetAllBySubscriptionID(ctx, "sub-1", 10); len(log) != 0 {
+		t.Fatalf("log after delete = %+v", log)
+	}
+	if log, _ := deliveries.GetAllBySubscriptionID(ctx, "sub-2", 10); len(log) != 1 {
+		t.Fatalf("other subscription's log = %+v", log)
+	}
+}
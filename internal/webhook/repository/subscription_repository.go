@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"term-service/internal/webhook/model"
+	"term-service/pkg/db"
+	"term-service/pkg/objectid"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SubscriptionRepository interface {
+	Create(ctx context.Context, sub *model.Subscription) (*model.Subscription, error)
+	GetByID(ctx context.Context, id string) (*model.Subscription, error)
+	Update(ctx context.Context, sub *model.Subscription) error
+	Delete(ctx context.Context, id string) error
+	GetAllByOrgID(ctx context.Context, orgID string) ([]*model.Subscription, error)
+}
+
+type subscriptionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSubscriptionRepository(collection *mongo.Collection) SubscriptionRepository {
+	return &subscriptionRepository{collection}
+}
+
+func (r *subscriptionRepository) Create(ctx context.Context, sub *model.Subscription) (*model.Subscription, error) {
+	now := time.Now()
+	if sub.ID.IsZero() {
+		sub.ID = objectid.New()
+	}
+	sub.CreatedAt = now
+	sub.UpdatedAt = now
+
+	if _, err := r.collection.InsertOne(ctx, sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (r *subscriptionRepository) GetByID(ctx context.Context, id string) (*model.Subscription, error) {
+	var sub model.Subscription
+	err := r.collection.FindOne(ctx, bson.M{"_id": objectid.ID(id)}).Decode(&sub)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, db.ErrNotFound
+		}
+		return nil, err
+	}
+	return &sub, nil
+}
+
+func (r *subscriptionRepository) Update(ctx context.Context, sub *model.Subscription) error {
+	sub.UpdatedAt = time.Now()
+
+	result, err := r.collection.UpdateByID(ctx, sub.ID, bson.M{"$set": bson.M{
+		"url":         sub.URL,
+		"secret":      sub.Secret,
+		"event_types": sub.EventTypes,
+		"active":      sub.Active,
+		"updated_at":  sub.UpdatedAt,
+	}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+func (r *subscriptionRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectid.ID(id)})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+func (r *subscriptionRepository) GetAllByOrgID(ctx context.Context, orgID string) ([]*model.Subscription, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"organization_id": orgID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	subs := make([]*model.Subscription, 0)
+	if err := cursor.All(ctx, &subs); err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
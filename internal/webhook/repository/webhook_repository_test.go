@@ -0,0 +1,47 @@
+package repository_test
+
+import (
+	"term-service/internal/webhook/repository"
+	"term-service/internal/webhook/repository/repositorytest"
+	"term-service/pkg/db/dbtest"
+	"testing"
+)
+
+func TestMongoWebhookRepositories(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		db := dbtest.NewMongo(t)
+		return repositorytest.Repositories{
+			Subscriptions: repository.NewSubscriptionRepository(db.Collection("webhook_subscriptions")),
+			Deliveries:    repository.NewDeliveryRepository(db.Collection("webhook_deliveries")),
+		}
+	})
+}
+
+func TestGormSQLiteWebhookRepositories(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		db := dbtest.NewSQLite(t)
+		return repositorytest.Repositories{
+			Subscriptions: repository.NewGormSubscriptionRepository(db),
+			Deliveries:    repository.NewGormDeliveryRepository(db),
+		}
+	})
+}
+
+func TestGormMySQLWebhookRepositories(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		db := dbtest.NewMySQL(t)
+		return repositorytest.Repositories{
+			Subscriptions: repository.NewGormSubscriptionRepository(db),
+			Deliveries:    repository.NewGormDeliveryRepository(db),
+		}
+	})
+}
+
+func TestMemoryWebhookRepositories(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		return repositorytest.Repositories{
+			Subscriptions: repository.NewMemorySubscriptionRepository(),
+			Deliveries:    repository.NewMemoryDeliveryRepository(),
+		}
+	})
+}
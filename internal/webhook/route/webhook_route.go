@@ -0,0 +1,31 @@
+package route
+
+import (
+	"term-service/internal/gateway"
+	"term-service/internal/policy"
+	"term-service/internal/term/middleware"
+	"term-service/internal/webhook/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// authLimit throttles per user and organization and runs after authorization
+// has resolved them.
+func RegisterWebhookRoutes(r *gin.Engine, h *handler.WebhookHandler, userGW gateway.UserGateway, authLimit gin.HandlerFunc) {
+	// Admin routes
+	adminGroup := r.Group("/api/v1/admin")
+	adminGroup.Use(middleware.Secured())
+	{
+		webhooksAdmin := adminGroup.Group("/webhooks")
+		webhooksAdmin.Use(middleware.Authorize(userGW, policy.WebhookAdmin, middleware.AdminTarget), authLimit)
+		{
+			webhooksAdmin.POST("", h.CreateSubscription)
+			webhooksAdmin.GET("", h.GetSubscriptions)
+			webhooksAdmin.GET("/:id", h.GetSubscription)
+			webhooksAdmin.PUT("/:id", h.UpdateSubscription)
+			webhooksAdmin.DELETE("/:id", h.DeleteSubscription)
+			webhooksAdmin.POST("/:id/ping", h.PingSubscription)
+			webhooksAdmin.GET("/:id/deliveries", h.GetDeliveries)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"syscall"
+)
+
+// ErrBlockedDestination marks deliveries refused because the subscription
+// URL leads to an address the service must not reach.
+var ErrBlockedDestination = errors.New("webhook destination is not a public address")
+
+// Destinations decides where webhooks may be sent. By default only https
+// URLs whose host resolves to public addresses are accepted, so a
+// subscription cannot reach the service's own network: loopback, private and
+// link-local addresses (cloud metadata, the Consul agent) are refused.
+type Destinations struct {
+	// AllowInsecure admits http URLs and non-public addresses, for local
+	// development and tests.
+	AllowInsecure bool
+	// LookupIP resolves subscription hosts; net.DefaultResolver.LookupIP
+	// when nil.
+	LookupIP func(ctx context.Context, network, host string) ([]net.IP, error)
+}
+
+// CheckURL reports whether raw may be subscribed to. Every address its host
+// resolves to must be public.
+func (d Destinations) CheckURL(ctx context.Context, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Hostname() == "" || !d.allowedScheme(u.Scheme) {
+		if d.AllowInsecure {
+			return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidSubscription)
+		}
+		return fmt.Errorf("%w: url must be an absolute https URL", ErrInvalidSubscription)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%w: url must not carry credentials", ErrInvalidSubscription)
+	}
+	if d.AllowInsecure {
+		return nil
+	}
+
+	host := u.Hostname()
+	ips, err := d.lookup(ctx, host)
+	if err != nil {
+		return fmt.Errorf("%w: url host %s does not resolve", ErrInvalidSubscription, host)
+	}
+	for _, ip := range ips {
+		if !public(ip) {
+			return fmt.Errorf("%w: url host %s resolves to %s, which is not a public address", ErrInvalidSubscription, host, ip)
+		}
+	}
+	return nil
+}
+
+func (d Destinations) allowedScheme(scheme string) bool {
+	return scheme == "https" || (d.AllowInsecure && scheme == "http")
+}
+
+func (d Destinations) lookup(ctx context.Context, host string) ([]net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IP{ip}, nil
+	}
+	lookup := d.LookupIP
+	if lookup == nil {
+		lookup = net.DefaultResolver.LookupIP
+	}
+	ips, err := lookup(ctx, "ip", host)
+	if err == nil && len(ips) == 0 {
+		err = fmt.Errorf("no addresses for %s", host)
+	}
+	return ips, err
+}
+
+// control runs on every connection the sender opens, after the host was
+// resolved, so a host re-pointed at an internal address once its
+// subscription was accepted (DNS rebinding) is still refused.
+func (d Destinations) control(network, address string, _ syscall.RawConn) error {
+	if d.AllowInsecure {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !public(ip) {
+		return fmt.Errorf("%w: %s", ErrBlockedDestination, host)
+	}
+	return nil
+}
+
+func public(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast()
+}
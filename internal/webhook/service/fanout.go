@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"term-service/internal/webhook/model"
+	"term-service/internal/webhook/repository"
+	"term-service/pkg/outbox"
+)
+
+type fanoutRecorder struct {
+	next          outbox.Recorder
+	subscriptions repository.SubscriptionRepository
+	deliveries    repository.DeliveryRepository
+}
+
+// NewFanoutRecorder records events with next and queues a delivery for
+// every active subscription of the event's organization that wants it.
+// Both happen with the caller's ctx, so inside its transaction.
+func NewFanoutRecorder(next outbox.Recorder, subscriptions repository.SubscriptionRepository, deliveries repository.DeliveryRepository) outbox.Recorder {
+	return &fanoutRecorder{next: next, subscriptions: subscriptions, deliveries: deliveries}
+}
+
+func (r *fanoutRecorder) Record(ctx context.Context, events ...*outbox.Event) error {
+	if err := r.next.Record(ctx, events...); err != nil {
+		return err
+	}
+
+	subsByOrg := make(map[string][]*model.Subscription)
+	var queued []*model.Delivery
+	for _, e := range events {
+		subs, ok := subsByOrg[e.OrganizationID]
+		if !ok {
+			var err error
+			if subs, err = r.subscriptions.GetAllByOrgID(ctx, e.OrganizationID); err != nil {
+				return fmt.Errorf("list webhook subscriptions: %w", err)
+			}
+			subsByOrg[e.OrganizationID] = subs
+		}
+
+		var payload []byte
+		for _, sub := range subs {
+			if !sub.Wants(e.Type) {
+				continue
+			}
+			if payload == nil {
+				var err error
+				if payload, err = json.Marshal(e.Envelope()); err != nil {
+					return err
+				}
+			}
+			queued = append(queued, &model.Delivery{
+				SubscriptionID: sub.ID.Hex(),
+				OrganizationID: e.OrganizationID,
+				EventID:        e.ID.Hex(),
+				EventType:      e.Type,
+				Payload:        payload,
+			})
+		}
+	}
+
+	if err := r.deliveries.Create(ctx, queued...); err != nil {
+		return fmt.Errorf("queue webhook deliveries: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"term-service/internal/webhook/model"
+	"time"
+)
+
+// Headers set on every delivery. Receivers verify SignatureHeader against
+// Sign(secret, TimestampHeader, body) and should reject stale timestamps.
+const (
+	DeliveryHeader  = "X-Webhook-Delivery"
+	EventHeader     = "X-Webhook-Event"
+	TimestampHeader = "X-Webhook-Timestamp"
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+// Sign returns the signature of body sent at timestamp (unix seconds):
+// "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>".
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Sender makes one delivery attempt.
+type Sender interface {
+	// Send POSTs the delivery payload to the subscription URL and returns the
+	// response status (0 when there was no response). Anything but a 2xx
+	// answer is an error.
+	Send(ctx context.Context, sub *model.Subscription, d *model.Delivery) (int, error)
+}
+
+type httpSender struct {
+	client       *http.Client
+	destinations Destinations
+}
+
+// NewSender returns a Sender whose requests time out after timeout.
+// Redirects are not followed, so a subscription cannot bounce deliveries
+// to a URL that was never registered. Connections are only opened to
+// addresses destinations admits, and never through a proxy, so the
+// address checked is the one connected to.
+func NewSender(timeout time.Duration, destinations Destinations) Sender {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+		Control:   destinations.control,
+	}).DialContext
+
+	return &httpSender{
+		destinations: destinations,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   timeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *httpSender) Send(ctx context.Context, sub *model.Subscription, d *model.Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+	if !s.destinations.allowedScheme(req.URL.Scheme) {
+		return 0, fmt.Errorf("%w: %s URLs are not allowed", ErrBlockedDestination, req.URL.Scheme)
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "term-service-webhooks")
+	req.Header.Set(DeliveryHeader, d.ID.Hex())
+	req.Header.Set(EventHeader, d.EventType)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(sub.Secret, timestamp, d.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,40 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"term-service/internal/webhook/model"
+	"term-service/internal/webhook/service"
+	"term-service/pkg/objectid"
+)
+
+func TestSenderRefusesNonPublicAddresses(t *testing.T) {
+	received := false
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = true
+	}))
+	defer srv.Close()
+
+	// the subscription was accepted while its host looked public; at
+	// delivery time it resolves to loopback
+	sub := &model.Subscription{URL: srv.URL, Secret: "0123456789abcdef"}
+	d := &model.Delivery{ID: objectid.New(), EventType: "ping", Payload: []byte(`{}`)}
+
+	status, err := service.NewSender(time.Second, service.Destinations{}).Send(context.Background(), sub, d)
+	if !errors.Is(err, service.ErrBlockedDestination) || status != 0 {
+		t.Fatalf("Send = %d, %v, want %v", status, err, service.ErrBlockedDestination)
+	}
+	if received {
+		t.Fatal("delivery reached the loopback receiver")
+	}
+
+	sub.URL = "http://partner.example/hooks"
+	if _, err := service.NewSender(time.Second, service.Destinations{}).Send(context.Background(), sub, d); !errors.Is(err, service.ErrBlockedDestination) {
+		t.Fatalf("Send over http = %v, want %v", err, service.ErrBlockedDestination)
+	}
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"term-service/internal/webhook/dto/request"
+	"term-service/internal/webhook/dto/response"
+	"term-service/internal/webhook/mapper"
+	"term-service/internal/webhook/model"
+	"term-service/internal/webhook/repository"
+	"term-service/pkg/db"
+	"term-service/pkg/db/txn"
+	"term-service/pkg/objectid"
+	"term-service/pkg/outbox"
+	"time"
+)
+
+// PingEvent is the type of the test delivery sent by PingSubscription.
+const PingEvent = "ping"
+
+// ErrInvalidSubscription marks requests whose subscription settings are
+// unusable.
+var ErrInvalidSubscription = errors.New("invalid webhook subscription")
+
+// WebhookService manages an organization's webhook subscriptions. Every
+// method is scoped to organizationID, which the caller has already been
+// authorized for; subscriptions of other organizations are not found.
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, organizationID string, req request.CreateSubscriptionRequest) (*response.SubscriptionResDTO, error)
+	GetSubscriptions(ctx context.Context, organizationID string) ([]response.SubscriptionResDTO, error)
+	GetSubscription(ctx context.Context, organizationID, id string) (*response.SubscriptionResDTO, error)
+	UpdateSubscription(ctx context.Context, organizationID, id string, req request.UpdateSubscriptionRequest) (*response.SubscriptionResDTO, error)
+	DeleteSubscription(ctx context.Context, organizationID, id string) error
+	// PingSubscription sends a signed ping event right away, even to an
+	// inactive subscription, and logs it like any other delivery.
+	PingSubscription(ctx context.Context, organizationID, id string) (*response.DeliveryResDTO, error)
+	GetDeliveries(ctx context.Context, organizationID, id string, limit int) ([]response.DeliveryResDTO, error)
+}
+
+type webhookService struct {
+	subscriptions repository.SubscriptionRepository
+	deliveries    repository.DeliveryRepository
+	tx            txn.Transactor
+	sender        Sender
+	destinations  Destinations
+}
+
+func NewWebhookService(subscriptions repository.SubscriptionRepository, deliveries repository.DeliveryRepository, tx txn.Transactor, sender Sender, destinations Destinations) WebhookService {
+	return &webhookService{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		tx:            tx,
+		sender:        sender,
+		destinations:  destinations,
+	}
+}
+
+func (s *webhookService) CreateSubscription(ctx context.Context, organizationID string, req request.CreateSubscriptionRequest) (*response.SubscriptionResDTO, error) {
+	if err := s.destinations.CheckURL(ctx, req.URL); err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		var err error
+		if secret, err = newSecret(); err != nil {
+			return nil, err
+		}
+	}
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	sub, err := s.subscriptions.Create(ctx, &model.Subscription{
+		OrganizationID: organizationID,
+		URL:            req.URL,
+		Secret:         secret,
+		EventTypes:     req.EventTypes,
+		Active:         active,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create webhook subscription: %w", err)
+	}
+
+	res := mapper.MapSubscriptionToResDTO(sub)
+	res.Secret = secret
+	return &res, nil
+}
+
+func (s *webhookService) GetSubscriptions(ctx context.Context, organizationID string) ([]response.SubscriptionResDTO, error) {
+	subs, err := s.subscriptions.GetAllByOrgID(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook subscriptions by orgID failed: %w", err)
+	}
+	return mapper.MapSubscriptionListToResDTO(subs), nil
+}
+
+func (s *webhookService) GetSubscription(ctx context.Context, organizationID, id string) (*response.SubscriptionResDTO, error) {
+	sub, err := s.get(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	res := mapper.MapSubscriptionToResDTO(sub)
+	return &res, nil
+}
+
+func (s *webhookService) UpdateSubscription(ctx context.Context, organizationID, id string, req request.UpdateSubscriptionRequest) (*response.SubscriptionResDTO, error) {
+	if err := s.destinations.CheckURL(ctx, req.URL); err != nil {
+		return nil, err
+	}
+	sub, err := s.get(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	sub.URL = req.URL
+	sub.EventTypes = req.EventTypes
+	if req.Active != nil {
+		sub.Active = *req.Active
+	}
+	if req.Secret != "" {
+		sub.Secret = req.Secret
+	}
+	if err := s.subscriptions.Update(ctx, sub); err != nil {
+		return nil, fmt.Errorf("update webhook subscription %s: %w", id, err)
+	}
+
+	res := mapper.MapSubscriptionToResDTO(sub)
+	if req.Secret != "" {
+		res.Secret = req.Secret
+	}
+	return &res, nil
+}
+
+func (s *webhookService) DeleteSubscription(ctx context.Context, organizationID, id string) error {
+	if _, err := s.get(ctx, organizationID, id); err != nil {
+		return err
+	}
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.subscriptions.Delete(ctx, id); err != nil {
+			return fmt.Errorf("delete webhook subscription %s: %w", id, err)
+		}
+		return s.deliveries.DeleteBySubscriptionID(ctx, id)
+	})
+}
+
+func (s *webhookService) PingSubscription(ctx context.Context, organizationID, id string) (*response.DeliveryResDTO, error) {
+	sub, err := s.get(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	eventID := objectid.New()
+	data, _ := json.Marshal(map[string]string{"subscription_id": sub.ID.Hex()})
+	payload, err := json.Marshal(outbox.Envelope{
+		ID:             eventID.Hex(),
+		Type:           PingEvent,
+		Source:         outbox.Source,
+		AggregateType:  "webhook_subscription",
+		AggregateID:    sub.ID.Hex(),
+		OrganizationID: organizationID,
+		OccurredAt:     time.Now().UTC(),
+		Data:           data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d := &model.Delivery{
+		ID:             objectid.New(),
+		SubscriptionID: sub.ID.Hex(),
+		OrganizationID: organizationID,
+		EventID:        eventID.Hex(),
+		EventType:      PingEvent,
+		Payload:        payload,
+	}
+	// Pings are not retried; the outcome is logged as it is.
+	if err := attempt(ctx, s.sender, sub, d); err != nil {
+		d.Status = model.DeliveryFailed
+	}
+	if err := s.deliveries.Create(ctx, d); err != nil {
+		return nil, fmt.Errorf("log webhook ping: %w", err)
+	}
+
+	res := mapper.MapDeliveryToResDTO(d)
+	return &res, nil
+}
+
+func (s *webhookService) GetDeliveries(ctx context.Context, organizationID, id string, limit int) ([]response.DeliveryResDTO, error) {
+	if _, err := s.get(ctx, organizationID, id); err != nil {
+		return nil, err
+	}
+	deliveries, err := s.deliveries.GetAllBySubscriptionID(ctx, id, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook deliveries failed: %w", err)
+	}
+	return mapper.MapDeliveryListToResDTO(deliveries), nil
+}
+
+// get loads a subscription of organizationID; others are reported as not
+// found so their existence is not revealed.
+func (s *webhookService) get(ctx context.Context, organizationID, id string) (*model.Subscription, error) {
+	sub, err := s.subscriptions.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook subscription %s: %w", id, err)
+	}
+	if sub.OrganizationID != organizationID {
+		return nil, fmt.Errorf("webhook subscription %s in organization %s: %w", id, organizationID, db.ErrNotFound)
+	}
+	return sub, nil
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
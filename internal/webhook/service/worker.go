@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"term-service/internal/webhook/model"
+	"term-service/internal/webhook/repository"
+	"term-service/pkg/db"
+	"term-service/pkg/metrics"
+	"term-service/pkg/zap"
+	"time"
+)
+
+// WorkerConfig tunes delivery; zero fields take the defaults below.
+type WorkerConfig struct {
+	Interval    time.Duration // pause between polls when nothing is due
+	BatchSize   int
+	Lease       time.Duration // how long a claimed delivery is hidden from other replicas
+	MaxAttempts int           // after this many failures a delivery is marked failed
+	MinBackoff  time.Duration // wait after the first failure; doubles per attempt
+	MaxBackoff  time.Duration
+}
+
+func (c WorkerConfig) withDefaults() WorkerConfig {
+	if c.Interval <= 0 {
+		c.Interval = 2 * time.Second
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = 50
+	}
+	if c.Lease <= 0 {
+		c.Lease = time.Minute
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 10
+	}
+	if c.MinBackoff <= 0 {
+		c.MinBackoff = 10 * time.Second
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = time.Hour
+	}
+	return c
+}
+
+// Worker sends queued deliveries and schedules retries.
+type Worker struct {
+	subscriptions repository.SubscriptionRepository
+	deliveries    repository.DeliveryRepository
+	sender        Sender
+	cfg           WorkerConfig
+}
+
+func NewWorker(subscriptions repository.SubscriptionRepository, deliveries repository.DeliveryRepository, sender Sender, cfg WorkerConfig) *Worker {
+	return &Worker{subscriptions: subscriptions, deliveries: deliveries, sender: sender, cfg: cfg.withDefaults()}
+}
+
+// Run delivers until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	logger := zap.FromContext(ctx)
+	logger.Infow("webhook worker started")
+
+	for {
+		n, err := w.DeliverOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.Errorw("webhook delivery failed", "error", err)
+		}
+
+		wait := w.cfg.Interval
+		if n == w.cfg.BatchSize {
+			wait = 0
+		}
+		select {
+		case <-ctx.Done():
+			logger.Infow("webhook worker stopped")
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// DeliverOnce claims one batch of due deliveries and attempts each,
+// returning how many were claimed.
+func (w *Worker) DeliverOnce(ctx context.Context) (int, error) {
+	claimed, err := w.deliveries.Claim(ctx, w.cfg.BatchSize, w.cfg.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, d := range claimed {
+		sub, err := w.subscriptions.GetByID(ctx, d.SubscriptionID)
+		switch {
+		case errors.Is(err, db.ErrNotFound):
+			giveUp(d, "subscription was deleted")
+		case err != nil:
+			return len(claimed), err
+		case !sub.Active:
+			giveUp(d, "subscription is disabled")
+		default:
+			if err := attempt(ctx, w.sender, sub, d); err != nil {
+				w.scheduleRetry(d)
+				zap.FromContext(ctx).Warnw("webhook delivery attempt failed",
+					"delivery_id", d.ID.Hex(),
+					"subscription_id", d.SubscriptionID,
+					"attempt", d.Attempts,
+					"status", d.Status,
+					"error", err,
+				)
+			}
+		}
+
+		if err := w.deliveries.Update(ctx, d); err != nil {
+			return len(claimed), err
+		}
+	}
+	return len(claimed), nil
+}
+
+// scheduleRetry backs off after a failed attempt, or gives up once
+// MaxAttempts is reached.
+func (w *Worker) scheduleRetry(d *model.Delivery) {
+	if d.Attempts >= w.cfg.MaxAttempts {
+		d.Status = model.DeliveryFailed
+		return
+	}
+	delay := w.cfg.MinBackoff
+	for i := 1; i < d.Attempts && delay < w.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > w.cfg.MaxBackoff {
+		delay = w.cfg.MaxBackoff
+	}
+	d.NextAttemptAt = time.Now().Add(delay)
+}
+
+// attempt sends d once and records the outcome on it.
+func attempt(ctx context.Context, sender Sender, sub *model.Subscription, d *model.Delivery) error {
+	now := time.Now()
+	status, err := sender.Send(ctx, sub, d)
+	metrics.ObserveWebhookDelivery(err)
+
+	d.Attempts++
+	d.LastAttemptAt = &now
+	d.ResponseStatus = status
+	if err != nil {
+		d.LastError = truncate(err.Error())
+		return err
+	}
+	d.Status = model.DeliverySucceeded
+	d.LastError = ""
+	return nil
+}
+
+func giveUp(d *model.Delivery, reason string) {
+	d.Status = model.DeliveryFailed
+	d.LastError = reason
+}
+
+// truncate keeps LastError within its column size.
+func truncate(msg string) string {
+	const max = 1024
+	if len(msg) > max {
+		return msg[:max]
+	}
+	return msg
+}
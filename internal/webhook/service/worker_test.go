@@ -0,0 +1,127 @@
+package service_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"term-service/internal/webhook/model"
+	"term-service/internal/webhook/repository"
+	"term-service/internal/webhook/service"
+	"term-service/pkg/outbox"
+)
+
+// receiver is a webhook endpoint answering with the queued statuses, then
+// 200, and verifying every signature.
+type receiver struct {
+	t      *testing.T
+	secret string
+
+	mu       sync.Mutex
+	statuses []int
+	events   []string
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	ts, _ := strconv.ParseInt(r.Header.Get(service.TimestampHeader), 10, 64)
+	if got, want := r.Header.Get(service.SignatureHeader), service.Sign(rc.secret, ts, body); got != want {
+		rc.t.Errorf("signature = %q, want %q", got, want)
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.events = append(rc.events, r.Header.Get(service.EventHeader))
+	status := http.StatusOK
+	if len(rc.statuses) > 0 {
+		status, rc.statuses = rc.statuses[0], rc.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func setup(t *testing.T, statuses ...int) (*receiver, repository.SubscriptionRepository, repository.DeliveryRepository, outbox.Recorder, *model.Subscription) {
+	t.Helper()
+	rc := &receiver{t: t, secret: "0123456789abcdef", statuses: statuses}
+	srv := httptest.NewServer(rc)
+	t.Cleanup(srv.Close)
+
+	subs := repository.NewMemorySubscriptionRepository()
+	deliveries := repository.NewMemoryDeliveryRepository()
+	sub, err := subs.Create(context.Background(), &model.Subscription{
+		OrganizationID: "org-a",
+		URL:            srv.URL,
+		Secret:         rc.secret,
+		EventTypes:     []string{outbox.TermUpdated},
+		Active:         true,
+	})
+	if err != nil {
+		t.Fatalf("create subscription: %v", err)
+	}
+	recorder := service.NewFanoutRecorder(outbox.NewMemoryStore(), subs, deliveries)
+	return rc, subs, deliveries, recorder, sub
+}
+
+func record(t *testing.T, recorder outbox.Recorder, eventType, orgID string) {
+	t.Helper()
+	e, err := outbox.NewEvent(eventType, "term", "t1", orgID, map[string]string{"id": "t1"})
+	if err != nil {
+		t.Fatalf("NewEvent: %v", err)
+	}
+	if err := recorder.Record(context.Background(), e); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+}
+
+func TestWorkerRetriesUntilDelivered(t *testing.T) {
+	ctx := context.Background()
+	rc, subs, deliveries, recorder, sub := setup(t, http.StatusInternalServerError)
+
+	record(t, recorder, outbox.TermUpdated, "org-a")
+	record(t, recorder, outbox.TermCreated, "org-a") // not subscribed
+	record(t, recorder, outbox.TermUpdated, "org-b") // other organization
+
+	w := service.NewWorker(subs, deliveries, service.NewSender(time.Second, service.Destinations{AllowInsecure: true}), service.WorkerConfig{MinBackoff: time.Millisecond})
+	if n, err := w.DeliverOnce(ctx); err != nil || n != 1 {
+		t.Fatalf("DeliverOnce = %d, %v, want 1 delivery", n, err)
+	}
+	log, _ := deliveries.GetAllBySubscriptionID(ctx, sub.ID.Hex(), 10)
+	if len(log) != 1 || log[0].Status != model.DeliveryPending || log[0].Attempts != 1 || log[0].ResponseStatus != 500 {
+		t.Fatalf("after failed attempt: %+v", log)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	if n, err := w.DeliverOnce(ctx); err != nil || n != 1 {
+		t.Fatalf("retry DeliverOnce = %d, %v", n, err)
+	}
+	log, _ = deliveries.GetAllBySubscriptionID(ctx, sub.ID.Hex(), 10)
+	if log[0].Status != model.DeliverySucceeded || log[0].Attempts != 2 || log[0].ResponseStatus != 200 || log[0].LastError != "" {
+		t.Fatalf("after retry: %+v", log[0])
+	}
+	if len(rc.events) != 2 || rc.events[1] != outbox.TermUpdated {
+		t.Fatalf("receiver saw %v", rc.events)
+	}
+}
+
+func TestWorkerGivesUpAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	_, subs, deliveries, recorder, sub := setup(t, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+	record(t, recorder, outbox.TermUpdated, "org-a")
+
+	w := service.NewWorker(subs, deliveries, service.NewSender(time.Second, service.Destinations{AllowInsecure: true}), service.WorkerConfig{MaxAttempts: 2, MinBackoff: time.Millisecond})
+	for i := 0; i < 3; i++ {
+		if _, err := w.DeliverOnce(ctx); err != nil {
+			t.Fatalf("DeliverOnce: %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	log, _ := deliveries.GetAllBySubscriptionID(ctx, sub.ID.Hex(), 10)
+	if len(log) != 1 || log[0].Status != model.DeliveryFailed || log[0].Attempts != 2 {
+		t.Fatalf("delivery = %+v, want failed after 2 attempts", log)
+	}
+}
@@ -2,40 +2,46 @@ package config
 
 import (
 	"log"
-	"os"
-
-	"gopkg.in/yaml.v3"
+	"time"
 )
 
 type ServerConfig struct {
-	Port string `yaml:"port"`
+	Port string `mapstructure:"port" validate:"required,numeric"`
+	// TrustedProxies lists the addresses or CIDRs of the proxies whose
+	// X-Forwarded-For the client IP is taken from. Empty trusts none and
+	// uses the connection's address.
+	TrustedProxies []string `mapstructure:"trusted_proxies" validate:"dive,ip|cidr"`
 }
 
 type DatabaseConfig struct {
-	Active string        `yaml:"active"` // "mysql" or "mongodb"
-	MySQL  MySQLConfig   `yaml:"mysql"`
-	Mongo  MongoDBConfig `yaml:"mongodb"`
+	Active string        `mapstructure:"active" validate:"required,oneof=mysql mongodb"` // "mysql" or "mongodb"
+	MySQL  MySQLConfig   `mapstructure:"mysql"`
+	Mongo  MongoDBConfig `mapstructure:"mongodb"`
 }
 
 type MySQLConfig struct {
-	Host     string `yaml:"host"`
-	Port     string `yaml:"port"`
-	User     string `yaml:"user"`
-	Password string `yaml:"password"`
-	Name     string `yaml:"name"`
+	Host     string `mapstructure:"host"`
+	Port     string `mapstructure:"port"`
+	User     string `mapstructure:"user"`
+	Password string `mapstructure:"password"`
+	Name     string `mapstructure:"name"`
 }
 
 type MongoDBConfig struct {
-	Host     string `yaml:"host"`
-	Port     string `yaml:"port"`
-	User     string `yaml:"user"`
-	Password string `yaml:"password"`
-	Name     string `yaml:"name"`
+	Host        string `mapstructure:"host"`
+	Port        string `mapstructure:"port"`
+	User        string `mapstructure:"user"`
+	Password    string `mapstructure:"password"`
+	Name        string `mapstructure:"name"`
+	AutoMigrate bool   `mapstructure:"auto_migrate"` // apply pending migrations at boot
+	// AllowNonTransactional admits a standalone server, where a change and its
+	// event are not written atomically.
+	AllowNonTransactional bool `mapstructure:"allow_non_transactional"`
 }
 
 type ConsulConfig struct {
-	Host string `yaml:"host"`
-	Port int    `yaml:"port"`
+	Host string `mapstructure:"host" validate:"required"`
+	Port int    `mapstructure:"port" validate:"required,gt=0"`
 }
 
 type ZapConfig struct {
@@ -45,12 +51,125 @@ type ZapConfig struct {
 	Cores       struct {
 		Console struct {
 			Type     string `mapstructure:"type"`
-			Level    string `mapstructure:"level"`
-			Encoding string `mapstructure:"encoding"`
+			Level    string `mapstructure:"level" validate:"omitempty,oneof=debug info warn error"`
+			Encoding string `mapstructure:"encoding" validate:"omitempty,oneof=console json"`
 		} `mapstructure:"console"`
 	} `mapstructure:"cores"`
 }
 
+type TracingConfig struct {
+	Enabled     bool    `mapstructure:"enabled"`
+	Exporter    string  `mapstructure:"exporter" validate:"omitempty,oneof=otlp stdout"` // "otlp" or "stdout"
+	Endpoint    string  `mapstructure:"endpoint"`                                        // OTLP/HTTP collector, e.g. "otel-collector:4318"
+	Insecure    bool    `mapstructure:"insecure"`
+	SampleRatio float64 `mapstructure:"sample_ratio" validate:"gte=0,lte=1"`
+}
+
+// ServiceAuthConfig holds the shared key used to sign and verify the tokens
+// internal services send to /api/v1/gateway routes.
+type ServiceAuthConfig struct {
+	Secret         string        `mapstructure:"secret" validate:"omitempty,min=32"`
+	Issuer         string        `mapstructure:"issuer" validate:"required"`
+	TokenTTL       time.Duration `mapstructure:"token_ttl" validate:"gt=0"`
+	AllowedCallers []string      `mapstructure:"allowed_callers"` // empty allows any service holding the secret
+}
+
+// RateLimitConfig throttles clients and bounds uploads. A rule with zero
+// requests is disabled.
+type RateLimitConfig struct {
+	Enabled         bool        `mapstructure:"enabled"`
+	Store           string      `mapstructure:"store" validate:"omitempty,oneof=memory redis"` // "memory" or "redis"
+	Redis           RedisConfig `mapstructure:"redis"`
+	PerIP           RateRule    `mapstructure:"per_ip"`
+	PerUser         RateRule    `mapstructure:"per_user"` // per user whose token the user service accepted
+	PerOrganization RateRule    `mapstructure:"per_organization"`
+	MaxBodyBytes    int64       `mapstructure:"max_body_bytes" validate:"gte=0"`
+	MaxUploadItems  int         `mapstructure:"max_upload_items" validate:"gte=0"`
+}
+
+type RateRule struct {
+	Requests int           `mapstructure:"requests" validate:"gte=0"`
+	Window   time.Duration `mapstructure:"window" validate:"gte=0"`
+}
+
+type RedisConfig struct {
+	Addr     string `mapstructure:"addr"`
+	Password string `mapstructure:"password"`
+	DB       int    `mapstructure:"db"`
+}
+
+type IdempotencyConfig struct {
+	TTL time.Duration `mapstructure:"ttl" validate:"gte=0"` // how long Idempotency-Key responses are kept; 0 disables
+}
+
+// EventsConfig selects where term and holiday events recorded in the outbox
+// are delivered and how the dispatcher polls for them.
+type EventsConfig struct {
+	Enabled  bool                `mapstructure:"enabled"`
+	Sink     string              `mapstructure:"sink" validate:"omitempty,oneof=kafka nats webhook file memory"`
+	Kafka    KafkaSinkConfig     `mapstructure:"kafka"`
+	NATS     NATSSinkConfig      `mapstructure:"nats"`
+	Webhook  WebhookSinkConfig   `mapstructure:"webhook"`
+	File     FileSinkConfig      `mapstructure:"file"`
+	Dispatch EventDispatchConfig `mapstructure:"dispatch"`
+}
+
+type KafkaSinkConfig struct {
+	Brokers []string `mapstructure:"brokers"`
+	Topic   string   `mapstructure:"topic"`
+}
+
+type NATSSinkConfig struct {
+	URL           string `mapstructure:"url"`
+	SubjectPrefix string `mapstructure:"subject_prefix"` // events go to <prefix>.<type>
+}
+
+type WebhookSinkConfig struct {
+	URL     string        `mapstructure:"url"`
+	Token   string        `mapstructure:"token"` // sent as a bearer token when set
+	Timeout time.Duration `mapstructure:"timeout" validate:"gte=0"`
+}
+
+type FileSinkConfig struct {
+	Path string `mapstructure:"path"`
+}
+
+type EventDispatchConfig struct {
+	Interval   time.Duration `mapstructure:"interval" validate:"gte=0"`
+	BatchSize  int           `mapstructure:"batch_size" validate:"gte=0"`
+	Lease      time.Duration `mapstructure:"lease" validate:"gte=0"`
+	MaxBackoff time.Duration `mapstructure:"max_backoff" validate:"gte=0"`
+	Retention  time.Duration `mapstructure:"retention" validate:"gte=0"` // how long published events are kept
+}
+
+// WebhookConfig controls delivery to webhook subscriptions. Deliveries are
+// queued either way; Enabled runs the worker that sends them.
+type WebhookConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	Timeout     time.Duration `mapstructure:"timeout" validate:"gt=0"` // per request, also for pings
+	Interval    time.Duration `mapstructure:"interval" validate:"gte=0"`
+	BatchSize   int           `mapstructure:"batch_size" validate:"gte=0"`
+	Lease       time.Duration `mapstructure:"lease" validate:"gte=0"`
+	MaxAttempts int           `mapstructure:"max_attempts" validate:"gte=0"`
+	MinBackoff  time.Duration `mapstructure:"min_backoff" validate:"gte=0"`
+	MaxBackoff  time.Duration `mapstructure:"max_backoff" validate:"gte=0"`
+	// AllowInsecureURLs admits http subscription URLs and non-public
+	// addresses; for local development only.
+	AllowInsecureURLs bool `mapstructure:"allow_insecure_urls"`
+}
+
+// LifecycleConfig controls the scheduler that notifies terms starting soon,
+// terms that ended and holidays starting soon.
+type LifecycleConfig struct {
+	Enabled           bool          `mapstructure:"enabled"`
+	Interval          time.Duration `mapstructure:"interval" validate:"gte=0"`
+	Timezone          string        `mapstructure:"timezone"` // IANA name deciding which day "today" is
+	TermLeadDays      int           `mapstructure:"term_lead_days" validate:"gte=0"`
+	HolidayLeadDays   int           `mapstructure:"holiday_lead_days" validate:"gte=0"`
+	EndedLookbackDays int           `mapstructure:"ended_lookback_days" validate:"gte=0"`
+	Retention         time.Duration `mapstructure:"retention" validate:"gte=0"` // how long fired records are kept
+}
+
 type AppConfiguration struct {
 	Name        string    `mapstructure:"name"`
 	Version     string    `mapstructure:"version"`
@@ -78,27 +197,31 @@ type Registry struct {
 }
 
 type AppConfigStruct struct {
-	Server   ServerConfig     `yaml:"server"`
-	Database DatabaseConfig   `yaml:"database"`
-	Consul   ConsulConfig     `yaml:"consul"`
-	Zap      ZapConfig        `mapstructure:"zap"`
-	Registry Registry         `mapstructure:"registry" validate:"required"`
-	App      AppConfiguration `mapstructure:"app"`
+	Server    ServerConfig      `mapstructure:"server"`
+	Database  DatabaseConfig    `mapstructure:"database"`
+	Consul    ConsulConfig      `mapstructure:"consul"`
+	Tracing   TracingConfig     `mapstructure:"tracing"`
+	Service   ServiceAuthConfig `mapstructure:"service_auth"`
+	Limits    RateLimitConfig   `mapstructure:"rate_limit"`
+	Idem      IdempotencyConfig `mapstructure:"idempotency"`
+	Events    EventsConfig      `mapstructure:"events"`
+	Webhooks  WebhookConfig     `mapstructure:"webhooks"`
+	Lifecycle LifecycleConfig   `mapstructure:"lifecycle"`
+	Zap       ZapConfig         `mapstructure:"zap"`
+	Registry  Registry          `mapstructure:"registry" validate:"required"`
+	App       AppConfiguration  `mapstructure:"app"`
 }
 
 var AppConfig *AppConfigStruct
 
+// LoadConfig loads the configuration into AppConfig and exits the process
+// when it cannot be read or is invalid.
 func LoadConfig(filePath string) {
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		log.Fatalf("Failed to read config file: %v", err)
-	}
-
-	AppConfig = &AppConfigStruct{}
-	err = yaml.Unmarshal(data, AppConfig)
+	cfg, err := Load(filePath)
 	if err != nil {
-		log.Fatalf("Failed to unmarshal config: %v", err)
+		log.Fatalf("Invalid configuration:\n%v", err)
 	}
 
+	AppConfig = cfg
 	log.Println("Config loaded successfully")
 }
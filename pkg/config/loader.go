@@ -0,0 +1,265 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/viper"
+)
+
+// EnvPrefix prefixes every environment override, e.g.
+// TERM_SERVICE_DATABASE_MONGODB_HOST overrides database.mongodb.host.
+// Appending _FILE (TERM_SERVICE_DATABASE_MONGODB_PASSWORD_FILE) reads the
+// value from a file instead, for docker/kubernetes secrets.
+const EnvPrefix = "TERM_SERVICE"
+
+const fileSuffix = "_FILE"
+
+var envKeyReplacer = strings.NewReplacer(".", "_")
+
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("server.port", "8009")
+	v.SetDefault("database.active", "mongodb")
+	v.SetDefault("database.mysql.port", "3306")
+	v.SetDefault("database.mongodb.host", "localhost")
+	v.SetDefault("database.mongodb.port", "27017")
+	v.SetDefault("database.mongodb.name", "term_service")
+	v.SetDefault("database.mongodb.auto_migrate", true)
+	v.SetDefault("database.mongodb.allow_non_transactional", false)
+	v.SetDefault("consul.host", "localhost")
+	v.SetDefault("consul.port", 8500)
+	v.SetDefault("tracing.exporter", "otlp")
+	v.SetDefault("tracing.sample_ratio", 1.0)
+	v.SetDefault("service_auth.issuer", "term-service")
+	v.SetDefault("service_auth.token_ttl", "5m")
+	v.SetDefault("rate_limit.enabled", true)
+	v.SetDefault("rate_limit.store", "memory")
+	v.SetDefault("rate_limit.per_ip.requests", 300)
+	v.SetDefault("rate_limit.per_ip.window", "1m")
+	v.SetDefault("rate_limit.per_user.requests", 120)
+	v.SetDefault("rate_limit.per_user.window", "1m")
+	v.SetDefault("rate_limit.per_organization.requests", 600)
+	v.SetDefault("rate_limit.per_organization.window", "1m")
+	v.SetDefault("rate_limit.max_body_bytes", 1<<20)
+	v.SetDefault("rate_limit.max_upload_items", 200)
+	v.SetDefault("idempotency.ttl", "24h")
+	v.SetDefault("events.enabled", false)
+	v.SetDefault("events.sink", "file")
+	v.SetDefault("events.kafka.topic", "term-service.events")
+	v.SetDefault("events.nats.subject_prefix", "term-service")
+	v.SetDefault("events.webhook.timeout", "10s")
+	v.SetDefault("events.file.path", "events.jsonl")
+	v.SetDefault("events.dispatch.interval", "1s")
+	v.SetDefault("events.dispatch.batch_size", 100)
+	v.SetDefault("events.dispatch.lease", "1m")
+	v.SetDefault("events.dispatch.max_backoff", "10m")
+	v.SetDefault("events.dispatch.retention", "168h")
+	v.SetDefault("webhooks.enabled", true)
+	v.SetDefault("webhooks.timeout", "10s")
+	v.SetDefault("webhooks.interval", "2s")
+	v.SetDefault("webhooks.batch_size", 50)
+	v.SetDefault("webhooks.lease", "1m")
+	v.SetDefault("webhooks.max_attempts", 10)
+	v.SetDefault("webhooks.min_backoff", "10s")
+	v.SetDefault("webhooks.max_backoff", "1h")
+	v.SetDefault("webhooks.allow_insecure_urls", false)
+	v.SetDefault("lifecycle.enabled", true)
+	v.SetDefault("lifecycle.interval", "1h")
+	v.SetDefault("lifecycle.timezone", "UTC")
+	v.SetDefault("lifecycle.term_lead_days", 1)
+	v.SetDefault("lifecycle.holiday_lead_days", 3)
+	v.SetDefault("lifecycle.ended_lookback_days", 3)
+	v.SetDefault("lifecycle.retention", "2160h")
+	v.SetDefault("zap.cores.console.level", "info")
+	v.SetDefault("zap.cores.console.encoding", "console")
+	v.SetDefault("app.name", "term-service")
+	v.SetDefault("app.api.rest.setting.ignoreLogUrls", []string{"/healthz", "/readyz", "/metrics"})
+}
+
+// Load reads filePath (if it exists), applies defaults and environment
+// overrides, and validates the result. All validation failures are returned
+// together rather than one at a time.
+func Load(filePath string) (*AppConfigStruct, error) {
+	v := viper.New()
+	setDefaults(v)
+
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(envKeyReplacer)
+	v.AutomaticEnv()
+
+	if filePath != "" {
+		v.SetConfigFile(filePath)
+		if err := v.ReadInConfig(); err != nil {
+			if _, statErr := os.Stat(filePath); !os.IsNotExist(statErr) {
+				return nil, fmt.Errorf("read config file %s: %w", filePath, err)
+			}
+			// No file: run purely on defaults and environment.
+		}
+	}
+
+	if err := bindEnv(v); err != nil {
+		return nil, err
+	}
+
+	cfg := &AppConfigStruct{}
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("decode config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// bindEnv registers every config key with viper so environment overrides
+// apply even to keys that are absent from the YAML file, and resolves the
+// *_FILE secret variants.
+func bindEnv(v *viper.Viper) error {
+	var errs []error
+	for _, key := range configKeys(reflect.TypeOf(AppConfigStruct{}), "") {
+		if err := v.BindEnv(key); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		fileEnv := EnvName(key) + fileSuffix
+		path, ok := os.LookupEnv(fileEnv)
+		if !ok {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: read secret file: %w", fileEnv, err))
+			continue
+		}
+		v.Set(key, strings.TrimSpace(string(data)))
+	}
+	return errors.Join(errs...)
+}
+
+// EnvName returns the environment variable that overrides key.
+func EnvName(key string) string {
+	return EnvPrefix + "_" + strings.ToUpper(envKeyReplacer.Replace(key))
+}
+
+func configKeys(t reflect.Type, prefix string) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("mapstructure"), ",")[0]
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+
+		key := prefix + name
+		if f.Type.Kind() == reflect.Struct {
+			keys = append(keys, configKeys(f.Type, key+".")...)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Validate checks the struct tags and the rules that depend on other fields,
+// reporting every problem in one error.
+func (c *AppConfigStruct) Validate() error {
+	var errs []error
+
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(f reflect.StructField) string {
+		return strings.Split(f.Tag.Get("mapstructure"), ",")[0]
+	})
+
+	if err := validate.Struct(c); err != nil {
+		var fieldErrs validator.ValidationErrors
+		if !errors.As(err, &fieldErrs) {
+			return err
+		}
+		for _, fe := range fieldErrs {
+			errs = append(errs, fieldError(fe))
+		}
+	}
+
+	switch c.Database.Active {
+	case "mongodb":
+		errs = append(errs, requireFields("database.mongodb", map[string]string{
+			"host": c.Database.Mongo.Host,
+			"port": c.Database.Mongo.Port,
+			"name": c.Database.Mongo.Name,
+		})...)
+	case "mysql":
+		errs = append(errs, requireFields("database.mysql", map[string]string{
+			"host": c.Database.MySQL.Host,
+			"port": c.Database.MySQL.Port,
+			"user": c.Database.MySQL.User,
+			"name": c.Database.MySQL.Name,
+		})...)
+	}
+
+	if c.Tracing.Enabled && c.Tracing.Exporter == "otlp" && c.Tracing.Endpoint == "" {
+		errs = append(errs, fmt.Errorf("tracing.endpoint is required when the otlp exporter is enabled (%s)", EnvName("tracing.endpoint")))
+	}
+
+	if c.Limits.Enabled && c.Limits.Store == "redis" && c.Limits.Redis.Addr == "" {
+		errs = append(errs, fmt.Errorf("rate_limit.redis.addr is required when rate_limit.store is redis (%s)", EnvName("rate_limit.redis.addr")))
+	}
+
+	if c.Events.Enabled {
+		switch c.Events.Sink {
+		case "kafka":
+			if len(c.Events.Kafka.Brokers) == 0 {
+				errs = append(errs, fmt.Errorf("events.kafka.brokers is required when events.sink is kafka (%s)", EnvName("events.kafka.brokers")))
+			}
+		case "nats":
+			if c.Events.NATS.URL == "" {
+				errs = append(errs, fmt.Errorf("events.nats.url is required when events.sink is nats (%s)", EnvName("events.nats.url")))
+			}
+		case "webhook":
+			if c.Events.Webhook.URL == "" {
+				errs = append(errs, fmt.Errorf("events.webhook.url is required when events.sink is webhook (%s)", EnvName("events.webhook.url")))
+			}
+		}
+	}
+
+	if _, err := time.LoadLocation(c.Lifecycle.Timezone); err != nil {
+		errs = append(errs, fmt.Errorf("lifecycle.timezone %q is not a known time zone (%s)", c.Lifecycle.Timezone, EnvName("lifecycle.timezone")))
+	}
+
+	return errors.Join(errs...)
+}
+
+func requireFields(prefix string, fields map[string]string) []error {
+	var errs []error
+	for _, name := range []string{"host", "port", "user", "name"} {
+		val, ok := fields[name]
+		if ok && val == "" {
+			key := prefix + "." + name
+			errs = append(errs, fmt.Errorf("%s is required (%s)", key, EnvName(key)))
+		}
+	}
+	return errs
+}
+
+func fieldError(fe validator.FieldError) error {
+	// Namespace is "AppConfigStruct.database.active"; drop the type name.
+	key := fe.Namespace()
+	if i := strings.IndexByte(key, '.'); i >= 0 {
+		key = key[i+1:]
+	}
+
+	switch fe.Tag() {
+	case "required":
+		return fmt.Errorf("%s is required (%s)", key, EnvName(key))
+	case "oneof":
+		return fmt.Errorf("%s must be one of [%s], got %q", key, fe.Param(), fe.Value())
+	default:
+		return fmt.Errorf("%s is invalid: failed %s=%s, got %v", key, fe.Tag(), fe.Param(), fe.Value())
+	}
+}
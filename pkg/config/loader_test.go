@@ -0,0 +1,159 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"term-service/pkg/config"
+)
+
+// baseYAML is a valid configuration the cases start from.
+const baseYAML = `
+server:
+  port: "9000"
+database:
+  active: "mongodb"
+  mongodb:
+    host: "term_db"
+    port: "27017"
+    name: "term_service"
+registry:
+  host: "localhost"
+`
+
+func TestLoad(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "mongo_password")
+	if err := os.WriteFile(secret, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatalf("write secret: %v", err)
+	}
+
+	cases := []struct {
+		name  string
+		yaml  string // replaces baseYAML when set
+		env   map[string]string
+		check func(t *testing.T, cfg *config.AppConfigStruct)
+		// wantErrs are substrings the error must contain; empty wants none
+		wantErrs []string
+	}{
+		{
+			name: "file and defaults",
+			check: func(t *testing.T, cfg *config.AppConfigStruct) {
+				if cfg.Server.Port != "9000" || cfg.Database.Mongo.Host != "term_db" {
+					t.Errorf("file values = %q, %q", cfg.Server.Port, cfg.Database.Mongo.Host)
+				}
+				if cfg.Idem.TTL != 24*time.Hour || cfg.Lifecycle.Timezone != "UTC" {
+					t.Errorf("defaults = %v, %q", cfg.Idem.TTL, cfg.Lifecycle.Timezone)
+				}
+				if cfg.Database.Mongo.AllowNonTransactional {
+					t.Error("allow_non_transactional defaults to true, want false")
+				}
+			},
+		},
+		{
+			name: "env overrides file",
+			env:  map[string]string{"TERM_SERVICE_SERVER_PORT": "9100", "TERM_SERVICE_DATABASE_MONGODB_HOST": "mongo.internal"},
+			check: func(t *testing.T, cfg *config.AppConfigStruct) {
+				if cfg.Server.Port != "9100" || cfg.Database.Mongo.Host != "mongo.internal" {
+					t.Errorf("overridden values = %q, %q", cfg.Server.Port, cfg.Database.Mongo.Host)
+				}
+			},
+		},
+		{
+			name: "env sets keys absent from the file",
+			env:  map[string]string{"TERM_SERVICE_WEBHOOKS_ALLOW_INSECURE_URLS": "true", "TERM_SERVICE_RATE_LIMIT_PER_USER_REQUESTS": "7"},
+			check: func(t *testing.T, cfg *config.AppConfigStruct) {
+				if !cfg.Webhooks.AllowInsecureURLs || cfg.Limits.PerUser.Requests != 7 {
+					t.Errorf("env values = %v, %d", cfg.Webhooks.AllowInsecureURLs, cfg.Limits.PerUser.Requests)
+				}
+			},
+		},
+		{
+			name: "secret read from _FILE",
+			env:  map[string]string{"TERM_SERVICE_DATABASE_MONGODB_PASSWORD_FILE": secret},
+			check: func(t *testing.T, cfg *config.AppConfigStruct) {
+				if cfg.Database.Mongo.Password != "s3cret" {
+					t.Errorf("password = %q, want the trimmed file content", cfg.Database.Mongo.Password)
+				}
+			},
+		},
+		{
+			name:     "unreadable _FILE",
+			env:      map[string]string{"TERM_SERVICE_DATABASE_MONGODB_PASSWORD_FILE": filepath.Join(t.TempDir(), "missing")},
+			wantErrs: []string{"TERM_SERVICE_DATABASE_MONGODB_PASSWORD_FILE: read secret file"},
+		},
+		{
+			name: "missing required field",
+			yaml: strings.Replace(baseYAML, `host: "term_db"`, `host: ""`, 1),
+			wantErrs: []string{
+				"database.mongodb.host is required (TERM_SERVICE_DATABASE_MONGODB_HOST)",
+			},
+		},
+		{
+			name: "invalid values are reported together",
+			yaml: strings.Replace(strings.Replace(baseYAML, `active: "mongodb"`, `active: "postgres"`, 1), `port: "9000"`, `port: "http"`, 1),
+			env:  map[string]string{"TERM_SERVICE_LIFECYCLE_TIMEZONE": "Mars/Olympus"},
+			wantErrs: []string{
+				`database.active must be one of [mysql mongodb], got "postgres"`,
+				"server.port is invalid: failed numeric",
+				`lifecycle.timezone "Mars/Olympus" is not a known time zone`,
+			},
+		},
+		{
+			name:     "trusted proxies must be addresses",
+			env:      map[string]string{"TERM_SERVICE_SERVER_TRUSTED_PROXIES": "lb.internal"},
+			wantErrs: []string{"server.trusted_proxies[0] is invalid"},
+		},
+		{
+			name:     "redis store needs an address",
+			env:      map[string]string{"TERM_SERVICE_RATE_LIMIT_STORE": "redis"},
+			wantErrs: []string{"rate_limit.redis.addr is required when rate_limit.store is redis"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			content := tc.yaml
+			if content == "" {
+				content = baseYAML
+			}
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+
+			cfg, err := config.Load(path)
+			if len(tc.wantErrs) > 0 {
+				if err == nil {
+					t.Fatalf("Load succeeded, want errors %q", tc.wantErrs)
+				}
+				for _, want := range tc.wantErrs {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("Load error = %v, want it to contain %q", err, want)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			tc.check(t, cfg)
+		})
+	}
+}
+
+func TestLoadWithoutFile(t *testing.T) {
+	t.Setenv("TERM_SERVICE_REGISTRY_HOST", "localhost")
+	cfg, err := config.Load(filepath.Join(t.TempDir(), "absent.yaml"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Server.Port != "8009" || cfg.Database.Active != "mongodb" || cfg.Database.Mongo.Host != "localhost" {
+		t.Fatalf("config = %+v, want the defaults", cfg.Server)
+	}
+}
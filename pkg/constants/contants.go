@@ -118,8 +118,19 @@ var (
 	UserRoles      ContextKey = "roles"
 	CurrentUserKey ContextKey = "currentUser"
 	AppLanguage    ContextKey = "app_language"
+	RequestID      ContextKey = "request_id"
+	OrganizationID ContextKey = "organization_id"
+	ServiceName    ContextKey = "service_name"           // internal caller authenticated by a service token
+	TargetOrgID    ContextKey = "target_organization_id" // organization a request was authorized against
+	CallerID       ContextKey = "caller_id"              // user whose token the user service accepted
 )
 
+const RequestIDHeader = "X-Request-ID"
+
+// OrganizationIDHeader lets a super admin name the organization an admin
+// endpoint acts on.
+const OrganizationIDHeader = "X-Organization-ID"
+
 // MessageLangKey defines the key of message language
 type MessageLangKey string
 
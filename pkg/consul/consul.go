@@ -1,8 +1,8 @@
 package consul
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"math/rand"
 	"net/http"
 	"strconv"
@@ -30,10 +30,16 @@ type Client interface {
 	Deregister()
 }
 
+// ReadinessReporter decides whether this instance should receive traffic.
+type ReadinessReporter interface {
+	Ready(ctx context.Context) (bool, string)
+}
+
 type service struct {
-	client *api.Client
-	log    zap.Logger
-	cfg    *config.AppConfigStruct
+	client    *api.Client
+	log       zap.Logger
+	cfg       *config.AppConfigStruct
+	readiness ReadinessReporter
 }
 
 func NewConsulConn(log zap.Logger, cfg *config.AppConfigStruct) *service {
@@ -71,6 +77,11 @@ func (c *service) Connect() *api.Client {
 	return c.client
 }
 
+// SetReadiness feeds the dependency health into the consul TTL check.
+func (c *service) SetReadiness(r ReadinessReporter) {
+	c.readiness = r
+}
+
 func (c *service) Deregister() {
 	// Deregister service
 	err := c.client.Agent().ServiceDeregister(serviceId)
@@ -83,14 +94,33 @@ func (c *service) updateHealthCheck() {
 	ticker := time.NewTicker(time.Second * 5)
 
 	for {
-		err := c.client.Agent().UpdateTTL(checkId, "online", api.HealthPassing)
+		status, output := c.healthStatus()
+		err := c.client.Agent().UpdateTTL(checkId, output, status)
 		if err != nil {
-			log.Fatalf("Failed to check AgentHealthService: %v", err)
+			c.log.Errorf("Failed to update TTL check: %v", err)
 		}
 		<-ticker.C
 	}
 }
 
+// healthStatus maps readiness to a consul check status. Not-ready instances
+// are reported as warning rather than critical: they drop out of passing-only
+// queries but are not deregistered after DeregisterCriticalServiceAfter.
+func (c *service) healthStatus() (string, string) {
+	if c.readiness == nil {
+		return api.HealthWarning, "starting"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ttl/3)
+	defer cancel()
+
+	ready, output := c.readiness.Ready(ctx)
+	if !ready {
+		return api.HealthWarning, output
+	}
+	return api.HealthPassing, output
+}
+
 func (c *service) setupConsul() {
 	hostname := c.cfg.Registry.Host
 	port, _ := strconv.Atoi(c.cfg.Server.Port)
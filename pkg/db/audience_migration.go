@@ -0,0 +1,92 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"term-service/pkg/publish"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// migrateAudiences fills the audiences column of a table created before
+// audiences from its published_<channel>, publish_<channel>_at and
+// unpublish_<channel>_at columns, then drops those columns. Tables without
+// them are left alone, so it is safe to run on every start. It runs before
+// gorm's AutoMigrate, which may rebuild the table without the legacy data.
+func migrateAudiences(db *gorm.DB, table string, channels ...string) error {
+	m := db.Migrator()
+	if !m.HasTable(table) || !m.HasColumn(table, "published_"+channels[0]) {
+		return nil
+	}
+	if !m.HasColumn(table, "audiences") {
+		if err := db.Exec("ALTER TABLE ? ADD COLUMN audiences TEXT", clause.Table{Name: table}).Error; err != nil {
+			return fmt.Errorf("add %s.audiences: %w", table, err)
+		}
+	}
+
+	columns := []string{"id"}
+	for _, ch := range channels {
+		columns = append(columns, "published_"+ch, "publish_"+ch+"_at", "unpublish_"+ch+"_at")
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		rows, err := tx.Table(table).Select(columns).Rows()
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		audiences := make(map[string]publish.Audiences)
+		for rows.Next() {
+			var id string
+			published := make([]sql.NullBool, len(channels))
+			publishAt := make([]sql.NullTime, len(channels))
+			unpublishAt := make([]sql.NullTime, len(channels))
+			dest := []interface{}{&id}
+			for i := range channels {
+				dest = append(dest, &published[i], &publishAt[i], &unpublishAt[i])
+			}
+			if err := rows.Scan(dest...); err != nil {
+				return err
+			}
+
+			as := publish.Audiences{}
+			for i, ch := range channels {
+				as = as.WithFlag(ch, published[i].Bool, timePtr(publishAt[i]), timePtr(unpublishAt[i]))
+			}
+			audiences[id] = as
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for id, as := range audiences {
+			if err := tx.Table(table).Where("id = ?", id).Update("audiences", as).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("move %s publish flags into audiences: %w", table, err)
+	}
+
+	// Dropped outside the transaction: MySQL commits implicitly on DDL. A
+	// failure here leaves the legacy columns, and the next run converts
+	// them again to the same audiences.
+	for _, column := range columns[1:] {
+		if err := db.Exec("ALTER TABLE ? DROP COLUMN ?", clause.Table{Name: table}, clause.Column{Name: column}).Error; err != nil {
+			return fmt.Errorf("drop %s.%s: %w", table, column, err)
+		}
+	}
+	return nil
+}
+
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
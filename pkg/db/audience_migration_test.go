@@ -0,0 +1,125 @@
+package db_test
+
+import (
+	"testing"
+	"time"
+
+	holiday_model "term-service/internal/holiday/model"
+	"term-service/internal/term/model"
+	"term-service/pkg/db"
+	"term-service/pkg/publish"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestAutoMigrateMovesPublishFlagsIntoAudiences(t *testing.T) {
+	gdb, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqlDB, err := gdb.DB()
+	if err != nil {
+		t.Fatalf("sqlite handle: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	// The schema as it was before audiences.
+	if err := gdb.AutoMigrate(&legacyTerm{}, &legacyHoliday{}); err != nil {
+		t.Fatalf("create legacy tables: %v", err)
+	}
+	publishAt := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	if err := gdb.Create([]legacyTerm{
+		{ID: "t1", OrganizationID: "org-1", Title: "Spring", PublishedMobile: true, PublishedTeacher: true, PublishTeacherAt: &publishAt, Version: 1},
+		{ID: "t2", OrganizationID: "org-1", Title: "Draft", Version: 1},
+	}).Error; err != nil {
+		t.Fatalf("seed terms: %v", err)
+	}
+	if err := gdb.Create(&legacyHoliday{ID: "h1", OrganizationID: "org-1", Title: "Tet", PublishedDesktop: true, Version: 1}).Error; err != nil {
+		t.Fatalf("seed holidays: %v", err)
+	}
+
+	if err := db.AutoMigrate(gdb); err != nil {
+		t.Fatalf("AutoMigrate: %v", err)
+	}
+
+	var spring, draft model.Term
+	if err := gdb.First(&spring, "id = ?", "t1").Error; err != nil {
+		t.Fatalf("load t1: %v", err)
+	}
+	if err := gdb.First(&draft, "id = ?", "t2").Error; err != nil {
+		t.Fatalf("load t2: %v", err)
+	}
+	if len(spring.Audiences) != 2 || !spring.VisibleOn(publish.Mobile, publishAt) || spring.VisibleOn(publish.Teacher, publishAt.Add(-time.Hour)) ||
+		!spring.VisibleOn(publish.Teacher, publishAt) {
+		t.Errorf("t1 audiences = %+v, want mobile and teacher from %v", spring.Audiences, publishAt)
+	}
+	if len(draft.Audiences) != 0 {
+		t.Errorf("t2 audiences = %+v, want none", draft.Audiences)
+	}
+
+	var tet holiday_model.Holiday
+	if err := gdb.First(&tet, "id = ?", "h1").Error; err != nil {
+		t.Fatalf("load h1: %v", err)
+	}
+	if len(tet.Audiences) != 1 || tet.Audiences[0].Channel != publish.Desktop {
+		t.Errorf("h1 audiences = %+v, want desktop", tet.Audiences)
+	}
+
+	if gdb.Migrator().HasColumn("terms", "published_mobile") || gdb.Migrator().HasColumn("holidays", "publish_desktop_at") {
+		t.Error("legacy columns not dropped")
+	}
+	// A second run finds nothing left to convert.
+	if err := db.AutoMigrate(gdb); err != nil {
+		t.Fatalf("second AutoMigrate: %v", err)
+	}
+}
+
+// legacyTerm and legacyHoliday are the models before audiences.
+type legacyTerm struct {
+	ID                 string `gorm:"primaryKey;size:24"`
+	OrganizationID     string `gorm:"size:64"`
+	Title              string
+	Color              string `gorm:"size:32"`
+	PublishedMobile    bool
+	PublishedDesktop   bool
+	PublishedTeacher   bool
+	PublishedParent    bool
+	StartDate          time.Time
+	EndDate            time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	Version            int64 `gorm:"not null;default:1"`
+	PublishMobileAt    *time.Time
+	UnpublishMobileAt  *time.Time
+	PublishDesktopAt   *time.Time
+	UnpublishDesktopAt *time.Time
+	PublishTeacherAt   *time.Time
+	UnpublishTeacherAt *time.Time
+	PublishParentAt    *time.Time
+	UnpublishParentAt  *time.Time
+}
+
+func (legacyTerm) TableName() string { return "terms" }
+
+type legacyHoliday struct {
+	ID                 string `gorm:"primaryKey;size:24"`
+	OrganizationID     string `gorm:"size:64"`
+	Title              string
+	Color              string `gorm:"size:32"`
+	PublishedMobile    bool
+	PublishedDesktop   bool
+	StartDate          time.Time
+	EndDate            time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	Version            int64 `gorm:"not null;default:1"`
+	PublishMobileAt    *time.Time
+	UnpublishMobileAt  *time.Time
+	PublishDesktopAt   *time.Time
+	UnpublishDesktopAt *time.Time
+}
+
+func (legacyHoliday) TableName() string { return "holidays" }
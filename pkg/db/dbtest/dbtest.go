@@ -0,0 +1,106 @@
+// Package dbtest opens throwaway databases for repository tests.
+package dbtest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"term-service/pkg/db"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	// MongoURIEnv enables the Mongo backend tests, e.g. mongodb://localhost:27017
+	MongoURIEnv = "TERM_SERVICE_TEST_MONGO_URI"
+	// MySQLDSNEnv enables the MySQL backend tests, e.g. root:pw@tcp(localhost:3306)/term_test?parseTime=true&clientFoundRows=true
+	MySQLDSNEnv = "TERM_SERVICE_TEST_MYSQL_DSN"
+)
+
+// NewSQLite returns an empty, migrated in-memory SQLite database.
+func NewSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	gdb, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+
+	// Every connection to :memory: is a separate database.
+	sqlDB, err := gdb.DB()
+	if err != nil {
+		t.Fatalf("sqlite handle: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	if err := db.AutoMigrate(gdb); err != nil {
+		t.Fatalf("migrate sqlite: %v", err)
+	}
+	return gdb
+}
+
+// NewMySQL returns a migrated MySQL database with empty tables, or skips the
+// test when MySQLDSNEnv is not set.
+func NewMySQL(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv(MySQLDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s not set", MySQLDSNEnv)
+	}
+
+	gdb, err := gorm.Open(mysql.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open mysql: %v", err)
+	}
+	if err := db.AutoMigrate(gdb); err != nil {
+		t.Fatalf("migrate mysql: %v", err)
+	}
+
+	tables, err := gdb.Migrator().GetTables()
+	if err != nil {
+		t.Fatalf("list mysql tables: %v", err)
+	}
+	for _, table := range tables {
+		if err := gdb.Exec("DELETE FROM " + table).Error; err != nil {
+			t.Fatalf("truncate %s: %v", table, err)
+		}
+	}
+	return gdb
+}
+
+// NewMongo returns a fresh Mongo database that is dropped after the test,
+// or skips the test when MongoURIEnv is not set.
+func NewMongo(t *testing.T) *mongo.Database {
+	t.Helper()
+
+	uri := os.Getenv(MongoURIEnv)
+	if uri == "" {
+		t.Skipf("%s not set", MongoURIEnv)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatalf("connect mongo: %v", err)
+	}
+
+	database := client.Database(fmt.Sprintf("term_service_test_%d", time.Now().UnixNano()))
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = database.Drop(ctx)
+		_ = client.Disconnect(ctx)
+	})
+	return database
+}
@@ -0,0 +1,11 @@
+package db
+
+import "errors"
+
+// ErrNotFound is returned by every repository backend when the requested
+// record does not exist, so services don't depend on mongo or gorm errors.
+var ErrNotFound = errors.New("record not found")
+
+// ErrVersionConflict is returned by conditional updates when the record was
+// changed since the caller read it.
+var ErrVersionConflict = errors.New("record was modified by another request")
@@ -0,0 +1,264 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	holiday_model "term-service/internal/holiday/model"
+	term_model "term-service/internal/term/model"
+	"term-service/pkg/revision"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// All lists every migration in order. Append new ones; never edit or
+// renumber a migration that has shipped.
+var All = []Migration{
+	{
+		Version:     1,
+		Description: "create term indexes for organization scoped queries",
+		Up: createIndexes("terms", []mongo.IndexModel{
+			index("org_start_date", bson.D{{Key: "organization_id", Value: 1}, {Key: "start_date", Value: 1}}),
+			index("org_mobile_created", bson.D{{Key: "organization_id", Value: 1}, {Key: "published_mobile", Value: 1}, {Key: "created_at", Value: 1}}),
+			index("org_desktop_created", bson.D{{Key: "organization_id", Value: 1}, {Key: "published_desktop", Value: 1}, {Key: "created_at", Value: 1}}),
+			index("org_teacher_created", bson.D{{Key: "organization_id", Value: 1}, {Key: "published_teacher", Value: 1}, {Key: "created_at", Value: 1}}),
+			index("start_end_date", bson.D{{Key: "start_date", Value: 1}, {Key: "end_date", Value: 1}}),
+		}),
+	},
+	{
+		Version:     2,
+		Description: "create holiday indexes for organization scoped queries",
+		Up: createIndexes("holidays", []mongo.IndexModel{
+			index("org_start_date", bson.D{{Key: "organization_id", Value: 1}, {Key: "start_date", Value: 1}}),
+			index("org_mobile_created", bson.D{{Key: "organization_id", Value: 1}, {Key: "published_mobile", Value: 1}, {Key: "created_at", Value: 1}}),
+		}),
+	},
+	{
+		Version:     3,
+		Description: "backfill publish flags and updated_at on documents created before they existed",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			terms := db.Collection("terms")
+			for _, field := range []string{"published_mobile", "published_desktop", "published_teacher", "published_parent"} {
+				if _, err := terms.UpdateMany(ctx,
+					bson.M{field: bson.M{"$exists": false}},
+					bson.M{"$set": bson.M{field: false}},
+				); err != nil {
+					return err
+				}
+			}
+
+			holidays := db.Collection("holidays")
+			for _, field := range []string{"published_mobile", "published_desktop"} {
+				if _, err := holidays.UpdateMany(ctx,
+					bson.M{field: bson.M{"$exists": false}},
+					bson.M{"$set": bson.M{field: false}},
+				); err != nil {
+					return err
+				}
+			}
+
+			for _, coll := range []*mongo.Collection{terms, holidays} {
+				if _, err := coll.UpdateMany(ctx,
+					bson.M{"updated_at": bson.M{"$exists": false}},
+					mongo.Pipeline{{{Key: "$set", Value: bson.M{"updated_at": "$created_at"}}}},
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:     4,
+		Description: "expire idempotency records",
+		Up: createIndexes("idempotency_records", []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "expires_at", Value: 1}},
+				Options: options.Index().SetName("expires_at_ttl").SetExpireAfterSeconds(0),
+			},
+		}),
+	},
+	{
+		Version:     5,
+		Description: "start optimistic concurrency versions at 1",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			for _, name := range []string{"terms", "holidays"} {
+				if _, err := db.Collection(name).UpdateMany(ctx,
+					bson.M{"version": bson.M{"$exists": false}},
+					bson.M{"$set": bson.M{"version": 1}},
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:     6,
+		Description: "index pending outbox events",
+		Up: createIndexes("outbox_events", []mongo.IndexModel{
+			index("published_next_attempt", bson.D{{Key: "published_at", Value: 1}, {Key: "next_attempt_at", Value: 1}}),
+		}),
+	},
+	{
+		Version:     7,
+		Description: "index webhook subscriptions and deliveries",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := createIndexes("webhook_subscriptions", []mongo.IndexModel{
+				index("organization_id", bson.D{{Key: "organization_id", Value: 1}}),
+			})(ctx, db); err != nil {
+				return err
+			}
+			return createIndexes("webhook_deliveries", []mongo.IndexModel{
+				index("status_next_attempt", bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}),
+				index("subscription_created", bson.D{{Key: "subscription_id", Value: 1}, {Key: "created_at", Value: -1}}),
+			})(ctx, db)
+		},
+	},
+	{
+		Version:     8,
+		Description: "index fired lifecycle transitions by fired_at",
+		Up: createIndexes("lifecycle_fired", []mongo.IndexModel{
+			index("fired_at", bson.D{{Key: "fired_at", Value: 1}}),
+		}),
+	},
+	{
+		Version:     9,
+		Description: "move published flags and windows into audiences",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			terms, holidays := db.Collection("terms"), db.Collection("holidays")
+			if err := moveToAudiences(ctx, terms, "mobile", "desktop", "teacher", "parent"); err != nil {
+				return err
+			}
+			if err := moveToAudiences(ctx, holidays, "mobile", "desktop"); err != nil {
+				return err
+			}
+
+			if err := dropIndexes(ctx, terms, "org_mobile_created", "org_desktop_created", "org_teacher_created"); err != nil {
+				return err
+			}
+			if err := dropIndexes(ctx, holidays, "org_mobile_created"); err != nil {
+				return err
+			}
+			audience := index("org_audience_created", bson.D{{Key: "organization_id", Value: 1}, {Key: "audiences.channel", Value: 1}, {Key: "created_at", Value: 1}})
+			if err := createIndexes("terms", []mongo.IndexModel{audience})(ctx, db); err != nil {
+				return err
+			}
+			return createIndexes("holidays", []mongo.IndexModel{audience})(ctx, db)
+		},
+	},
+	{
+		Version:     10,
+		Description: "index calendar drafts by organization",
+		Up: createIndexes("calendar_drafts", []mongo.IndexModel{
+			index("org_created", bson.D{{Key: "organization_id", Value: 1}, {Key: "created_at", Value: 1}}),
+		}),
+	},
+	{
+		Version:     11,
+		Description: "index revisions and give existing terms and holidays a baseline revision",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			err := createIndexes("revisions", []mongo.IndexModel{
+				index("type_org_recorded", bson.D{{Key: "entity_type", Value: 1}, {Key: "organization_id", Value: 1}, {Key: "recorded_at", Value: 1}}),
+				index("type_entity_recorded", bson.D{{Key: "entity_type", Value: 1}, {Key: "entity_id", Value: 1}, {Key: "recorded_at", Value: -1}}),
+			})(ctx, db)
+			if err != nil {
+				return err
+			}
+			return baselineRevisions(ctx, db)
+		},
+	},
+}
+
+// baselineRevisions writes the baseline revision of every term and holiday.
+func baselineRevisions(ctx context.Context, db *mongo.Database) error {
+	var terms []*term_model.Term
+	if err := findAll(ctx, db.Collection("terms"), &terms); err != nil {
+		return err
+	}
+	var holidays []*holiday_model.Holiday
+	if err := findAll(ctx, db.Collection("holidays"), &holidays); err != nil {
+		return err
+	}
+
+	docs := make([]interface{}, 0, len(terms)+len(holidays))
+	for _, t := range terms {
+		r, err := revision.Baseline(revision.Term, t.ID.Hex(), t.OrganizationID, t.Version, t.UpdatedAt, t)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, r)
+	}
+	for _, h := range holidays {
+		r, err := revision.Baseline(revision.Holiday, h.ID.Hex(), h.OrganizationID, h.Version, h.UpdatedAt, h)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, r)
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	_, err := db.Collection("revisions").InsertMany(ctx, docs)
+	return err
+}
+
+func findAll(ctx context.Context, coll *mongo.Collection, results interface{}) error {
+	cursor, err := coll.Find(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+	return cursor.All(ctx, results)
+}
+
+// moveToAudiences builds the audiences array of documents that predate it
+// from their published_<channel>, publish_<channel>_at and
+// unpublish_<channel>_at fields, and removes those fields.
+func moveToAudiences(ctx context.Context, coll *mongo.Collection, channels ...string) error {
+	perChannel := bson.A{}
+	legacy := bson.A{}
+	for _, ch := range channels {
+		perChannel = append(perChannel, bson.M{"$cond": bson.A{
+			bson.M{"$eq": bson.A{"$published_" + ch, true}},
+			bson.A{bson.M{"channel": ch, "publish_at": "$publish_" + ch + "_at", "unpublish_at": "$unpublish_" + ch + "_at"}},
+			bson.A{},
+		}})
+		legacy = append(legacy, "published_"+ch, "publish_"+ch+"_at", "unpublish_"+ch+"_at")
+	}
+
+	_, err := coll.UpdateMany(ctx, bson.M{"audiences": bson.M{"$exists": false}}, mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"audiences": bson.M{"$concatArrays": perChannel}}}},
+		{{Key: "$unset", Value: legacy}},
+	})
+	return err
+}
+
+// dropIndexes drops the named indexes, skipping ones that do not exist.
+func dropIndexes(ctx context.Context, coll *mongo.Collection, names ...string) error {
+	for _, name := range names {
+		_, err := coll.Indexes().DropOne(ctx, name)
+		var cmdErr mongo.CommandError
+		if errors.As(err, &cmdErr) && cmdErr.Code == indexNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexNotFound is the server error code for dropping an unknown index.
+const indexNotFound = 27
+
+func index(name string, keys bson.D) mongo.IndexModel {
+	return mongo.IndexModel{Keys: keys, Options: options.Index().SetName(name)}
+}
+
+func createIndexes(collection string, models []mongo.IndexModel) func(context.Context, *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		_, err := db.Collection(collection).Indexes().CreateMany(ctx, models)
+		return err
+	}
+}
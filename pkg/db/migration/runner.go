@@ -0,0 +1,187 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"term-service/pkg/zap"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	migrationsCollection = "schema_migrations"
+	lockCollection       = "schema_migrations_lock"
+	lockID               = "migration"
+	lockStaleAfter       = 10 * time.Minute
+	lockPollInterval     = 2 * time.Second
+)
+
+// Migration is one versioned, forward-only schema or data change.
+// Up must be idempotent: a crash after Up but before the version is recorded
+// re-runs it on the next start.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+}
+
+// Applied is the record stored in schema_migrations for each applied version.
+type Applied struct {
+	Version     int       `bson:"_id" json:"version"`
+	Description string    `bson:"description" json:"description"`
+	AppliedAt   time.Time `bson:"applied_at" json:"applied_at"`
+	DurationMs  int64     `bson:"duration_ms" json:"duration_ms"`
+}
+
+type Runner struct {
+	db         *mongo.Database
+	migrations []Migration
+	log        zap.Logger
+}
+
+func NewRunner(db *mongo.Database, migrations []Migration, log zap.Logger) *Runner {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	return &Runner{db: db, migrations: sorted, log: log}
+}
+
+// Run applies every pending migration in version order. Concurrent replicas
+// starting together serialise on a lock document; the loser waits for the
+// winner and then finds nothing left to apply.
+func (r *Runner) Run(ctx context.Context) error {
+	if err := r.checkVersions(); err != nil {
+		return err
+	}
+
+	release, err := r.acquireLock(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	applied, err := r.appliedVersions(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range r.migrations {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+
+		r.log.Infof("applying migration %d: %s", m.Version, m.Description)
+		start := time.Now()
+		if err := m.Up(ctx, r.db); err != nil {
+			return fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Description, err)
+		}
+
+		record := Applied{
+			Version:     m.Version,
+			Description: m.Description,
+			AppliedAt:   time.Now(),
+			DurationMs:  time.Since(start).Milliseconds(),
+		}
+		if _, err := r.db.Collection(migrationsCollection).InsertOne(ctx, record); err != nil {
+			return fmt.Errorf("record migration %d failed: %w", m.Version, err)
+		}
+		r.log.Infof("applied migration %d in %dms", m.Version, record.DurationMs)
+	}
+
+	return nil
+}
+
+// Status returns the applied migrations and the versions still pending.
+func (r *Runner) Status(ctx context.Context) ([]Applied, []Migration, error) {
+	cur, err := r.db.Collection(migrationsCollection).Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cur.Close(ctx)
+
+	var applied []Applied
+	if err := cur.All(ctx, &applied); err != nil {
+		return nil, nil, err
+	}
+
+	done := make(map[int]struct{}, len(applied))
+	for _, a := range applied {
+		done[a.Version] = struct{}{}
+	}
+
+	var pending []Migration
+	for _, m := range r.migrations {
+		if _, ok := done[m.Version]; !ok {
+			pending = append(pending, m)
+		}
+	}
+	return applied, pending, nil
+}
+
+func (r *Runner) checkVersions() error {
+	seen := make(map[int]struct{}, len(r.migrations))
+	for _, m := range r.migrations {
+		if m.Version <= 0 {
+			return fmt.Errorf("migration %q has invalid version %d", m.Description, m.Version)
+		}
+		if _, dup := seen[m.Version]; dup {
+			return fmt.Errorf("duplicate migration version %d", m.Version)
+		}
+		seen[m.Version] = struct{}{}
+	}
+	return nil
+}
+
+func (r *Runner) appliedVersions(ctx context.Context) (map[int]struct{}, error) {
+	applied, _, err := r.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load applied migrations failed: %w", err)
+	}
+
+	versions := make(map[int]struct{}, len(applied))
+	for _, a := range applied {
+		versions[a.Version] = struct{}{}
+	}
+	return versions, nil
+}
+
+func (r *Runner) acquireLock(ctx context.Context) (func(), error) {
+	locks := r.db.Collection(lockCollection)
+
+	for {
+		_, err := locks.InsertOne(ctx, bson.M{"_id": lockID, "locked_at": time.Now()})
+		if err == nil {
+			return func() {
+				if _, err := locks.DeleteOne(context.Background(), bson.M{"_id": lockID}); err != nil {
+					r.log.Errorf("release migration lock failed: %v", err)
+				}
+			}, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return nil, fmt.Errorf("acquire migration lock failed: %w", err)
+		}
+
+		// Break locks left behind by a crashed instance.
+		res, err := locks.DeleteOne(ctx, bson.M{"_id": lockID, "locked_at": bson.M{"$lt": time.Now().Add(-lockStaleAfter)}})
+		if err != nil {
+			return nil, fmt.Errorf("check stale migration lock failed: %w", err)
+		}
+		if res.DeletedCount > 0 {
+			r.log.Warnf("removed stale migration lock")
+			continue
+		}
+
+		r.log.Infof("waiting for another instance to finish migrations")
+		select {
+		case <-ctx.Done():
+			return nil, errors.Join(errors.New("waiting for migration lock"), ctx.Err())
+		case <-time.After(lockPollInterval):
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 )
 
 var MongoClient *mongo.Client
+var MongoDatabase *mongo.Database
 var TermCollection *mongo.Collection
 var HolidayCollection *mongo.Collection
 
@@ -46,7 +47,8 @@ func ConnectMongoDB() {
 		log.Fatalf("MongoDB ping failed: %v", err)
 	}
 
-	TermCollection = MongoClient.Database(d.Name).Collection("terms")
-	HolidayCollection = MongoClient.Database(d.Name).Collection("holidays")
+	MongoDatabase = MongoClient.Database(d.Name)
+	TermCollection = MongoDatabase.Collection("terms")
+	HolidayCollection = MongoDatabase.Collection("holidays")
 	log.Println("Connected to MongoDB and loaded 'terms' and 'holidays' collection")
 }
@@ -3,8 +3,15 @@ package db
 import (
 	"fmt"
 	"log"
+	draft_model "term-service/internal/draft/model"
+	holiday_model "term-service/internal/holiday/model"
+	lifecycle_model "term-service/internal/lifecycle/model"
 	"term-service/internal/term/model"
+	webhook_model "term-service/internal/webhook/model"
 	"term-service/pkg/config"
+	"term-service/pkg/idempotency"
+	"term-service/pkg/outbox"
+	"term-service/pkg/revision"
 
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -14,7 +21,9 @@ var MySqlDB *gorm.DB
 
 func ConnectMySQL() {
 	d := config.AppConfig.Database.MySQL
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true",
+	// clientFoundRows makes UPDATE report matched rather than changed rows,
+	// so an update that rewrites identical values isn't mistaken for "not found".
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&clientFoundRows=true",
 		d.User, d.Password, d.Host, d.Port, d.Name)
 
 	var err error
@@ -23,10 +32,25 @@ func ConnectMySQL() {
 		log.Fatalf("Failed to connect to MySQL: %v", err)
 	}
 
-	err = MySqlDB.AutoMigrate(&model.Term{})
-	if err != nil {
+	if err := AutoMigrate(MySqlDB); err != nil {
 		log.Fatalf("AutoMigrate failed: %v", err)
 	}
 
 	log.Println("Connected to MySQL and migrated schema")
 }
+
+// AutoMigrate creates or updates the SQL schema for every model.
+func AutoMigrate(db *gorm.DB) error {
+	if err := migrateAudiences(db, "terms", "mobile", "desktop", "teacher", "parent"); err != nil {
+		return err
+	}
+	if err := migrateAudiences(db, "holidays", "mobile", "desktop"); err != nil {
+		return err
+	}
+	err := db.AutoMigrate(&model.Term{}, &holiday_model.Holiday{}, &idempotency.Record{}, &outbox.Event{},
+		&webhook_model.Subscription{}, &webhook_model.Delivery{}, &lifecycle_model.FiredTransition{}, &draft_model.Draft{}, &revision.Revision{})
+	if err != nil {
+		return err
+	}
+	return backfillRevisions(db)
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"fmt"
+	holiday_model "term-service/internal/holiday/model"
+	"term-service/internal/term/model"
+	"term-service/pkg/revision"
+
+	"gorm.io/gorm"
+)
+
+// backfillRevisions gives terms and holidays that predate revisions their
+// baseline revision, so as_of reads find them. It does nothing once the
+// revisions table has rows.
+func backfillRevisions(db *gorm.DB) error {
+	var recorded int64
+	if err := db.Model(&revision.Revision{}).Count(&recorded).Error; err != nil {
+		return err
+	}
+	if recorded > 0 {
+		return nil
+	}
+
+	var terms []*model.Term
+	if err := db.Find(&terms).Error; err != nil {
+		return err
+	}
+	var holidays []*holiday_model.Holiday
+	if err := db.Find(&holidays).Error; err != nil {
+		return err
+	}
+
+	revs := make([]*revision.Revision, 0, len(terms)+len(holidays))
+	for _, t := range terms {
+		r, err := revision.Baseline(revision.Term, t.ID.Hex(), t.OrganizationID, t.Version, t.UpdatedAt, t)
+		if err != nil {
+			return err
+		}
+		revs = append(revs, r)
+	}
+	for _, h := range holidays {
+		r, err := revision.Baseline(revision.Holiday, h.ID.Hex(), h.OrganizationID, h.Version, h.UpdatedAt, h)
+		if err != nil {
+			return err
+		}
+		revs = append(revs, r)
+	}
+	if len(revs) == 0 {
+		return nil
+	}
+	if err := db.CreateInBatches(revs, 500).Error; err != nil {
+		return fmt.Errorf("backfill revisions: %w", err)
+	}
+	return nil
+}
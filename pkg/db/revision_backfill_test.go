@@ -0,0 +1,61 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	holiday_model "term-service/internal/holiday/model"
+	"term-service/internal/term/model"
+	"term-service/pkg/db"
+	"term-service/pkg/db/dbtest"
+	"term-service/pkg/objectid"
+	"term-service/pkg/revision"
+)
+
+func TestAutoMigrateBackfillsBaselineRevisions(t *testing.T) {
+	gdb := dbtest.NewSQLite(t)
+	created := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	updated := created.AddDate(0, 1, 0)
+
+	term := &model.Term{ID: objectid.New(), OrganizationID: "org-1", Title: "Spring", Version: 3, CreatedAt: created, UpdatedAt: updated}
+	holiday := &holiday_model.Holiday{ID: objectid.New(), OrganizationID: "org-1", Title: "Tet", Version: 1, CreatedAt: created, UpdatedAt: created}
+	if err := gdb.Create(term).Error; err != nil {
+		t.Fatalf("seed term: %v", err)
+	}
+	if err := gdb.Create(holiday).Error; err != nil {
+		t.Fatalf("seed holiday: %v", err)
+	}
+
+	// Migrating again backfills once.
+	for i := 0; i < 2; i++ {
+		if err := db.AutoMigrate(gdb); err != nil {
+			t.Fatalf("AutoMigrate: %v", err)
+		}
+	}
+
+	var n int64
+	if err := gdb.Model(&revision.Revision{}).Count(&n).Error; err != nil || n != 2 {
+		t.Fatalf("revisions = %d, %v; want 2", n, err)
+	}
+
+	store := revision.NewGormStore(gdb)
+	rev, err := store.Get(context.Background(), revision.Term, term.ID.Hex(), updated)
+	if err != nil || rev == nil {
+		t.Fatalf("term revision at its last update = %+v, %v", rev, err)
+	}
+	var got model.Term
+	if err := rev.Decode(&got); err != nil || got.Title != "Spring" || rev.Version != 3 {
+		t.Fatalf("term baseline = %+v (version %d), %v", got, rev.Version, err)
+	}
+	// the state before version 3 was never kept
+	if rev, _ := store.Get(context.Background(), revision.Term, term.ID.Hex(), updated.Add(-time.Second)); rev != nil {
+		t.Fatalf("term revision before its last update = %+v, want none", rev)
+	}
+	if rev, _ := store.Get(context.Background(), revision.Holiday, holiday.ID.Hex(), created); rev == nil {
+		t.Fatal("never updated holiday has no revision at creation")
+	}
+	if rev, _ := store.Get(context.Background(), revision.Holiday, holiday.ID.Hex(), created.Add(-time.Second)); rev != nil {
+		t.Fatalf("holiday revision before creation = %+v", rev)
+	}
+}
@@ -0,0 +1,188 @@
+// Package txn runs a unit of work in one database transaction. Repositories
+// pick the transaction up from the context, so services can combine writes
+// (e.g. a term and its outbox event) without knowing the backend.
+package txn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"term-service/pkg/zap"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+)
+
+// Transactor runs fn atomically. Repository calls made with the ctx passed
+// to fn take part in the transaction.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// ---- after commit ----
+
+type hooksKey struct{}
+
+type hooks struct {
+	fns []func(ctx context.Context) error
+}
+
+// AfterCommit runs fn once the outermost transaction in ctx has committed,
+// for side effects that cannot be rolled back, such as calls to other
+// services. It is dropped when the transaction rolls back, and a retried
+// transaction keeps only the hooks of the attempt that committed. Outside a
+// transaction fn runs at once. A failing fn is logged: the data is already
+// committed.
+func AfterCommit(ctx context.Context, fn func(ctx context.Context) error) {
+	if h, ok := ctx.Value(hooksKey{}).(*hooks); ok {
+		h.fns = append(h.fns, fn)
+		return
+	}
+	runHook(ctx, fn)
+}
+
+// withHooks runs the transaction started by begin, giving each attempt of fn
+// a fresh set of hooks, and runs the hooks of the committed attempt.
+func withHooks(ctx context.Context, begin func(fn func(ctx context.Context) error) error, fn func(ctx context.Context) error) error {
+	h := &hooks{}
+	err := begin(func(txCtx context.Context) error {
+		h.fns = nil
+		return fn(context.WithValue(txCtx, hooksKey{}, h))
+	})
+	if err != nil {
+		return err
+	}
+	for _, hook := range h.fns {
+		runHook(ctx, hook)
+	}
+	return nil
+}
+
+func runHook(ctx context.Context, fn func(ctx context.Context) error) {
+	if err := fn(ctx); err != nil {
+		zap.FromContext(ctx).Errorw("after-commit hook failed", "error", err.Error())
+	}
+}
+
+func joined(ctx context.Context) bool {
+	_, ok := ctx.Value(hooksKey{}).(*hooks)
+	return ok
+}
+
+// ---- gorm ----
+
+type gormTxKey struct{}
+
+type gormTransactor struct {
+	db *gorm.DB
+}
+
+func NewGormTransactor(db *gorm.DB) Transactor {
+	return &gormTransactor{db: db}
+}
+
+func (t *gormTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(gormTxKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+	return withHooks(ctx, func(fn func(ctx context.Context) error) error {
+		return Gorm(ctx, t.db).Transaction(func(tx *gorm.DB) error {
+			return fn(context.WithValue(ctx, gormTxKey{}, tx))
+		})
+	}, fn)
+}
+
+// Gorm returns the transaction in ctx, or db when there is none, bound to
+// ctx. GORM repositories use it for every query.
+func Gorm(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(gormTxKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// ---- mongo ----
+
+type mongoTransactor struct {
+	client    *mongo.Client
+	supported bool
+}
+
+// ErrTransactionsUnavailable is returned for a standalone Mongo server when
+// running without transactions was not allowed.
+var ErrTransactionsUnavailable = errors.New("mongo transactions unavailable (standalone server)")
+
+// NewMongoTransactor uses multi-document transactions when the server is a
+// replica set or sharded cluster. It asks the server once, at startup, and
+// fails if it cannot. A standalone server cannot run transactions: it is
+// refused with ErrTransactionsUnavailable unless allowNonTransactional is
+// set, in which case the work runs without one and an error is logged.
+func NewMongoTransactor(ctx context.Context, client *mongo.Client, allowNonTransactional bool) (Transactor, error) {
+	supported, err := detectTransactions(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("detect mongo transaction support: %w", err)
+	}
+	if !supported {
+		if !allowNonTransactional {
+			return nil, ErrTransactionsUnavailable
+		}
+		zap.FromContext(ctx).Errorw("mongo transactions unavailable (standalone server); writes and their events are not atomic")
+	}
+	return &mongoTransactor{client: client, supported: supported}, nil
+}
+
+func (t *mongoTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	if !t.supported {
+		if joined(ctx) {
+			return fn(ctx)
+		}
+		return withHooks(ctx, func(fn func(ctx context.Context) error) error { return fn(ctx) }, fn)
+	}
+
+	session, err := t.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	return withHooks(ctx, func(fn func(ctx context.Context) error) error {
+		_, err := session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+			return nil, fn(sc)
+		})
+		return err
+	}, fn)
+}
+
+func detectTransactions(ctx context.Context, client *mongo.Client) (bool, error) {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return false, err
+	}
+	return hello.SetName != "" || hello.Msg == "isdbgrid", nil
+}
+
+// ---- memory ----
+
+type noopTransactor struct{}
+
+// NewNoopTransactor runs fn directly, for the in-memory repositories. Its
+// after-commit hooks run once fn succeeds.
+func NewNoopTransactor() Transactor {
+	return noopTransactor{}
+}
+
+func (noopTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if joined(ctx) {
+		return fn(ctx)
+	}
+	return withHooks(ctx, func(fn func(ctx context.Context) error) error { return fn(ctx) }, fn)
+}
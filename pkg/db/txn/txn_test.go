@@ -0,0 +1,133 @@
+package txn_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"term-service/internal/term/model"
+	"term-service/internal/term/repository"
+	"term-service/pkg/db/dbtest"
+	"term-service/pkg/db/txn"
+	"term-service/pkg/outbox"
+)
+
+func TestGormTransactorRollsBackEveryWrite(t *testing.T) {
+	ctx := context.Background()
+	gdb := dbtest.NewSQLite(t)
+	terms := repository.NewGormTermRepository(gdb)
+	events := outbox.NewGormStore(gdb)
+	tx := txn.NewGormTransactor(gdb)
+
+	write := func(title string, fail error) error {
+		return tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			term, err := terms.Create(ctx, &model.Term{OrganizationID: "org-a", Title: title, StartDate: time.Now(), EndDate: time.Now()})
+			if err != nil {
+				return err
+			}
+			e, err := outbox.NewEvent(outbox.TermCreated, "term", term.ID.Hex(), term.OrganizationID, title)
+			if err != nil {
+				return err
+			}
+			if err := events.Record(ctx, e); err != nil {
+				return err
+			}
+			return fail
+		})
+	}
+
+	boom := errors.New("boom")
+	if err := write("rolled back", boom); !errors.Is(err, boom) {
+		t.Fatalf("WithinTransaction = %v, want %v", err, boom)
+	}
+	if err := write("committed", nil); err != nil {
+		t.Fatalf("WithinTransaction: %v", err)
+	}
+
+	stored, err := terms.GetAllByOrgID(ctx, "org-a")
+	if err != nil {
+		t.Fatalf("GetAllByOrgID: %v", err)
+	}
+	if len(stored) != 1 || stored[0].Title != "committed" {
+		t.Fatalf("terms = %+v, want only the committed one", stored)
+	}
+	pending, err := events.Claim(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if len(pending) != 1 || pending[0].AggregateID != stored[0].ID.Hex() {
+		t.Fatalf("outbox = %+v, want the committed term's event only", pending)
+	}
+}
+
+func TestNewMongoTransactorChecksAtStartup(t *testing.T) {
+	client := dbtest.NewMongo(t).Client()
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := txn.NewMongoTransactor(cancelled, client, true); err == nil {
+		t.Fatal("NewMongoTransactor with a cancelled context succeeded, want the check to fail")
+	}
+
+	tx, err := txn.NewMongoTransactor(context.Background(), client, false)
+	if errors.Is(err, txn.ErrTransactionsUnavailable) {
+		// A standalone test server is only admitted with the opt-in.
+		tx, err = txn.NewMongoTransactor(context.Background(), client, true)
+	}
+	if err != nil {
+		t.Fatalf("NewMongoTransactor: %v", err)
+	}
+	if err := tx.WithinTransaction(context.Background(), func(ctx context.Context) error { return nil }); err != nil {
+		t.Fatalf("WithinTransaction: %v", err)
+	}
+}
+
+func TestAfterCommit(t *testing.T) {
+	ctx := context.Background()
+	transactors := map[string]txn.Transactor{
+		"gorm": txn.NewGormTransactor(dbtest.NewSQLite(t)),
+		"noop": txn.NewNoopTransactor(),
+	}
+	for name, tx := range transactors {
+		t.Run(name, func(t *testing.T) {
+			var ran []string
+			hook := func(name string) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					ran = append(ran, name)
+					return nil
+				}
+			}
+
+			boom := errors.New("boom")
+			err := tx.WithinTransaction(ctx, func(ctx context.Context) error {
+				txn.AfterCommit(ctx, hook("rolled back"))
+				return boom
+			})
+			if !errors.Is(err, boom) || len(ran) != 0 {
+				t.Fatalf("rolled back: err = %v, hooks run = %v", err, ran)
+			}
+
+			err = tx.WithinTransaction(ctx, func(ctx context.Context) error {
+				txn.AfterCommit(ctx, hook("outer"))
+				// a nested transaction joins the outer one, hooks included
+				err := tx.WithinTransaction(ctx, func(ctx context.Context) error {
+					txn.AfterCommit(ctx, hook("nested"))
+					return nil
+				})
+				if len(ran) != 0 {
+					t.Fatalf("hooks ran before the outer commit: %v", ran)
+				}
+				return err
+			})
+			if err != nil || len(ran) != 2 || ran[0] != "outer" || ran[1] != "nested" {
+				t.Fatalf("committed: err = %v, hooks run = %v", err, ran)
+			}
+
+			txn.AfterCommit(ctx, hook("no transaction"))
+			if len(ran) != 3 {
+				t.Fatalf("hook outside a transaction did not run at once: %v", ran)
+			}
+		})
+	}
+}
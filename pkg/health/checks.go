@@ -0,0 +1,77 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/consul/api"
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+)
+
+// MongoCheck pings the Mongo deployment.
+func MongoCheck(client *mongo.Client) CheckFunc {
+	return func(ctx context.Context) error {
+		if client == nil {
+			return fmt.Errorf("mongo client not initialized")
+		}
+		return client.Ping(ctx, nil)
+	}
+}
+
+// ConsulCheck verifies the consul agent is reachable and the cluster has a leader.
+func ConsulCheck(client *api.Client) CheckFunc {
+	return func(ctx context.Context) error {
+		leader, err := client.Status().LeaderWithQueryOptions((&api.QueryOptions{}).WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("consul unreachable: %w", err)
+		}
+		if leader == "" {
+			return fmt.Errorf("consul has no leader")
+		}
+		return nil
+	}
+}
+
+// ServiceCheck verifies that a dependency has at least one passing instance
+// in consul and that the instance accepts TCP connections.
+func ServiceCheck(client *api.Client, serviceName string) CheckFunc {
+	return func(ctx context.Context) error {
+		opts := (&api.QueryOptions{}).WithContext(ctx)
+		entries, _, err := client.Health().Service(serviceName, "", true, opts)
+		if err != nil {
+			return fmt.Errorf("lookup %s failed: %w", serviceName, err)
+		}
+		if len(entries) == 0 {
+			return fmt.Errorf("%s has no passing instance", serviceName)
+		}
+
+		svc := entries[0].Service
+		addr := svc.Address
+		if addr == "" {
+			addr = entries[0].Node.Address
+		}
+
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(addr, fmt.Sprint(svc.Port)))
+		if err != nil {
+			return fmt.Errorf("%s unreachable: %w", serviceName, err)
+		}
+		return conn.Close()
+	}
+}
+
+// SQLCheck pings a GORM database.
+func SQLCheck(db *gorm.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		if db == nil {
+			return fmt.Errorf("sql database not initialized")
+		}
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
@@ -0,0 +1,29 @@
+package health
+
+import (
+	"net/http"
+	"term-service/pkg/helper"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes exposes /healthz (liveness) and /readyz (readiness).
+func RegisterRoutes(r *gin.Engine, checker *Checker) {
+	r.GET("/healthz", func(c *gin.Context) {
+		helper.SendSuccess(c, http.StatusOK, "alive", nil)
+	})
+
+	r.GET("/readyz", func(c *gin.Context) {
+		report := checker.Check(c.Request.Context())
+		if !report.Ready() {
+			c.JSON(http.StatusServiceUnavailable, helper.APIResponse{
+				StatusCode: http.StatusServiceUnavailable,
+				Message:    "not ready",
+				Data:       report,
+			})
+			return
+		}
+
+		helper.SendSuccess(c, http.StatusOK, "ready", report)
+	})
+}
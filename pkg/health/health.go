@@ -0,0 +1,155 @@
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"term-service/pkg/metrics"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// CheckFunc probes a single dependency and returns an error when it is unusable.
+type CheckFunc func(ctx context.Context) error
+
+type CheckResult struct {
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Duration  int64     `json:"duration_ms"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+type Report struct {
+	Status string        `json:"status"`
+	Checks []CheckResult `json:"checks"`
+}
+
+// Ready reports whether every registered dependency is up.
+func (r Report) Ready() bool {
+	return r.Status == StatusUp
+}
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// Checker runs the registered dependency checks and caches their results,
+// so that probes from kubernetes, consul and the TTL loop don't hammer
+// Mongo or the other services.
+type Checker struct {
+	mu       sync.Mutex
+	checks   []check
+	cache    map[string]CheckResult
+	cacheTTL time.Duration
+	timeout  time.Duration
+}
+
+func NewChecker(cacheTTL, timeout time.Duration) *Checker {
+	return &Checker{
+		cache:    make(map[string]CheckResult),
+		cacheTTL: cacheTTL,
+		timeout:  timeout,
+	}
+}
+
+// Register adds a named dependency check.
+func (c *Checker) Register(name string, fn CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, check{name: name, fn: fn})
+}
+
+// Check returns the state of every dependency, running only the checks
+// whose cached result has expired.
+func (c *Checker) Check(ctx context.Context) Report {
+	c.mu.Lock()
+	checks := make([]check, len(c.checks))
+	copy(checks, c.checks)
+	c.mu.Unlock()
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, chk := range checks {
+		if cached, ok := c.cached(chk.name); ok {
+			results[i] = cached
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, chk check) {
+			defer wg.Done()
+			results[i] = c.run(ctx, chk)
+		}(i, chk)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	report := Report{Status: StatusUp, Checks: results}
+	for _, res := range results {
+		if res.Status != StatusUp {
+			report.Status = StatusDown
+			break
+		}
+	}
+	return report
+}
+
+// Ready implements consul.ReadinessReporter.
+func (c *Checker) Ready(ctx context.Context) (bool, string) {
+	report := c.Check(ctx)
+	if report.Ready() {
+		return true, "online"
+	}
+
+	for _, res := range report.Checks {
+		if res.Status != StatusUp {
+			return false, res.Name + ": " + res.Error
+		}
+	}
+	return false, "not ready"
+}
+
+func (c *Checker) cached(name string) (CheckResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res, ok := c.cache[name]
+	if !ok || time.Since(res.CheckedAt) > c.cacheTTL {
+		metrics.CacheMiss("health")
+		return CheckResult{}, false
+	}
+	metrics.CacheHit("health")
+	return res, true
+}
+
+func (c *Checker) run(ctx context.Context, chk check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := chk.fn(ctx)
+
+	res := CheckResult{
+		Name:      chk.name,
+		Status:    StatusUp,
+		Duration:  time.Since(start).Milliseconds(),
+		CheckedAt: time.Now(),
+	}
+	if err != nil {
+		res.Status = StatusDown
+		res.Error = err.Error()
+	}
+
+	c.mu.Lock()
+	c.cache[chk.name] = res
+	c.mu.Unlock()
+
+	return res
+}
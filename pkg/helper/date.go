@@ -2,7 +2,10 @@ package helper
 
 import (
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/gin-gonic/gin"
 )
 
 func FormatDate(t time.Time) string {
@@ -19,3 +22,21 @@ func FormatRemainingDays(days int) string {
 	}
 	return fmt.Sprintf("%d", days)
 }
+
+// AsOf parses the as_of query parameter: an RFC 3339 timestamp, or a date
+// meaning the end of that day in UTC. It returns the zero time when the
+// parameter is absent.
+func AsOf(c *gin.Context) (time.Time, error) {
+	value := strings.TrimSpace(c.Query("as_of"))
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	day, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid as_of %q: expected an RFC 3339 timestamp or a YYYY-MM-DD date", value)
+	}
+	return day.Add(24*time.Hour - time.Nanosecond), nil
+}
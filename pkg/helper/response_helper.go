@@ -1,7 +1,9 @@
 package helper
 
 import (
-	"term-service/logger"
+	"errors"
+	"net/http"
+	"term-service/pkg/zap"
 
 	"github.com/gin-gonic/gin"
 )
@@ -11,6 +13,23 @@ const (
 	ErrInvalidRequest   = "ERR_INVALID_REQUEST"
 	ErrNotFount         = "ERR_NOT_FOUND"
 	ErrInternal         = "ERR_INTERNAL"
+	ErrUnauthorized     = "ERR_UNAUTHORIZED"
+	ErrForbidden        = "ERR_FORBIDDEN"
+	ErrTooManyRequests  = "ERR_TOO_MANY_REQUESTS"
+	ErrPayloadTooLarge  = "ERR_PAYLOAD_TOO_LARGE"
+
+	ErrIdempotencyKeyReused = "ERR_IDEMPOTENCY_KEY_REUSED"
+	ErrRequestInProgress    = "ERR_REQUEST_IN_PROGRESS"
+
+	ErrConflict             = "ERR_CONFLICT"
+	ErrPreconditionRequired = "ERR_PRECONDITION_REQUIRED"
+)
+
+// ErrNotAuthenticated and ErrAccessDenied are the failures SendServiceError
+// reports as 401 and 403; errors that wrap them get those statuses.
+var (
+	ErrNotAuthenticated = errors.New("unauthenticated")
+	ErrAccessDenied     = errors.New("access denied")
 )
 
 type APIResponse struct {
@@ -38,12 +57,12 @@ func SendError(c *gin.Context, statusCode int, err error, errorCode string) {
 	}
 
 	// Ghi log lỗi
-	logger.WriteLogEx("error", errMsg, map[string]interface{}{
-		"status_code": statusCode,
-		"error_code":  errorCode,
-		"path":        c.Request.URL.Path,
-		"method":      c.Request.Method,
-	})
+	zap.FromContext(c.Request.Context()).Errorw(errMsg,
+		"status_code", statusCode,
+		"error_code", errorCode,
+		"path", c.Request.URL.Path,
+		"method", c.Request.Method,
+	)
 
 	c.JSON(statusCode, APIResponse{
 		StatusCode: statusCode,
@@ -52,3 +71,33 @@ func SendError(c *gin.Context, statusCode int, err error, errorCode string) {
 		ErrorCode:  errorCode,
 	})
 }
+
+// SendServiceError sends err with the status it maps to: 401/403 for
+// ErrNotAuthenticated/ErrAccessDenied, 409 (with the current record) for version conflicts, 428 for a
+// missing version, fallbackStatus for anything else.
+func SendServiceError(c *gin.Context, fallbackStatus int, err error, errorCode string) {
+	var conflict *ConflictError
+	switch {
+	case errors.As(err, &conflict):
+		zap.FromContext(c.Request.Context()).Infow(err.Error(),
+			"status_code", http.StatusConflict,
+			"path", c.Request.URL.Path,
+			"method", c.Request.Method,
+		)
+		c.JSON(http.StatusConflict, APIResponse{
+			StatusCode: http.StatusConflict,
+			Error:      err.Error(),
+			Message:    err.Error(),
+			ErrorCode:  ErrConflict,
+			Data:       conflict.Current,
+		})
+	case errors.Is(err, ErrVersionRequired):
+		SendError(c, http.StatusPreconditionRequired, err, ErrPreconditionRequired)
+	case errors.Is(err, ErrNotAuthenticated):
+		SendError(c, http.StatusUnauthorized, err, ErrUnauthorized)
+	case errors.Is(err, ErrAccessDenied):
+		SendError(c, http.StatusForbidden, err, ErrForbidden)
+	default:
+		SendError(c, fallbackStatus, err, errorCode)
+	}
+}
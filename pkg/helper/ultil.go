@@ -8,6 +8,8 @@ import (
 	"term-service/internal/holiday/dto/request"
 	term_request "term-service/internal/term/dto/request"
 	"term-service/pkg/constants"
+
+	"github.com/gin-gonic/gin"
 )
 
 func CurrentUserFromCtx(ctx context.Context) (*dto.CurrentUser, bool) {
@@ -53,6 +55,15 @@ func BuildTermMessagesUpload(termID string, req term_request.UploadTermRequest,
 	}
 }
 
+// RequestedOrganizationID reads an explicit target organization from the
+// X-Organization-ID header or the organization_id query parameter.
+func RequestedOrganizationID(c *gin.Context) string {
+	if orgID := strings.TrimSpace(c.GetHeader(constants.OrganizationIDHeader)); orgID != "" {
+		return orgID
+	}
+	return strings.TrimSpace(c.Query("organization_id"))
+}
+
 func GetHeaders(ctx context.Context) map[string]string {
 	headers := make(map[string]string)
 
@@ -60,6 +71,10 @@ func GetHeaders(ctx context.Context) map[string]string {
 		headers["X-App-Language"] = strconv.Itoa(int(lang))
 	}
 
+	if requestID, ok := ctx.Value(constants.RequestID).(string); ok && requestID != "" {
+		headers[constants.RequestIDHeader] = requestID
+	}
+
 	return headers
 }
 
@@ -0,0 +1,52 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrVersionRequired is returned when an update names neither the version it
+// was based on nor an If-Match header.
+var ErrVersionRequired = errors.New("version (or If-Match) is required to update a record")
+
+// ConflictError reports an update based on a stale version. Current is the
+// stored record, sent back with the 409 so the client can merge.
+type ConflictError struct {
+	Err     error
+	Current interface{}
+}
+
+func (e *ConflictError) Error() string { return e.Err.Error() }
+
+func (e *ConflictError) Unwrap() error { return e.Err }
+
+// IfMatchVersion parses the If-Match header ("3", W/"3" or 3). It returns 0
+// when the header is absent.
+func IfMatchVersion(c *gin.Context) (int64, error) {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" {
+		return 0, nil
+	}
+	tag := strings.Trim(strings.TrimPrefix(value, "W/"), `"`)
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("invalid If-Match %q: expected a record version", value)
+	}
+	return version, nil
+}
+
+// ExpectedVersion picks the version an update must match: the item's own,
+// else the If-Match version when the request updates a single record.
+func ExpectedVersion(itemVersion, ifMatch int64, updates int) (int64, error) {
+	if itemVersion > 0 {
+		return itemVersion, nil
+	}
+	if ifMatch > 0 && updates == 1 {
+		return ifMatch, nil
+	}
+	return 0, ErrVersionRequired
+}
@@ -0,0 +1,64 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore returns a Store backed by the idempotency_records table.
+// Expired rows are deleted when their key is reserved again.
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+func (s *gormStore) Reserve(ctx context.Context, rec *Record) (*Record, error) {
+	rec.CreatedAt = time.Now()
+
+	for attempt := 0; attempt < 2; attempt++ {
+		insertErr := s.db.WithContext(ctx).Create(rec).Error
+		if insertErr == nil {
+			return nil, nil
+		}
+
+		// Duplicate key errors differ per dialect; a failed insert whose
+		// key exists is treated as one.
+		var existing Record
+		err := s.db.WithContext(ctx).Where("idempotency_key = ?", rec.Key).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, insertErr
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !existing.expired(time.Now()) {
+			return &existing, nil
+		}
+		if err := s.db.WithContext(ctx).
+			Where("idempotency_key = ? AND expires_at = ?", rec.Key, existing.ExpiresAt).
+			Delete(&Record{}).Error; err != nil {
+			return nil, err
+		}
+	}
+	return nil, errors.New("idempotency key is contended, retry")
+}
+
+func (s *gormStore) Complete(ctx context.Context, key string, expiresAt time.Time, statusCode int, contentType string, body []byte) error {
+	return s.db.WithContext(ctx).Model(&Record{}).Where("idempotency_key = ?", key).Updates(map[string]interface{}{
+		"completed":    true,
+		"expires_at":   expiresAt,
+		"status_code":  statusCode,
+		"content_type": contentType,
+		"body":         body,
+	}).Error
+}
+
+func (s *gormStore) Release(ctx context.Context, key string) error {
+	return s.db.WithContext(ctx).Where("idempotency_key = ?", key).Delete(&Record{}).Error
+}
@@ -0,0 +1,52 @@
+// Package idempotency makes retried uploads safe: the first request carrying
+// an Idempotency-Key runs, and retries with the same key replay its response.
+package idempotency
+
+import (
+	"context"
+	"time"
+)
+
+// Header is the request header clients put the key in.
+const Header = "Idempotency-Key"
+
+// MaxKeyLength bounds client supplied keys.
+const MaxKeyLength = 255
+
+// Lease is how long a key stays reserved while its first request runs. A
+// reservation left behind by a crashed instance frees up once it lapses,
+// instead of answering 409 until the record's ttl.
+const Lease = 2 * time.Minute
+
+// Record is one key and, once the request finished, its response. In
+// progress records expire after their lease, completed ones after the ttl.
+type Record struct {
+	Key         string    `bson:"_id" gorm:"column:idempotency_key;primaryKey;size:512"`
+	Fingerprint string    `bson:"fingerprint" gorm:"size:64"` // sha256 of the request body
+	Completed   bool      `bson:"completed"`
+	StatusCode  int       `bson:"status_code"`
+	ContentType string    `bson:"content_type" gorm:"size:128"`
+	Body        []byte    `bson:"body"`
+	CreatedAt   time.Time `bson:"created_at"`
+	ExpiresAt   time.Time `bson:"expires_at" gorm:"index:idx_idempotency_expires"`
+}
+
+func (Record) TableName() string {
+	return "idempotency_records"
+}
+
+func (r *Record) expired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// Store keeps records until they expire.
+type Store interface {
+	// Reserve saves rec as in progress. If an unexpired record already holds
+	// the key it is returned instead and rec is not saved.
+	Reserve(ctx context.Context, rec *Record) (*Record, error)
+	// Complete stores the response of a reserved key and keeps it until
+	// expiresAt.
+	Complete(ctx context.Context, key string, expiresAt time.Time, statusCode int, contentType string, body []byte) error
+	// Release drops a reservation so the request can be retried.
+	Release(ctx context.Context, key string) error
+}
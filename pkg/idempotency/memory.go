@@ -0,0 +1,62 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[string]Record
+}
+
+// NewMemoryStore returns a Store kept in process memory, for tests and local
+// runs without a database.
+func NewMemoryStore() Store {
+	return &memoryStore{records: make(map[string]Record)}
+}
+
+func (s *memoryStore) Reserve(ctx context.Context, rec *Record) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, existing := range s.records {
+		if existing.expired(now) {
+			delete(s.records, key)
+		}
+	}
+
+	if existing, ok := s.records[rec.Key]; ok {
+		return &existing, nil
+	}
+	rec.CreatedAt = now
+	s.records[rec.Key] = *rec
+	return nil, nil
+}
+
+func (s *memoryStore) Complete(ctx context.Context, key string, expiresAt time.Time, statusCode int, contentType string, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.records[key]
+	if !ok {
+		return nil
+	}
+	rec.Completed = true
+	rec.ExpiresAt = expiresAt
+	rec.StatusCode = statusCode
+	rec.ContentType = contentType
+	rec.Body = append([]byte(nil), body...)
+	s.records[key] = rec
+	return nil
+}
+
+func (s *memoryStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
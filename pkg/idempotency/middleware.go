@@ -0,0 +1,134 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"term-service/pkg/helper"
+	"term-service/pkg/zap"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ScopeFunc returns the namespace keys live in, so two organizations can use
+// the same key independently.
+type ScopeFunc func(c *gin.Context) string
+
+// Middleware replays the stored response when a request repeats an
+// Idempotency-Key seen within ttl. Requests without the header run as usual.
+// A key reused with a different body is rejected with 422, and one whose
+// first request is still running with 409. Responses of 5xx are not stored,
+// and neither is a request that panics, so such requests can be retried.
+func Middleware(store Store, ttl time.Duration, scope ScopeFunc) gin.HandlerFunc {
+	if store == nil || ttl <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		clientKey := c.GetHeader(Header)
+		if clientKey == "" {
+			c.Next()
+			return
+		}
+		if len(clientKey) > MaxKeyLength {
+			helper.SendError(c, http.StatusBadRequest, fmt.Errorf("%s longer than %d characters", Header, MaxKeyLength), helper.ErrInvalidRequest)
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		logger := zap.FromContext(ctx)
+		rec := &Record{
+			Key:         scope(c) + "|" + c.Request.Method + " " + c.FullPath() + "|" + clientKey,
+			Fingerprint: fingerprint(body),
+			ExpiresAt:   time.Now().Add(min(Lease, ttl)),
+		}
+
+		existing, err := store.Reserve(ctx, rec)
+		if err != nil {
+			logger.Warnw("idempotency store unavailable, running request without it", "error", err)
+			c.Next()
+			return
+		}
+		if existing != nil {
+			replay(c, existing, rec.Fingerprint)
+			return
+		}
+
+		w := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = w
+
+		// The request is done; store the outcome even if the client went
+		// away. A panic unwinds through here before Recovery answers 500, so
+		// the key is released then too.
+		ctx = context.WithoutCancel(ctx)
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			if err := store.Release(ctx, rec.Key); err != nil {
+				logger.Warnw("idempotency reservation not released", "error", err)
+			}
+		}()
+
+		c.Next()
+
+		if w.Status() >= http.StatusInternalServerError {
+			return
+		}
+		if err := store.Complete(ctx, rec.Key, time.Now().Add(ttl), w.Status(), w.Header().Get("Content-Type"), w.body.Bytes()); err != nil {
+			logger.Warnw("idempotency record not saved", "error", err)
+			return
+		}
+		completed = true
+	}
+}
+
+func replay(c *gin.Context, existing *Record, fingerprint string) {
+	switch {
+	case existing.Fingerprint != fingerprint:
+		helper.SendError(c, http.StatusUnprocessableEntity, fmt.Errorf("%s was already used with a different request body", Header), helper.ErrIdempotencyKeyReused)
+	case !existing.Completed:
+		helper.SendError(c, http.StatusConflict, fmt.Errorf("a request with this %s is still in progress", Header), helper.ErrRequestInProgress)
+	default:
+		c.Header("Idempotent-Replayed", "true")
+		c.Data(existing.StatusCode, existing.ContentType, existing.Body)
+	}
+	c.Abort()
+}
+
+func fingerprint(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// responseRecorder keeps a copy of the body written to the client.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,51 @@
+package idempotency_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"term-service/pkg/idempotency"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMiddlewareReleasesKeyOnPanic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := idempotency.NewMemoryStore()
+
+	calls := 0
+	r := gin.New()
+	r.Use(gin.CustomRecovery(func(c *gin.Context, _ any) { c.AbortWithStatus(http.StatusInternalServerError) }))
+	r.POST("/upload", idempotency.Middleware(store, time.Hour, func(*gin.Context) string { return "org-a" }), func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			panic("boom")
+		}
+		c.JSON(http.StatusCreated, gin.H{"call": calls})
+	})
+
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(`{"a":1}`))
+		req.Header.Set(idempotency.Header, "k1")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := send(); w.Code != http.StatusInternalServerError {
+		t.Fatalf("first request status = %d, want 500", w.Code)
+	}
+	if w := send(); w.Code != http.StatusCreated {
+		t.Fatalf("retry after panic status = %d, want 201: %s", w.Code, w.Body)
+	}
+	w := send()
+	if w.Code != http.StatusCreated || w.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("replay status = %d, replayed = %q", w.Code, w.Header().Get("Idempotent-Replayed"))
+	}
+	if calls != 2 {
+		t.Fatalf("handler ran %d times, want 2", calls)
+	}
+}
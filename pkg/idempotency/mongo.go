@@ -0,0 +1,69 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoStore returns a Store backed by collection. Expired records are
+// removed by the TTL index on expires_at (migration 4); until then they are
+// ignored.
+func NewMongoStore(collection *mongo.Collection) Store {
+	return &mongoStore{collection: collection}
+}
+
+func (s *mongoStore) Reserve(ctx context.Context, rec *Record) (*Record, error) {
+	rec.CreatedAt = time.Now()
+
+	// At most two rounds: an expired record the TTL monitor has not removed
+	// yet is deleted and the insert retried once.
+	for attempt := 0; attempt < 2; attempt++ {
+		_, err := s.collection.InsertOne(ctx, rec)
+		if err == nil {
+			return nil, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return nil, err
+		}
+
+		var existing Record
+		err = s.collection.FindOne(ctx, bson.M{"_id": rec.Key}).Decode(&existing)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !existing.expired(time.Now()) {
+			return &existing, nil
+		}
+		if _, err := s.collection.DeleteOne(ctx, bson.M{"_id": rec.Key, "expires_at": existing.ExpiresAt}); err != nil {
+			return nil, err
+		}
+	}
+	return nil, errors.New("idempotency key is contended, retry")
+}
+
+func (s *mongoStore) Complete(ctx context.Context, key string, expiresAt time.Time, statusCode int, contentType string, body []byte) error {
+	_, err := s.collection.UpdateOne(ctx, bson.M{"_id": key}, bson.M{"$set": bson.M{
+		"completed":    true,
+		"expires_at":   expiresAt,
+		"status_code":  statusCode,
+		"content_type": contentType,
+		"body":         body,
+	}})
+	return err
+}
+
+func (s *mongoStore) Release(ctx context.Context, key string) error {
+	_, err := s.collection.DeleteOne(ctx, bson.M{"_id": key})
+	return err
+}
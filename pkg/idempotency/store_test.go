@@ -0,0 +1,81 @@
+package idempotency_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"term-service/pkg/db/dbtest"
+	"term-service/pkg/idempotency"
+)
+
+func TestMemoryStore(t *testing.T) { testStore(t, idempotency.NewMemoryStore()) }
+
+func TestGormStoreSQLite(t *testing.T) { testStore(t, idempotency.NewGormStore(dbtest.NewSQLite(t))) }
+
+func TestGormStoreMySQL(t *testing.T) { testStore(t, idempotency.NewGormStore(dbtest.NewMySQL(t))) }
+
+func TestMongoStore(t *testing.T) {
+	testStore(t, idempotency.NewMongoStore(dbtest.NewMongo(t).Collection("idempotency_records")))
+}
+
+func testStore(t *testing.T, store idempotency.Store) {
+	ctx := context.Background()
+	expires := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+
+	existing, err := store.Reserve(ctx, &idempotency.Record{Key: "org-a|k1", Fingerprint: "f1", ExpiresAt: expires})
+	if err != nil || existing != nil {
+		t.Fatalf("first Reserve = %+v, %v, want nil, nil", existing, err)
+	}
+
+	existing, err = store.Reserve(ctx, &idempotency.Record{Key: "org-a|k1", Fingerprint: "f2", ExpiresAt: expires})
+	if err != nil || existing == nil {
+		t.Fatalf("second Reserve = %+v, %v, want the first record", existing, err)
+	}
+	if existing.Fingerprint != "f1" || existing.Completed {
+		t.Fatalf("in-progress record = %+v", existing)
+	}
+
+	if err := store.Complete(ctx, "org-a|k1", expires, 200, "application/json", []byte(`{"ok":true}`)); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	existing, err = store.Reserve(ctx, &idempotency.Record{Key: "org-a|k1", Fingerprint: "f1", ExpiresAt: expires})
+	if err != nil || existing == nil {
+		t.Fatalf("Reserve after Complete = %+v, %v", existing, err)
+	}
+	if !existing.Completed || existing.StatusCode != 200 || existing.ContentType != "application/json" || string(existing.Body) != `{"ok":true}` {
+		t.Fatalf("completed record = %+v", existing)
+	}
+
+	// other scope, same client key
+	if existing, err := store.Reserve(ctx, &idempotency.Record{Key: "org-b|k1", Fingerprint: "f1", ExpiresAt: expires}); err != nil || existing != nil {
+		t.Fatalf("Reserve in other scope = %+v, %v, want nil, nil", existing, err)
+	}
+
+	if err := store.Release(ctx, "org-b|k1"); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if existing, err := store.Reserve(ctx, &idempotency.Record{Key: "org-b|k1", Fingerprint: "f3", ExpiresAt: expires}); err != nil || existing != nil {
+		t.Fatalf("Reserve after Release = %+v, %v, want nil, nil", existing, err)
+	}
+
+	past := time.Now().Add(-time.Minute).Truncate(time.Millisecond)
+	if _, err := store.Reserve(ctx, &idempotency.Record{Key: "org-a|old", Fingerprint: "f1", ExpiresAt: past}); err != nil {
+		t.Fatalf("Reserve expired: %v", err)
+	}
+	if existing, err := store.Reserve(ctx, &idempotency.Record{Key: "org-a|old", Fingerprint: "f2", ExpiresAt: expires}); err != nil || existing != nil {
+		t.Fatalf("Reserve over expired record = %+v, %v, want nil, nil", existing, err)
+	}
+
+	// a lapsed lease is extended by Complete
+	if _, err := store.Reserve(ctx, &idempotency.Record{Key: "org-a|slow", Fingerprint: "f1", ExpiresAt: past}); err != nil {
+		t.Fatalf("Reserve slow: %v", err)
+	}
+	if err := store.Complete(ctx, "org-a|slow", expires, 201, "application/json", nil); err != nil {
+		t.Fatalf("Complete slow: %v", err)
+	}
+	existing, err = store.Reserve(ctx, &idempotency.Record{Key: "org-a|slow", Fingerprint: "f1", ExpiresAt: expires})
+	if err != nil || existing == nil || !existing.Completed || existing.StatusCode != 201 {
+		t.Fatalf("Reserve after late Complete = %+v, %v, want the completed record", existing, err)
+	}
+}
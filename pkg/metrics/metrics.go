@@ -0,0 +1,160 @@
+package metrics
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"term-service/pkg/db"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const namespace = "term_service"
+
+var (
+	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	repositoryOperationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "repository_operation_duration_seconds",
+		Help:      "Database operation latency by repository, method and outcome.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"repository", "method", "outcome"})
+
+	gatewayCallDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "gateway_call_duration_seconds",
+		Help:      "Outgoing gateway call latency by target service, method, path and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"service", "method", "path", "status"})
+
+	cacheRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Cache lookups by cache name and result (hit or miss).",
+	}, []string{"cache", "result"})
+
+	outboxDeliveriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbox_deliveries_total",
+		Help:      "Outbox event deliveries by sink and result (ok or error).",
+	}, []string{"sink", "result"})
+
+	webhookDeliveriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_deliveries_total",
+		Help:      "Webhook delivery attempts by result (ok or error).",
+	}, []string{"result"})
+
+	cacheHitRatio = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "cache_hit_ratio",
+		Help:      "Ratio of cache hits to lookups since start, by cache name.",
+	}, []string{"cache"})
+)
+
+// ObserveHTTPRequest records one served HTTP request.
+func ObserveHTTPRequest(method, route string, status int, elapsed time.Duration) {
+	code := strconv.Itoa(status)
+	httpRequestsTotal.WithLabelValues(method, route, code).Inc()
+	httpRequestDuration.WithLabelValues(method, route, code).Observe(elapsed.Seconds())
+}
+
+// ObserveRepository records a repository method call. It is meant to be
+// deferred with a pointer to the method's named error result.
+func ObserveRepository(repository, method string, start time.Time, err *error) {
+	outcome := "ok"
+	if err != nil && *err != nil {
+		outcome = "error"
+		if errors.Is(*err, db.ErrNotFound) || errors.Is(*err, mongo.ErrNoDocuments) {
+			outcome = "not_found"
+		}
+	}
+	repositoryOperationDuration.WithLabelValues(repository, method, outcome).Observe(time.Since(start).Seconds())
+}
+
+// ObserveGatewayCall records an outgoing call to another service. Status is
+// the HTTP status code, or 0 when the request never got a response.
+func ObserveGatewayCall(service, method, path string, status int, elapsed time.Duration) {
+	code := "error"
+	if status > 0 {
+		code = strconv.Itoa(status)
+	}
+	gatewayCallDuration.WithLabelValues(service, method, path, code).Observe(elapsed.Seconds())
+}
+
+// ObserveOutboxDelivery records one attempt to deliver an outbox event.
+func ObserveOutboxDelivery(sink string, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	outboxDeliveriesTotal.WithLabelValues(sink, result).Inc()
+}
+
+// ObserveWebhookDelivery records one attempt to deliver to a webhook
+// subscription.
+func ObserveWebhookDelivery(err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	webhookDeliveriesTotal.WithLabelValues(result).Inc()
+}
+
+type cacheStats struct {
+	hits   float64
+	lookup float64
+}
+
+var (
+	cacheMu    sync.Mutex
+	cacheState = make(map[string]*cacheStats)
+)
+
+// CacheHit records a cache hit for the named cache.
+func CacheHit(cache string) {
+	observeCache(cache, true)
+}
+
+// CacheMiss records a cache miss for the named cache.
+func CacheMiss(cache string) {
+	observeCache(cache, false)
+}
+
+func observeCache(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheRequestsTotal.WithLabelValues(cache, result).Inc()
+
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	st, ok := cacheState[cache]
+	if !ok {
+		st = &cacheStats{}
+		cacheState[cache] = st
+	}
+	st.lookup++
+	if hit {
+		st.hits++
+	}
+	cacheHitRatio.WithLabelValues(cache).Set(st.hits / st.lookup)
+}
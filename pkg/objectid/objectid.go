@@ -0,0 +1,56 @@
+package objectid
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ID is a backend-neutral identifier. It is a 24 character hex string in Go
+// and SQL, and is stored as a native ObjectID in Mongo so existing documents
+// and indexes keep working.
+type ID string
+
+// New generates a new ID.
+func New() ID {
+	return ID(primitive.NewObjectID().Hex())
+}
+
+// Hex returns the string form; kept so callers read like primitive.ObjectID.
+func (id ID) Hex() string {
+	return string(id)
+}
+
+func (id ID) String() string {
+	return string(id)
+}
+
+func (id ID) IsZero() bool {
+	return id == ""
+}
+
+// MarshalBSONValue stores valid hex ids as ObjectIDs and anything else as a
+// plain string.
+func (id ID) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if oid, err := primitive.ObjectIDFromHex(string(id)); err == nil {
+		return bson.MarshalValue(oid)
+	}
+	return bson.MarshalValue(string(id))
+}
+
+func (id *ID) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	raw := bson.RawValue{Type: t, Value: data}
+	switch t {
+	case bson.TypeObjectID:
+		*id = ID(raw.ObjectID().Hex())
+	case bson.TypeString:
+		*id = ID(raw.StringValue())
+	case bson.TypeNull, bson.TypeUndefined:
+		*id = ""
+	default:
+		return fmt.Errorf("cannot decode bson %s into objectid.ID", t)
+	}
+	return nil
+}
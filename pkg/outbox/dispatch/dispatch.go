@@ -0,0 +1,137 @@
+// Package dispatch delivers pending outbox events to a sink.
+package dispatch
+
+import (
+	"context"
+	"time"
+
+	"term-service/pkg/metrics"
+	"term-service/pkg/outbox"
+	"term-service/pkg/zap"
+)
+
+// Config tunes the dispatcher; zero fields take the defaults below.
+type Config struct {
+	Interval       time.Duration // pause between polls when the outbox is drained
+	BatchSize      int
+	Lease          time.Duration // how long a claimed event is hidden from other replicas
+	PublishTimeout time.Duration
+	MaxBackoff     time.Duration
+	Retention      time.Duration // published events are kept this long
+}
+
+func (c Config) withDefaults() Config {
+	if c.Interval <= 0 {
+		c.Interval = time.Second
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = 100
+	}
+	if c.Lease <= 0 {
+		c.Lease = time.Minute
+	}
+	if c.PublishTimeout <= 0 {
+		c.PublishTimeout = 10 * time.Second
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = 10 * time.Minute
+	}
+	if c.Retention <= 0 {
+		c.Retention = 7 * 24 * time.Hour
+	}
+	return c
+}
+
+// Dispatcher polls the outbox and publishes due events. Failed deliveries
+// are retried with exponential backoff; events are never dropped.
+type Dispatcher struct {
+	store outbox.Store
+	sink  outbox.Sink
+	cfg   Config
+	now   func() time.Time
+}
+
+func New(store outbox.Store, sink outbox.Sink, cfg Config) *Dispatcher {
+	return &Dispatcher{store: store, sink: sink, cfg: cfg.withDefaults(), now: time.Now}
+}
+
+// Run dispatches until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	logger := zap.FromContext(ctx)
+	logger.Infow("outbox dispatcher started", "sink", d.sink.Name())
+
+	lastPrune := time.Time{}
+	for {
+		n, err := d.DispatchOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.Errorw("outbox dispatch failed", "error", err)
+		}
+
+		if d.now().Sub(lastPrune) >= time.Hour {
+			if err := d.store.Prune(ctx, d.now().Add(-d.cfg.Retention)); err != nil && ctx.Err() == nil {
+				logger.Warnw("outbox prune failed", "error", err)
+			}
+			lastPrune = d.now()
+		}
+
+		// A full batch means more is probably waiting.
+		wait := d.cfg.Interval
+		if n == d.cfg.BatchSize {
+			wait = 0
+		}
+		select {
+		case <-ctx.Done():
+			logger.Infow("outbox dispatcher stopped")
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// DispatchOnce claims one batch and delivers it, returning how many events
+// were claimed.
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	events, err := d.store.Claim(ctx, d.cfg.BatchSize, d.cfg.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range events {
+		e := &events[i]
+		publishCtx, cancel := context.WithTimeout(ctx, d.cfg.PublishTimeout)
+		err := d.sink.Publish(publishCtx, e.Envelope())
+		cancel()
+		metrics.ObserveOutboxDelivery(d.sink.Name(), err)
+
+		if err != nil {
+			retryAt := d.now().Add(d.backoff(e.Attempts))
+			zap.FromContext(ctx).Warnw("outbox delivery failed",
+				"event_id", e.ID.Hex(),
+				"event_type", e.Type,
+				"attempt", e.Attempts+1,
+				"retry_at", retryAt,
+				"error", err,
+			)
+			if err := d.store.MarkFailed(ctx, e.ID.Hex(), err, retryAt); err != nil {
+				return len(events), err
+			}
+			continue
+		}
+		if err := d.store.MarkPublished(ctx, e.ID.Hex()); err != nil {
+			return len(events), err
+		}
+	}
+	return len(events), nil
+}
+
+// backoff doubles from one second per previous attempt, up to MaxBackoff.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := time.Second
+	for i := 0; i < attempts && delay < d.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.cfg.MaxBackoff {
+		delay = d.cfg.MaxBackoff
+	}
+	return delay
+}
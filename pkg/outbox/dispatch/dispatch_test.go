@@ -0,0 +1,69 @@
+package dispatch_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"term-service/pkg/outbox"
+	"term-service/pkg/outbox/dispatch"
+)
+
+// flakySink fails its next failures publishes, then delivers.
+type flakySink struct {
+	*outbox.MemorySink
+	failures int
+}
+
+func (s *flakySink) Publish(ctx context.Context, envelope outbox.Envelope) error {
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("broker unavailable")
+	}
+	return s.MemorySink.Publish(ctx, envelope)
+}
+
+func TestDispatchOnceRetriesFailedDeliveries(t *testing.T) {
+	ctx := context.Background()
+	store := outbox.NewMemoryStore()
+	sink := &flakySink{MemorySink: outbox.NewMemorySink(), failures: 1}
+
+	for _, id := range []string{"t1", "t2"} {
+		e, err := outbox.NewEvent(outbox.TermCreated, "term", id, "org-a", map[string]string{"id": id})
+		if err != nil {
+			t.Fatalf("NewEvent: %v", err)
+		}
+		if err := store.Record(ctx, e); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+
+	d := dispatch.New(store, sink, dispatch.Config{MaxBackoff: time.Millisecond})
+	if n, err := d.DispatchOnce(ctx); err != nil || n != 2 {
+		t.Fatalf("DispatchOnce = %d, %v, want 2, nil", n, err)
+	}
+	if got := sink.Envelopes(); len(got) != 1 || got[0].AggregateID != "t2" {
+		t.Fatalf("delivered %+v, want only t2", got)
+	}
+
+	time.Sleep(5 * time.Millisecond) // past the backoff
+	if n, err := d.DispatchOnce(ctx); err != nil || n != 1 {
+		t.Fatalf("retry DispatchOnce = %d, %v, want 1, nil", n, err)
+	}
+	if got := sink.Envelopes(); len(got) != 2 || got[1].AggregateID != "t1" {
+		t.Fatalf("delivered %+v, want t1 retried", got)
+	}
+
+	for _, e := range store.Events() {
+		if e.PublishedAt == nil {
+			t.Fatalf("event %s not marked published", e.AggregateID)
+		}
+		if e.AggregateID == "t1" && e.Attempts != 2 {
+			t.Fatalf("t1 attempts = %d, want 2", e.Attempts)
+		}
+	}
+	if n, _ := d.DispatchOnce(ctx); n != 0 {
+		t.Fatalf("DispatchOnce on a drained outbox claimed %d", n)
+	}
+}
@@ -0,0 +1,114 @@
+// Package outbox records domain events in the same transaction as the change
+// that caused them; package dispatch later delivers them to a Sink. Delivery
+// is at least once; consumers deduplicate by event id.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"term-service/pkg/objectid"
+)
+
+// Event types published by term-service.
+const (
+	TermCreated    = "term.created"
+	TermUpdated    = "term.updated"
+	TermDeleted    = "term.deleted"
+	HolidayCreated = "holiday.created"
+	HolidayUpdated = "holiday.updated"
+	HolidayDeleted = "holiday.deleted"
+
+	// Emitted by the lifecycle scheduler rather than by a change.
+	TermStartingSoon    = "term.starting_soon"
+	TermEnded           = "term.ended"
+	HolidayStartingSoon = "holiday.starting_soon"
+)
+
+// Event is one outbox entry.
+type Event struct {
+	ID             objectid.ID `bson:"_id" gorm:"primaryKey;size:24"`
+	Type           string      `bson:"type" gorm:"size:64"`
+	AggregateType  string      `bson:"aggregate_type" gorm:"size:32"`
+	AggregateID    string      `bson:"aggregate_id" gorm:"size:64"`
+	OrganizationID string      `bson:"organization_id" gorm:"size:64"`
+	Payload        []byte      `bson:"payload"` // JSON
+	OccurredAt     time.Time   `bson:"occurred_at"`
+
+	PublishedAt   *time.Time `bson:"published_at" gorm:"index:idx_outbox_pending,priority:1"`
+	NextAttemptAt time.Time  `bson:"next_attempt_at" gorm:"index:idx_outbox_pending,priority:2"`
+	Attempts      int        `bson:"attempts"`
+	LastError     string     `bson:"last_error" gorm:"size:1024"`
+}
+
+func (Event) TableName() string {
+	return "outbox_events"
+}
+
+// NewEvent builds an event for the aggregate with data as JSON payload.
+func NewEvent(eventType, aggregateType, aggregateID, organizationID string, data interface{}) (*Event, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s payload: %w", eventType, err)
+	}
+	now := time.Now().UTC()
+	return &Event{
+		ID:             objectid.New(),
+		Type:           eventType,
+		AggregateType:  aggregateType,
+		AggregateID:    aggregateID,
+		OrganizationID: organizationID,
+		Payload:        payload,
+		OccurredAt:     now,
+		NextAttemptAt:  now,
+	}, nil
+}
+
+// Envelope is the message sinks deliver.
+type Envelope struct {
+	ID             string          `json:"id"`
+	Type           string          `json:"type"`
+	Source         string          `json:"source"`
+	AggregateType  string          `json:"aggregate_type"`
+	AggregateID    string          `json:"aggregate_id"`
+	OrganizationID string          `json:"organization_id"`
+	OccurredAt     time.Time       `json:"occurred_at"`
+	Data           json.RawMessage `json:"data"`
+}
+
+// Source names this service in every envelope.
+const Source = "term-service"
+
+func (e *Event) Envelope() Envelope {
+	return Envelope{
+		ID:             e.ID.Hex(),
+		Type:           e.Type,
+		Source:         Source,
+		AggregateType:  e.AggregateType,
+		AggregateID:    e.AggregateID,
+		OrganizationID: e.OrganizationID,
+		OccurredAt:     e.OccurredAt,
+		Data:           json.RawMessage(e.Payload),
+	}
+}
+
+// Recorder appends events to the outbox. Called with a transaction context
+// (see txn.Transactor) the events commit or roll back with the change.
+type Recorder interface {
+	Record(ctx context.Context, events ...*Event) error
+}
+
+// Store is the outbox as seen by the dispatcher.
+type Store interface {
+	Recorder
+	// Claim returns up to limit due events and hides them from other
+	// claimers for lease.
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]Event, error)
+	MarkPublished(ctx context.Context, id string) error
+	// MarkFailed records a failed delivery and schedules the next attempt.
+	MarkFailed(ctx context.Context, id string, cause error, retryAt time.Time) error
+	// Prune deletes events published before the given time.
+	Prune(ctx context.Context, publishedBefore time.Time) error
+}
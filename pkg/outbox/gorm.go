@@ -0,0 +1,78 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"term-service/pkg/db/txn"
+
+	"gorm.io/gorm"
+)
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore returns a Store backed by the outbox_events table. Record
+// joins the transaction carried by ctx (txn.Gorm).
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+func (s *gormStore) Record(ctx context.Context, events ...*Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return txn.Gorm(ctx, s.db).Create(events).Error
+}
+
+func (s *gormStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]Event, error) {
+	now := time.Now().UTC()
+
+	var due []Event
+	err := txn.Gorm(ctx, s.db).
+		Where("published_at IS NULL AND next_attempt_at <= ?", now).
+		Order("occurred_at").
+		Limit(limit).
+		Find(&due).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Another dispatcher may claim the same rows; whoever moves
+	// next_attempt_at first wins.
+	claimed := due[:0]
+	for _, e := range due {
+		result := txn.Gorm(ctx, s.db).Model(&Event{}).
+			Where("id = ? AND next_attempt_at = ? AND published_at IS NULL", e.ID, e.NextAttemptAt).
+			Update("next_attempt_at", now.Add(lease))
+		if result.Error != nil {
+			return claimed, result.Error
+		}
+		if result.RowsAffected == 1 {
+			e.NextAttemptAt = now.Add(lease)
+			claimed = append(claimed, e)
+		}
+	}
+	return claimed, nil
+}
+
+func (s *gormStore) MarkPublished(ctx context.Context, id string) error {
+	return txn.Gorm(ctx, s.db).Model(&Event{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"published_at": time.Now().UTC(),
+		"last_error":   "",
+		"attempts":     gorm.Expr("attempts + 1"),
+	}).Error
+}
+
+func (s *gormStore) MarkFailed(ctx context.Context, id string, cause error, retryAt time.Time) error {
+	return txn.Gorm(ctx, s.db).Model(&Event{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"next_attempt_at": retryAt.UTC(),
+		"last_error":      truncate(cause.Error()),
+		"attempts":        gorm.Expr("attempts + 1"),
+	}).Error
+}
+
+func (s *gormStore) Prune(ctx context.Context, publishedBefore time.Time) error {
+	return txn.Gorm(ctx, s.db).Where("published_at < ?", publishedBefore.UTC()).Delete(&Event{}).Error
+}
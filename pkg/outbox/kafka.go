@@ -0,0 +1,46 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type kafkaSink struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaSink writes envelopes to topic, keyed by aggregate id so changes
+// to one term or holiday stay ordered within a partition.
+func NewKafkaSink(brokers []string, topic string) Sink {
+	return &kafkaSink{writer: &kafka.Writer{
+		Addr:                   kafka.TCP(brokers...),
+		Topic:                  topic,
+		Balancer:               &kafka.Hash{},
+		RequiredAcks:           kafka.RequireAll,
+		AllowAutoTopicCreation: false,
+	}}
+}
+
+func (s *kafkaSink) Name() string { return "kafka" }
+
+func (s *kafkaSink) Publish(ctx context.Context, envelope Envelope) error {
+	value, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	return s.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(envelope.AggregateID),
+		Value: value,
+		Headers: []kafka.Header{
+			{Key: "event_id", Value: []byte(envelope.ID)},
+			{Key: "event_type", Value: []byte(envelope.Type)},
+			{Key: "organization_id", Value: []byte(envelope.OrganizationID)},
+		},
+	})
+}
+
+func (s *kafkaSink) Close() error {
+	return s.writer.Close()
+}
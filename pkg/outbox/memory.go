@@ -0,0 +1,123 @@
+package outbox
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"term-service/pkg/objectid"
+)
+
+// MemoryStore is a Store kept in process memory, for tests and local runs
+// without a database.
+type MemoryStore struct {
+	mu     sync.Mutex
+	events map[objectid.ID]Event
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{events: make(map[objectid.ID]Event)}
+}
+
+// Events returns every recorded event in the order they occurred.
+func (s *MemoryStore) Events() []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := make([]Event, 0, len(s.events))
+	for _, e := range s.events {
+		events = append(events, e)
+	}
+	sortByOccurred(events)
+	return events
+}
+
+func (s *MemoryStore) Record(ctx context.Context, events ...*Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range events {
+		s.events[e.ID] = *e
+	}
+	return nil
+}
+
+func (s *MemoryStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var due []Event
+	for _, e := range s.events {
+		if e.PublishedAt == nil && !e.NextAttemptAt.After(now) {
+			due = append(due, e)
+		}
+	}
+	sortByOccurred(due)
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	for i := range due {
+		due[i].NextAttemptAt = now.Add(lease)
+		s.events[due[i].ID] = due[i]
+	}
+	return due, nil
+}
+
+func (s *MemoryStore) MarkPublished(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.events[objectid.ID(id)]; ok {
+		now := time.Now()
+		e.PublishedAt = &now
+		e.Attempts++
+		e.LastError = ""
+		s.events[e.ID] = e
+	}
+	return nil
+}
+
+func (s *MemoryStore) MarkFailed(ctx context.Context, id string, cause error, retryAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.events[objectid.ID(id)]; ok {
+		e.Attempts++
+		e.LastError = truncate(cause.Error())
+		e.NextAttemptAt = retryAt
+		s.events[e.ID] = e
+	}
+	return nil
+}
+
+func (s *MemoryStore) Prune(ctx context.Context, publishedBefore time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, e := range s.events {
+		if e.PublishedAt != nil && e.PublishedAt.Before(publishedBefore) {
+			delete(s.events, id)
+		}
+	}
+	return nil
+}
+
+func sortByOccurred(events []Event) {
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].OccurredAt.Equal(events[j].OccurredAt) {
+			return events[i].ID < events[j].ID
+		}
+		return events[i].OccurredAt.Before(events[j].OccurredAt)
+	})
+}
+
+// truncate keeps LastError within its column size.
+func truncate(msg string) string {
+	const max = 1024
+	if len(msg) > max {
+		return msg[:max]
+	}
+	return msg
+}
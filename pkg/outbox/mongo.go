@@ -0,0 +1,79 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"term-service/pkg/objectid"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoStore returns a Store backed by collection. Record joins the
+// transaction of a mongo.SessionContext.
+func NewMongoStore(collection *mongo.Collection) Store {
+	return &mongoStore{collection: collection}
+}
+
+func (s *mongoStore) Record(ctx context.Context, events ...*Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	docs := make([]interface{}, len(events))
+	for i, e := range events {
+		docs[i] = e
+	}
+	_, err := s.collection.InsertMany(ctx, docs)
+	return err
+}
+
+func (s *mongoStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]Event, error) {
+	var claimed []Event
+	for len(claimed) < limit {
+		now := time.Now().UTC()
+		var e Event
+		err := s.collection.FindOneAndUpdate(ctx,
+			bson.M{"published_at": nil, "next_attempt_at": bson.M{"$lte": now}},
+			bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}},
+			options.FindOneAndUpdate().
+				SetSort(bson.D{{Key: "occurred_at", Value: 1}}).
+				SetReturnDocument(options.After),
+		).Decode(&e)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			break
+		}
+		if err != nil {
+			return claimed, err
+		}
+		claimed = append(claimed, e)
+	}
+	return claimed, nil
+}
+
+func (s *mongoStore) MarkPublished(ctx context.Context, id string) error {
+	_, err := s.collection.UpdateByID(ctx, objectid.ID(id), bson.M{
+		"$set": bson.M{"published_at": time.Now().UTC(), "last_error": ""},
+		"$inc": bson.M{"attempts": 1},
+	})
+	return err
+}
+
+func (s *mongoStore) MarkFailed(ctx context.Context, id string, cause error, retryAt time.Time) error {
+	_, err := s.collection.UpdateByID(ctx, objectid.ID(id), bson.M{
+		"$set": bson.M{"next_attempt_at": retryAt.UTC(), "last_error": truncate(cause.Error())},
+		"$inc": bson.M{"attempts": 1},
+	})
+	return err
+}
+
+func (s *mongoStore) Prune(ctx context.Context, publishedBefore time.Time) error {
+	_, err := s.collection.DeleteMany(ctx, bson.M{"published_at": bson.M{"$lt": publishedBefore.UTC()}})
+	return err
+}
@@ -0,0 +1,46 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+)
+
+type natsSink struct {
+	conn          *nats.Conn
+	subjectPrefix string
+}
+
+// NewNATSSink publishes each envelope on subjectPrefix + "." + type, e.g.
+// term-service.term.updated.
+func NewNATSSink(url, subjectPrefix string) (Sink, error) {
+	conn, err := nats.Connect(url, nats.Name(Source))
+	if err != nil {
+		return nil, fmt.Errorf("connect nats: %w", err)
+	}
+	return &natsSink{conn: conn, subjectPrefix: subjectPrefix}, nil
+}
+
+func (s *natsSink) Name() string { return "nats" }
+
+func (s *natsSink) Publish(ctx context.Context, envelope Envelope) error {
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	msg := nats.NewMsg(s.subjectPrefix + "." + envelope.Type)
+	msg.Data = data
+	msg.Header.Set(nats.MsgIdHdr, envelope.ID) // JetStream deduplication
+	if err := s.conn.PublishMsg(msg); err != nil {
+		return err
+	}
+	// Flush so a lost connection fails this delivery instead of
+	// silently dropping the buffered message.
+	return s.conn.FlushWithContext(ctx)
+}
+
+func (s *natsSink) Close() error {
+	return s.conn.Drain()
+}
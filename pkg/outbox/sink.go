@@ -0,0 +1,76 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Sink delivers envelopes to consumers.
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, envelope Envelope) error
+	Close() error
+}
+
+// MemorySink keeps delivered envelopes, for tests.
+type MemorySink struct {
+	mu        sync.Mutex
+	envelopes []Envelope
+}
+
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+func (s *MemorySink) Name() string { return "memory" }
+
+func (s *MemorySink) Publish(ctx context.Context, envelope Envelope) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.envelopes = append(s.envelopes, envelope)
+	return nil
+}
+
+func (s *MemorySink) Close() error { return nil }
+
+// Envelopes returns what was delivered so far.
+func (s *MemorySink) Envelopes() []Envelope {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Envelope(nil), s.envelopes...)
+}
+
+type fileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileSink appends one JSON envelope per line to path, for local runs.
+func NewFileSink(path string) (Sink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open event file: %w", err)
+	}
+	return &fileSink{file: f}, nil
+}
+
+func (s *fileSink) Name() string { return "file" }
+
+func (s *fileSink) Publish(ctx context.Context, envelope Envelope) error {
+	line, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.file.Write(append(line, '\n'))
+	return err
+}
+
+func (s *fileSink) Close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,84 @@
+package outbox_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"term-service/pkg/outbox"
+)
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	sink, err := outbox.NewFileSink(path)
+	if err != nil {
+		t.Fatalf("NewFileSink: %v", err)
+	}
+
+	for _, id := range []string{"t1", "t2"} {
+		e := newEvent(t, outbox.TermCreated, id, time.Now())
+		if err := sink.Publish(context.Background(), e.Envelope()); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer f.Close()
+
+	var ids []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var env outbox.Envelope
+		if err := json.Unmarshal(scanner.Bytes(), &env); err != nil {
+			t.Fatalf("line %q: %v", scanner.Text(), err)
+		}
+		ids = append(ids, env.AggregateID)
+	}
+	if len(ids) != 2 || ids[0] != "t1" || ids[1] != "t2" {
+		t.Fatalf("file holds %v, want [t1 t2]", ids)
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var got outbox.Envelope
+	var header http.Header
+	status := http.StatusAccepted
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	sink := outbox.NewWebhookSink(srv.URL, "secret-token", time.Second)
+	e := newEvent(t, outbox.HolidayUpdated, "h1", time.Now())
+
+	if err := sink.Publish(context.Background(), e.Envelope()); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if got.ID != e.ID.Hex() || got.Type != outbox.HolidayUpdated || got.Source != outbox.Source {
+		t.Fatalf("delivered %+v", got)
+	}
+	if header.Get("Authorization") != "Bearer secret-token" || header.Get("X-Event-ID") != e.ID.Hex() {
+		t.Fatalf("headers = %v", header)
+	}
+
+	status = http.StatusServiceUnavailable
+	if err := sink.Publish(context.Background(), e.Envelope()); err == nil {
+		t.Fatal("Publish succeeded on a 503 answer")
+	}
+}
@@ -0,0 +1,91 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"term-service/pkg/db/dbtest"
+	"term-service/pkg/outbox"
+)
+
+func TestMemoryStore(t *testing.T) { testStore(t, outbox.NewMemoryStore()) }
+
+func TestGormStoreSQLite(t *testing.T) { testStore(t, outbox.NewGormStore(dbtest.NewSQLite(t))) }
+
+func TestGormStoreMySQL(t *testing.T) { testStore(t, outbox.NewGormStore(dbtest.NewMySQL(t))) }
+
+func TestMongoStore(t *testing.T) {
+	testStore(t, outbox.NewMongoStore(dbtest.NewMongo(t).Collection("outbox_events")))
+}
+
+func newEvent(t *testing.T, eventType, aggregateID string, occurredAt time.Time) *outbox.Event {
+	t.Helper()
+	e, err := outbox.NewEvent(eventType, "term", aggregateID, "org-a", map[string]string{"id": aggregateID})
+	if err != nil {
+		t.Fatalf("NewEvent: %v", err)
+	}
+	e.OccurredAt = occurredAt
+	e.NextAttemptAt = occurredAt
+	return e
+}
+
+func testStore(t *testing.T, store outbox.Store) {
+	ctx := context.Background()
+	base := time.Now().UTC().Add(-time.Minute).Truncate(time.Millisecond)
+
+	first := newEvent(t, outbox.TermCreated, "t1", base)
+	second := newEvent(t, outbox.TermUpdated, "t1", base.Add(time.Second))
+	third := newEvent(t, outbox.TermDeleted, "t1", base.Add(2*time.Second))
+	if err := store.Record(ctx, third, first, second); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	claimed, err := store.Claim(ctx, 2, time.Minute)
+	if err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if len(claimed) != 2 || claimed[0].ID != first.ID || claimed[1].ID != second.ID {
+		t.Fatalf("Claim = %+v, want the two oldest events in order", claimed)
+	}
+	if string(claimed[0].Payload) != `{"id":"t1"}` || claimed[0].Type != outbox.TermCreated {
+		t.Fatalf("claimed event = %+v", claimed[0])
+	}
+
+	// leased events are not handed out again
+	claimed, err = store.Claim(ctx, 10, time.Minute)
+	if err != nil || len(claimed) != 1 || claimed[0].ID != third.ID {
+		t.Fatalf("second Claim = %+v, %v, want only the third event", claimed, err)
+	}
+
+	if err := store.MarkPublished(ctx, first.ID.Hex()); err != nil {
+		t.Fatalf("MarkPublished: %v", err)
+	}
+	// a failure due now is claimable again at once
+	if err := store.MarkFailed(ctx, second.ID.Hex(), errors.New("sink down"), time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("MarkFailed: %v", err)
+	}
+	if err := store.MarkFailed(ctx, third.ID.Hex(), errors.New("sink down"), time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("MarkFailed: %v", err)
+	}
+
+	claimed, err = store.Claim(ctx, 10, time.Minute)
+	if err != nil || len(claimed) != 1 || claimed[0].ID != second.ID {
+		t.Fatalf("Claim after failures = %+v, %v, want only the second event", claimed, err)
+	}
+	if claimed[0].Attempts != 1 || claimed[0].LastError != "sink down" {
+		t.Fatalf("retried event = %+v", claimed[0])
+	}
+
+	if err := store.Prune(ctx, time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+	if err := store.MarkFailed(ctx, second.ID.Hex(), errors.New("sink down"), time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("MarkFailed: %v", err)
+	}
+	claimed, err = store.Claim(ctx, 10, time.Minute)
+	if err != nil || len(claimed) != 1 || claimed[0].ID != second.ID {
+		t.Fatalf("Claim after Prune = %+v, %v, want the unpublished event kept", claimed, err)
+	}
+}
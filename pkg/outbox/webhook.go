@@ -0,0 +1,60 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+type webhookSink struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewWebhookSink POSTs each envelope as JSON to url. A non-empty token is
+// sent as a bearer token. Any non-2xx answer counts as a failed delivery.
+func NewWebhookSink(url, token string, timeout time.Duration) Sink {
+	return &webhookSink{url: url, token: token, client: &http.Client{Timeout: timeout}}
+}
+
+func (s *webhookSink) Name() string { return "webhook" }
+
+func (s *webhookSink) Publish(ctx context.Context, envelope Envelope) error {
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", envelope.ID)
+	req.Header.Set("X-Event-Type", envelope.Type)
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s answered %d", s.url, resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *webhookSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
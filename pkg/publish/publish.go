@@ -0,0 +1,179 @@
+// Package publish decides whether a term or holiday is visible on a
+// channel. An item lists the audiences (channels) it is published to, each
+// with an optional [publish_at, unpublish_at) window, so a calendar can be
+// prepared in advance and revealed on a date.
+package publish
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"gorm.io/gorm"
+)
+
+// Channels an item can be published to.
+const (
+	Mobile  = "mobile"
+	Desktop = "desktop"
+	Teacher = "teacher"
+	Parent  = "parent"
+	Kiosk   = "kiosk"
+)
+
+// Channels lists every known channel.
+var Channels = []string{Mobile, Desktop, Teacher, Parent, Kiosk}
+
+// Audience publishes an item to one channel. Nil bounds are open.
+type Audience struct {
+	Channel     string     `bson:"channel" json:"channel"`
+	PublishAt   *time.Time `bson:"publish_at,omitempty" json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `bson:"unpublish_at,omitempty" json:"unpublish_at,omitempty"`
+}
+
+// VisibleAt reports whether now lies within the audience's window.
+func (a Audience) VisibleAt(now time.Time) bool {
+	if a.PublishAt != nil && now.Before(*a.PublishAt) {
+		return false
+	}
+	return a.UnpublishAt == nil || now.Before(*a.UnpublishAt)
+}
+
+// Audiences is the set of channels an item is published to, at most one
+// entry per channel.
+type Audiences []Audience
+
+// Find returns the audience of channel.
+func (as Audiences) Find(channel string) (Audience, bool) {
+	for _, a := range as {
+		if a.Channel == channel {
+			return a, true
+		}
+	}
+	return Audience{}, false
+}
+
+// VisibleOn reports whether the item is shown on channel at now.
+func (as Audiences) VisibleOn(channel string, now time.Time) bool {
+	a, ok := as.Find(channel)
+	return ok && a.VisibleAt(now)
+}
+
+// VisibleOnAny reports whether the item is shown on some channel at now.
+func (as Audiences) VisibleOnAny(now time.Time) bool {
+	for _, a := range as {
+		if a.VisibleAt(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate rejects unknown or repeated channels and empty windows.
+func (as Audiences) Validate() error {
+	var errs []error
+	seen := make(map[string]bool, len(as))
+	for _, a := range as {
+		switch {
+		case !slices.Contains(Channels, a.Channel):
+			errs = append(errs, fmt.Errorf("unknown channel %q", a.Channel))
+		case seen[a.Channel]:
+			errs = append(errs, fmt.Errorf("channel %s listed twice", a.Channel))
+		case a.PublishAt != nil && a.UnpublishAt != nil && !a.UnpublishAt.After(*a.PublishAt):
+			errs = append(errs, fmt.Errorf("unpublish_at of channel %s must be after publish_at", a.Channel))
+		}
+		seen[a.Channel] = true
+	}
+	return errors.Join(errs...)
+}
+
+// WithFlag adds channel when published is set. It converts the flag format
+// used before audiences (published_<channel>, publish_<channel>_at,
+// unpublish_<channel>_at).
+func (as Audiences) WithFlag(channel string, published bool, publishAt, unpublishAt *time.Time) Audiences {
+	if !published {
+		return as
+	}
+	return append(as, Audience{Channel: channel, PublishAt: publishAt, UnpublishAt: unpublishAt})
+}
+
+// Flag returns channel in the flag format, for clients that predate
+// audiences.
+func (as Audiences) Flag(channel string) (published bool, publishAt, unpublishAt *time.Time) {
+	a, ok := as.Find(channel)
+	return ok, a.PublishAt, a.UnpublishAt
+}
+
+// Equal reports whether as and other publish to the same channels with the
+// same windows, in any order.
+func (as Audiences) Equal(other Audiences) bool {
+	if len(as) != len(other) {
+		return false
+	}
+	for _, a := range as {
+		b, ok := other.Find(a.Channel)
+		if !ok || !sameTime(a.PublishAt, b.PublishAt) || !sameTime(a.UnpublishAt, b.UnpublishAt) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// Value stores audiences as JSON text in SQL databases.
+func (as Audiences) Value() (driver.Value, error) {
+	b, err := json.Marshal(as)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (as *Audiences) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*as = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, as)
+	case string:
+		return json.Unmarshal([]byte(v), as)
+	}
+	return fmt.Errorf("scan audiences from %T", src)
+}
+
+// GormDataType keeps the column plain text, so GormCandidates can match it
+// with LIKE on every dialect.
+func (Audiences) GormDataType() string {
+	return "text"
+}
+
+// MongoFilter adds the conditions for channel being visible at now to
+// filter and returns it.
+func MongoFilter(filter bson.M, channel string, now time.Time) bson.M {
+	filter["audiences"] = bson.M{"$elemMatch": bson.M{
+		"channel": channel,
+		"$and": bson.A{
+			bson.M{"$or": bson.A{bson.M{"publish_at": nil}, bson.M{"publish_at": bson.M{"$lte": now}}}},
+			bson.M{"$or": bson.A{bson.M{"unpublish_at": nil}, bson.M{"unpublish_at": bson.M{"$gt": now}}}},
+		},
+	}}
+	return filter
+}
+
+// GormCandidates restricts db to rows listing channel in their JSON
+// audiences column. Windows cannot be compared portably inside JSON, so
+// callers check VisibleOn on the result.
+func GormCandidates(db *gorm.DB, channel string) *gorm.DB {
+	return db.Where("audiences LIKE ?", `%"channel":"`+channel+`"%`)
+}
@@ -0,0 +1,95 @@
+package publish
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVisibleOn(t *testing.T) {
+	now := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+	before, after := now.Add(-time.Minute), now.Add(time.Minute)
+
+	cases := []struct {
+		name      string
+		audiences Audiences
+		want      bool
+	}{
+		{"not listed", Audiences{{Channel: Desktop}}, false},
+		{"no window", Audiences{{Channel: Mobile}}, true},
+		{"not yet", Audiences{{Channel: Mobile, PublishAt: &after}}, false},
+		{"published at now", Audiences{{Channel: Mobile, PublishAt: &now}}, true},
+		{"withdrawn", Audiences{{Channel: Mobile, UnpublishAt: &before}}, false},
+		{"unpublished at now", Audiences{{Channel: Mobile, UnpublishAt: &now}}, false},
+		{"inside window", Audiences{{Channel: Mobile, PublishAt: &before, UnpublishAt: &after}}, true},
+	}
+	for _, c := range cases {
+		if got := c.audiences.VisibleOn(Mobile, now); got != c.want {
+			t.Errorf("%s: VisibleOn = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	start := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	valid := Audiences{{Channel: Mobile, PublishAt: &start, UnpublishAt: &end}, {Channel: Kiosk, UnpublishAt: &end}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("valid audiences: %v", err)
+	}
+
+	invalid := map[string]Audiences{
+		"unknown channel":  {{Channel: "fax"}},
+		"repeated channel": {{Channel: Mobile}, {Channel: Mobile}},
+		"reversed window":  {{Channel: Mobile, PublishAt: &end, UnpublishAt: &start}},
+		"empty window":     {{Channel: Mobile, PublishAt: &start, UnpublishAt: &start}},
+	}
+	for name, as := range invalid {
+		if err := as.Validate(); err == nil {
+			t.Errorf("%s accepted", name)
+		}
+	}
+}
+
+func TestFlagRoundTrip(t *testing.T) {
+	start := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	as := Audiences(nil).
+		WithFlag(Mobile, true, &start, nil).
+		WithFlag(Desktop, false, nil, nil).
+		WithFlag(Teacher, true, nil, nil)
+	if len(as) != 2 {
+		t.Fatalf("audiences = %+v, want mobile and teacher", as)
+	}
+
+	published, publishAt, unpublishAt := as.Flag(Mobile)
+	if !published || publishAt == nil || !publishAt.Equal(start) || unpublishAt != nil {
+		t.Errorf("Flag(mobile) = %v, %v, %v", published, publishAt, unpublishAt)
+	}
+	if published, _, _ := as.Flag(Desktop); published {
+		t.Error("Flag(desktop) published, want not")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	start := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	local := start.In(time.FixedZone("ICT", 7*3600))
+	later := start.Add(time.Hour)
+
+	a := Audiences{{Channel: Mobile, PublishAt: &start}, {Channel: Kiosk}}
+	if !a.Equal(Audiences{{Channel: Kiosk}, {Channel: Mobile, PublishAt: &local}}) {
+		t.Error("same audiences in another order and zone not equal")
+	}
+
+	different := map[string]Audiences{
+		"missing channel": {{Channel: Mobile, PublishAt: &start}},
+		"other channel":   {{Channel: Mobile, PublishAt: &start}, {Channel: Parent}},
+		"moved window":    {{Channel: Mobile, PublishAt: &later}, {Channel: Kiosk}},
+		"open window":     {{Channel: Mobile}, {Channel: Kiosk}},
+	}
+	for name, b := range different {
+		if a.Equal(b) {
+			t.Errorf("%s: Equal = true", name)
+		}
+	}
+}
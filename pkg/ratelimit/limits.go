@@ -0,0 +1,13 @@
+// Package ratelimit throttles requests per client, token and organization and
+// bounds upload sizes.
+package ratelimit
+
+// Limits is the set of limits the router applies. Zero values disable the
+// corresponding limit.
+type Limits struct {
+	PerIP           Rule
+	PerUser         Rule // counted after authorization verified the user
+	PerOrganization Rule // counted after authorization resolved the organization
+	MaxBodyBytes    int64
+	MaxUploadItems  int // terms or holidays per upload request
+}
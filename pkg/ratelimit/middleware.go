@@ -0,0 +1,141 @@
+package ratelimit
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+
+	"term-service/pkg/helper"
+	"term-service/pkg/zap"
+
+	"github.com/gin-gonic/gin"
+)
+
+// KeyFunc names the bucket a request counts against. An empty key skips the
+// limit for that request.
+type KeyFunc func(c *gin.Context) string
+
+// ByIP buckets requests by client address.
+func ByIP(c *gin.Context) string {
+	return c.ClientIP()
+}
+
+// ByContextValue buckets requests by a string an earlier middleware stored
+// in the gin context, e.g. the organization resolved by authorization.
+func ByContextValue(name string) KeyFunc {
+	return func(c *gin.Context) string {
+		return c.GetString(name)
+	}
+}
+
+// Limit is one rule of a Middleware: Rule applies per Key, and Scope
+// separates its buckets from those of other limits sharing the store.
+type Limit struct {
+	Scope string
+	Rule  Rule
+	Key   KeyFunc
+}
+
+// Middleware rejects requests over any of limits with 429 and a Retry-After
+// header. Limits are checked in order and disabled ones are skipped. If the
+// store fails the request is let through, so an unavailable Redis does not
+// take the API down.
+func Middleware(store Store, limits ...Limit) gin.HandlerFunc {
+	var enabled []Limit
+	for _, l := range limits {
+		if l.Rule.Enabled() {
+			enabled = append(enabled, l)
+		}
+	}
+	if store == nil || len(enabled) == 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		for _, l := range enabled {
+			if !take(c, store, l) {
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// take counts the request against l and reports whether it may proceed,
+// answering 429 when it may not.
+func take(c *gin.Context, store Store, l Limit) bool {
+	k := l.Key(c)
+	if k == "" {
+		return true
+	}
+
+	res, err := store.Take(c.Request.Context(), l.Scope+":"+k, l.Rule)
+	if err != nil {
+		zap.FromContext(c.Request.Context()).Warnw("rate limit store unavailable, allowing request",
+			"scope", l.Scope,
+			"error", err,
+		)
+		return true
+	}
+
+	c.Header("X-RateLimit-Limit", strconv.Itoa(l.Rule.Requests))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	if !res.Allowed {
+		c.Header("Retry-After", strconv.Itoa(retryAfterSeconds(res)))
+		helper.SendError(c, http.StatusTooManyRequests, fmt.Errorf("rate limit exceeded (%s)", l.Scope), helper.ErrTooManyRequests)
+		return false
+	}
+	return true
+}
+
+func retryAfterSeconds(res Result) int {
+	seconds := int(math.Ceil(res.ResetAfter.Seconds()))
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
+
+// MaxBodySize rejects request bodies larger than limit bytes with 413.
+// Bodies of unknown length are buffered up to the limit. A limit of zero or
+// less disables the check.
+func MaxBodySize(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limit <= 0 || c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > limit {
+			rejectBody(c, limit)
+			return
+		}
+
+		if c.Request.ContentLength < 0 {
+			body, err := io.ReadAll(io.LimitReader(c.Request.Body, limit+1))
+			if err != nil {
+				helper.SendError(c, http.StatusBadRequest, err, helper.ErrInvalidRequest)
+				c.Abort()
+				return
+			}
+			if int64(len(body)) > limit {
+				rejectBody(c, limit)
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		} else {
+			// guards against a Content-Length smaller than the real body
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		}
+		c.Next()
+	}
+}
+
+func rejectBody(c *gin.Context, limit int64) {
+	helper.SendError(c, http.StatusRequestEntityTooLarge, fmt.Errorf("request body exceeds %d bytes", limit), helper.ErrPayloadTooLarge)
+	c.Abort()
+}
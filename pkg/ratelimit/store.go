@@ -0,0 +1,130 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Rule allows Requests per Window for one key. A rule with no requests is
+// disabled.
+type Rule struct {
+	Requests int
+	Window   time.Duration
+}
+
+func (r Rule) Enabled() bool {
+	return r.Requests > 0 && r.Window > 0
+}
+
+// Result is the outcome of one Take.
+type Result struct {
+	Allowed    bool
+	Remaining  int
+	ResetAfter time.Duration // until the current window ends
+}
+
+// Store counts requests per key in fixed windows.
+type Store interface {
+	Take(ctx context.Context, key string, rule Rule) (Result, error)
+}
+
+func result(count int, rule Rule, resetAfter time.Duration) Result {
+	remaining := rule.Requests - count
+	if remaining < 0 {
+		remaining = 0
+	}
+	return Result{Allowed: count <= rule.Requests, Remaining: remaining, ResetAfter: resetAfter}
+}
+
+// ---- memory ----
+
+type window struct {
+	count   int
+	expires time.Time
+}
+
+type memoryStore struct {
+	mu        sync.Mutex
+	windows   map[string]*window
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+// sweepEvery bounds how often expired windows are dropped from memory.
+const sweepEvery = time.Minute
+
+// NewMemoryStore keeps counters in process. Limits are per replica, so use
+// the Redis store when running more than one instance.
+func NewMemoryStore() Store {
+	return newMemoryStore(time.Now)
+}
+
+func newMemoryStore(now func() time.Time) *memoryStore {
+	return &memoryStore{windows: map[string]*window{}, now: now, lastSweep: now()}
+}
+
+func (s *memoryStore) Take(_ context.Context, key string, rule Rule) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastSweep) >= sweepEvery {
+		for k, w := range s.windows {
+			if !now.Before(w.expires) {
+				delete(s.windows, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	w, ok := s.windows[key]
+	if !ok || !now.Before(w.expires) {
+		w = &window{expires: now.Add(rule.Window)}
+		s.windows[key] = w
+	}
+	w.count++
+	return result(w.count, rule, w.expires.Sub(now)), nil
+}
+
+// ---- redis ----
+
+// takeScript increments the window counter, starting its expiry on the first
+// hit, and returns the count and the milliseconds left in the window.
+var takeScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl < 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+	ttl = tonumber(ARGV[1])
+end
+return {count, ttl}
+`)
+
+type redisStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisStore shares counters between replicas. Keys are namespaced with
+// prefix.
+func NewRedisStore(client redis.UniversalClient, prefix string) Store {
+	return &redisStore{client: client, prefix: prefix}
+}
+
+func (s *redisStore) Take(ctx context.Context, key string, rule Rule) (Result, error) {
+	res, err := takeScript.Run(ctx, s.client, []string{s.prefix + key}, rule.Window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("redis rate limit %s: %w", key, err)
+	}
+	if len(res) != 2 {
+		return Result{}, fmt.Errorf("redis rate limit %s: unexpected reply %v", key, res)
+	}
+	return result(int(res[0]), rule, time.Duration(res[1])*time.Millisecond), nil
+}
@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestMemoryStoreFixedWindow(t *testing.T) {
+	now := time.Date(2025, 1, 6, 8, 0, 0, 0, time.UTC)
+	store := newMemoryStore(func() time.Time { return now })
+	rule := Rule{Requests: 2, Window: time.Minute}
+	ctx := context.Background()
+
+	for i, want := range []bool{true, true, false} {
+		res, _ := store.Take(ctx, "k", rule)
+		if res.Allowed != want {
+			t.Fatalf("take %d allowed = %v, want %v", i+1, res.Allowed, want)
+		}
+	}
+
+	now = now.Add(40 * time.Second)
+	res, _ := store.Take(ctx, "k", rule)
+	if res.Allowed || res.ResetAfter != 20*time.Second {
+		t.Fatalf("mid-window result = %+v, want denied with 20s left", res)
+	}
+	if res, _ := store.Take(ctx, "other", rule); !res.Allowed || res.Remaining != 1 {
+		t.Fatalf("other key result = %+v, want allowed with 1 remaining", res)
+	}
+
+	now = now.Add(20 * time.Second)
+	if res, _ := store.Take(ctx, "k", rule); !res.Allowed {
+		t.Fatalf("next window result = %+v, want allowed", res)
+	}
+
+	now = now.Add(2 * sweepEvery)
+	store.Take(ctx, "k", rule)
+	if len(store.windows) != 1 {
+		t.Fatalf("windows after sweep = %d, want 1", len(store.windows))
+	}
+}
+
+// TestRedisStore runs against the server in TERM_SERVICE_TEST_REDIS_ADDR.
+func TestRedisStore(t *testing.T) {
+	addr := os.Getenv("TERM_SERVICE_TEST_REDIS_ADDR")
+	if addr == "" {
+		t.Skip("TERM_SERVICE_TEST_REDIS_ADDR not set")
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	defer client.Close()
+
+	ctx := context.Background()
+	prefix := "term-service-test:" + time.Now().Format(time.RFC3339Nano) + ":"
+	store := NewRedisStore(client, prefix)
+	rule := Rule{Requests: 2, Window: time.Minute}
+
+	for i, want := range []bool{true, true, false} {
+		res, err := store.Take(ctx, "k", rule)
+		if err != nil {
+			t.Fatalf("take %d: %v", i+1, err)
+		}
+		if res.Allowed != want {
+			t.Fatalf("take %d allowed = %v, want %v", i+1, res.Allowed, want)
+		}
+		if res.ResetAfter <= 0 || res.ResetAfter > time.Minute {
+			t.Fatalf("take %d reset after = %v", i+1, res.ResetAfter)
+		}
+	}
+	client.Del(ctx, prefix+"k")
+}
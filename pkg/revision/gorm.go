@@ -0,0 +1,63 @@
+package revision
+
+import (
+	"context"
+	"time"
+
+	"term-service/pkg/db/txn"
+
+	"gorm.io/gorm"
+)
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore returns a Store backed by the revisions table. Append joins
+// the transaction carried by ctx (txn.Gorm).
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+func (s *gormStore) Append(ctx context.Context, revisions ...*Revision) error {
+	if len(revisions) == 0 {
+		return nil
+	}
+	return txn.Gorm(ctx, s.db).Create(revisions).Error
+}
+
+// AsOf ranks each record's revisions in the database and reads only the
+// newest of each, so the cost follows the number of records rather than the
+// length of their history.
+func (s *gormStore) AsOf(ctx context.Context, entityType, organizationID string, at time.Time) ([]Revision, error) {
+	db := txn.Gorm(ctx, s.db)
+	ranked := db.Model(&Revision{}).
+		Select("*, ROW_NUMBER() OVER (PARTITION BY entity_id ORDER BY recorded_at DESC, version DESC) AS rn").
+		Where("entity_type = ? AND organization_id = ? AND recorded_at <= ?", entityType, organizationID, at.UTC())
+
+	var revs []Revision
+	err := db.Table("(?) AS ranked", ranked).
+		Where("rn = 1 AND deleted = ?", false).
+		Order("recorded_at, version").
+		Find(&revs).Error
+	if err != nil {
+		return nil, err
+	}
+	return revs, nil
+}
+
+func (s *gormStore) Get(ctx context.Context, entityType, entityID string, at time.Time) (*Revision, error) {
+	var revs []Revision
+	err := txn.Gorm(ctx, s.db).
+		Where("entity_type = ? AND entity_id = ? AND recorded_at <= ?", entityType, entityID, at.UTC()).
+		Order("recorded_at DESC, version DESC").
+		Limit(1).
+		Find(&revs).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(revs) == 0 || revs[0].Deleted {
+		return nil, nil
+	}
+	return &revs[0], nil
+}
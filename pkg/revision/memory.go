@@ -0,0 +1,62 @@
+package revision
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore is a Store kept in process memory, for tests and local runs
+// without a database.
+type MemoryStore struct {
+	mu        sync.Mutex
+	revisions []Revision
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// Revisions returns every revision in the order they were recorded.
+func (s *MemoryStore) Revisions() []Revision {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	revs := append([]Revision(nil), s.revisions...)
+	sortRecorded(revs)
+	return revs
+}
+
+func (s *MemoryStore) Append(ctx context.Context, revisions ...*Revision) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range revisions {
+		s.revisions = append(s.revisions, *r)
+	}
+	return nil
+}
+
+func (s *MemoryStore) AsOf(ctx context.Context, entityType, organizationID string, at time.Time) ([]Revision, error) {
+	var revs []Revision
+	for _, r := range s.Revisions() {
+		if r.EntityType == entityType && r.OrganizationID == organizationID && !r.RecordedAt.After(at) {
+			revs = append(revs, r)
+		}
+	}
+	return latest(revs), nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, entityType, entityID string, at time.Time) (*Revision, error) {
+	var found *Revision
+	for _, r := range s.Revisions() {
+		if r.EntityType == entityType && r.EntityID == entityID && !r.RecordedAt.After(at) {
+			r := r
+			found = &r
+		}
+	}
+	if found == nil || found.Deleted {
+		return nil, nil
+	}
+	return found, nil
+}
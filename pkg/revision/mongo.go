@@ -0,0 +1,79 @@
+package revision
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoStore returns a Store backed by collection. Append joins the
+// transaction of a mongo.SessionContext.
+func NewMongoStore(collection *mongo.Collection) Store {
+	return &mongoStore{collection: collection}
+}
+
+func (s *mongoStore) Append(ctx context.Context, revisions ...*Revision) error {
+	if len(revisions) == 0 {
+		return nil
+	}
+	docs := make([]interface{}, len(revisions))
+	for i, r := range revisions {
+		docs[i] = r
+	}
+	_, err := s.collection.InsertMany(ctx, docs)
+	return err
+}
+
+// AsOf keeps the newest revision of each record in the aggregation, so only
+// one document per record leaves the server.
+func (s *mongoStore) AsOf(ctx context.Context, entityType, organizationID string, at time.Time) ([]Revision, error) {
+	cursor, err := s.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"entity_type":     entityType,
+			"organization_id": organizationID,
+			"recorded_at":     bson.M{"$lte": at},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "entity_id", Value: 1}, {Key: "recorded_at", Value: -1}, {Key: "version", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$entity_id", "revision": bson.M{"$first": "$$ROOT"}}}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$revision"}}},
+		{{Key: "$match", Value: bson.M{"deleted": false}}},
+		{{Key: "$sort", Value: bson.D{{Key: "recorded_at", Value: 1}, {Key: "version", Value: 1}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	revs := []Revision{}
+	if err := cursor.All(ctx, &revs); err != nil {
+		return nil, err
+	}
+	return revs, nil
+}
+
+func (s *mongoStore) Get(ctx context.Context, entityType, entityID string, at time.Time) (*Revision, error) {
+	var r Revision
+	err := s.collection.FindOne(ctx, bson.M{
+		"entity_type": entityType,
+		"entity_id":   entityID,
+		"recorded_at": bson.M{"$lte": at},
+	}, options.FindOne().SetSort(bson.D{{Key: "recorded_at", Value: -1}, {Key: "version", Value: -1}})).Decode(&r)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if r.Deleted {
+		return nil, nil
+	}
+	return &r, nil
+}
@@ -0,0 +1,118 @@
+// Package revision keeps an append-only history of terms and holidays so
+// the calendar can be read as it was at any past moment. Every change adds a
+// revision holding a full snapshot of the record; revisions are never
+// updated or deleted.
+package revision
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"term-service/pkg/objectid"
+)
+
+// Entity types with a history.
+const (
+	Term    = "term"
+	Holiday = "holiday"
+)
+
+// Revision is the state of one record from RecordedAt until its next
+// revision.
+type Revision struct {
+	ID             objectid.ID `bson:"_id" gorm:"primaryKey;size:24"`
+	EntityType     string      `bson:"entity_type" gorm:"size:32;index:idx_revisions_org,priority:1;index:idx_revisions_entity,priority:1"`
+	EntityID       string      `bson:"entity_id" gorm:"size:64;index:idx_revisions_entity,priority:2"`
+	OrganizationID string      `bson:"organization_id" gorm:"size:64;index:idx_revisions_org,priority:2"`
+	// Version is the record's version; a deletion takes the version after
+	// the last one, so revisions of a record sort by it.
+	Version    int64     `bson:"version"`
+	Deleted    bool      `bson:"deleted"`
+	Snapshot   []byte    `bson:"snapshot"` // the record as JSON
+	RecordedAt time.Time `bson:"recorded_at" gorm:"index:idx_revisions_org,priority:3"`
+}
+
+func (Revision) TableName() string {
+	return "revisions"
+}
+
+// New builds a revision of record, taken now.
+func New(entityType, entityID, organizationID string, version int64, deleted bool, record interface{}) (*Revision, error) {
+	snapshot, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s %s snapshot: %w", entityType, entityID, err)
+	}
+	return &Revision{
+		ID:             objectid.New(),
+		EntityType:     entityType,
+		EntityID:       entityID,
+		OrganizationID: organizationID,
+		Version:        version,
+		Deleted:        deleted,
+		Snapshot:       snapshot,
+		RecordedAt:     time.Now().UTC(),
+	}, nil
+}
+
+// Baseline is the first revision of a record that predates revisions: its
+// current state, dated at its last update (updatedAt), the earliest moment
+// that state is known to hold. Earlier states were not kept, so the record
+// is unknown before then. A zero updatedAt dates it now.
+func Baseline(entityType, entityID, organizationID string, version int64, updatedAt time.Time, record interface{}) (*Revision, error) {
+	r, err := New(entityType, entityID, organizationID, version, false, record)
+	if err != nil {
+		return nil, err
+	}
+	if !updatedAt.IsZero() {
+		r.RecordedAt = updatedAt.UTC()
+	}
+	return r, nil
+}
+
+// Decode unmarshals the snapshot into record.
+func (r *Revision) Decode(record interface{}) error {
+	if err := json.Unmarshal(r.Snapshot, record); err != nil {
+		return fmt.Errorf("decode %s %s revision %d: %w", r.EntityType, r.EntityID, r.Version, err)
+	}
+	return nil
+}
+
+// Store keeps revisions. Append called with a transaction context (see
+// txn.Transactor) commits or rolls back with the change.
+type Store interface {
+	Append(ctx context.Context, revisions ...*Revision) error
+	// AsOf returns, for every record of entityType in organizationID that
+	// existed at at, its revision current at that moment.
+	AsOf(ctx context.Context, entityType, organizationID string, at time.Time) ([]Revision, error)
+	// Get returns the revision of one record current at at, or nil when
+	// the record did not exist then.
+	Get(ctx context.Context, entityType, entityID string, at time.Time) (*Revision, error)
+}
+
+// latest keeps the last revision of each record from revs, which must be
+// in recorded order, and drops records whose last revision is a deletion.
+func latest(revs []Revision) []Revision {
+	last := make(map[string]int, len(revs))
+	for i, r := range revs {
+		last[r.EntityID] = i
+	}
+	result := make([]Revision, 0, len(last))
+	for i, r := range revs {
+		if last[r.EntityID] == i && !r.Deleted {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+func sortRecorded(revs []Revision) {
+	sort.SliceStable(revs, func(i, j int) bool {
+		if revs[i].RecordedAt.Equal(revs[j].RecordedAt) {
+			return revs[i].Version < revs[j].Version
+		}
+		return revs[i].RecordedAt.Before(revs[j].RecordedAt)
+	})
+}
@@ -0,0 +1,101 @@
+package revision_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"term-service/pkg/db/dbtest"
+	"term-service/pkg/revision"
+)
+
+func TestMemoryStore(t *testing.T) { testStore(t, revision.NewMemoryStore()) }
+
+func TestGormStoreSQLite(t *testing.T) { testStore(t, revision.NewGormStore(dbtest.NewSQLite(t))) }
+
+func TestGormStoreMySQL(t *testing.T) { testStore(t, revision.NewGormStore(dbtest.NewMySQL(t))) }
+
+func TestMongoStore(t *testing.T) {
+	testStore(t, revision.NewMongoStore(dbtest.NewMongo(t).Collection("revisions")))
+}
+
+type record struct {
+	Title string
+}
+
+func newRevision(t *testing.T, id, orgID string, version int64, deleted bool, title string, at time.Time) *revision.Revision {
+	t.Helper()
+	r, err := revision.New(revision.Term, id, orgID, version, deleted, record{Title: title})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	r.RecordedAt = at
+	return r
+}
+
+func titles(t *testing.T, revs []revision.Revision) map[string]string {
+	t.Helper()
+	got := make(map[string]string, len(revs))
+	for _, r := range revs {
+		var rec record
+		if err := r.Decode(&rec); err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		got[r.EntityID] = rec.Title
+	}
+	return got
+}
+
+func testStore(t *testing.T, store revision.Store) {
+	ctx := context.Background()
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	err := store.Append(ctx,
+		newRevision(t, "t1", "org-a", 1, false, "Spring", base),
+		newRevision(t, "t1", "org-a", 2, false, "Spring (revised)", base.Add(2*day)),
+		newRevision(t, "t2", "org-a", 1, false, "Summer", base.Add(day)),
+		newRevision(t, "t2", "org-a", 2, true, "Summer", base.Add(3*day)),
+		newRevision(t, "t3", "org-b", 1, false, "Other", base),
+	)
+	if err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+
+	cases := []struct {
+		at   time.Time
+		want map[string]string
+	}{
+		{base.Add(-time.Second), map[string]string{}},
+		{base, map[string]string{"t1": "Spring"}},
+		{base.Add(day + time.Hour), map[string]string{"t1": "Spring", "t2": "Summer"}},
+		{base.Add(2 * day), map[string]string{"t1": "Spring (revised)", "t2": "Summer"}},
+		{base.Add(4 * day), map[string]string{"t1": "Spring (revised)"}},
+	}
+	for _, tc := range cases {
+		revs, err := store.AsOf(ctx, revision.Term, "org-a", tc.at)
+		if err != nil {
+			t.Fatalf("AsOf(%v): %v", tc.at, err)
+		}
+		got := titles(t, revs)
+		if len(got) != len(tc.want) {
+			t.Fatalf("AsOf(%v) = %v, want %v", tc.at, got, tc.want)
+		}
+		for id, title := range tc.want {
+			if got[id] != title {
+				t.Fatalf("AsOf(%v) = %v, want %v", tc.at, got, tc.want)
+			}
+		}
+	}
+
+	r, err := store.Get(ctx, revision.Term, "t1", base.Add(day))
+	if err != nil || r == nil || r.Version != 1 {
+		t.Fatalf("Get(t1, day 1) = %+v, %v, want version 1", r, err)
+	}
+	if r, err := store.Get(ctx, revision.Term, "t2", base.Add(3*day)); err != nil || r != nil {
+		t.Fatalf("Get(deleted t2) = %+v, %v, want none", r, err)
+	}
+	if r, err := store.Get(ctx, revision.Holiday, "t1", base.Add(day)); err != nil || r != nil {
+		t.Fatalf("Get(other entity type) = %+v, %v, want none", r, err)
+	}
+}
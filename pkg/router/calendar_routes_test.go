@@ -0,0 +1,206 @@
+package router_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	holiday_model "term-service/internal/holiday/model"
+	"term-service/internal/term/model"
+	"term-service/pkg/objectid"
+	"term-service/pkg/publish"
+)
+
+func day(month time.Month, d int) time.Time {
+	return time.Date(2025, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestTermWeeks(t *testing.T) {
+	s := newTestServer(t)
+	term := s.seedTerm(&model.Term{OrganizationID: orgA, Title: "Spring", Audiences: publish.Audiences{{Channel: publish.Desktop}}, StartDate: day(1, 6), EndDate: day(2, 28), Periods: model.Periods{
+		{ID: "p1", Type: model.PeriodBreak, Title: "Tet", StartDate: day(1, 27), EndDate: day(2, 2)},
+		{ID: "p2", Type: model.PeriodExam, Title: "Finals", StartDate: day(2, 24), EndDate: day(2, 28)},
+	}})
+	if _, err := s.holidays.Create(context.Background(), &holiday_model.Holiday{OrganizationID: orgA, Title: "Founders", Audiences: publish.Audiences{{Channel: publish.Desktop}}, StartDate: day(2, 10), EndDate: day(2, 11)}); err != nil {
+		t.Fatalf("seed holiday: %v", err)
+	}
+	// not published yet: only admins plan with it
+	if _, err := s.holidays.Create(context.Background(), &holiday_model.Holiday{OrganizationID: orgA, Title: "Staff day", StartDate: day(2, 5), EndDate: day(2, 5)}); err != nil {
+		t.Fatalf("seed holiday: %v", err)
+	}
+
+	code, res := s.do(http.MethodGet, "/api/v1/terms/"+term.ID.Hex()+"/weeks", s.memberToken, nil)
+	if code != http.StatusOK {
+		t.Fatalf("status = %d, body %+v", code, res)
+	}
+	var body struct {
+		Weeks []struct {
+			Number            int    `json:"number"`
+			StartDate         string `json:"start_date"`
+			EndDate           string `json:"end_date"`
+			InstructionalDays int    `json:"instructional_days"`
+			Holidays          []struct {
+				Title string `json:"title"`
+			} `json:"holidays"`
+			Periods []periodRes `json:"periods"`
+		} `json:"weeks"`
+	}
+	s.decode(res, &body)
+
+	want := []struct {
+		number, days     int
+		start, end       string
+		holidays, period int
+	}{
+		{1, 5, "2025-01-06", "2025-01-12", 0, 0},
+		{2, 5, "2025-01-13", "2025-01-19", 0, 0},
+		{3, 5, "2025-01-20", "2025-01-26", 0, 0},
+		{0, 0, "2025-01-27", "2025-02-02", 0, 1},
+		{4, 5, "2025-02-03", "2025-02-09", 0, 0},
+		{5, 3, "2025-02-10", "2025-02-16", 1, 0},
+		{6, 5, "2025-02-17", "2025-02-23", 0, 0},
+		{7, 5, "2025-02-24", "2025-02-28", 0, 1},
+	}
+	if len(body.Weeks) != len(want) {
+		t.Fatalf("weeks = %+v, want %d", body.Weeks, len(want))
+	}
+	for i, w := range want {
+		got := body.Weeks[i]
+		if got.Number != w.number || got.InstructionalDays != w.days || got.StartDate != w.start || got.EndDate != w.end ||
+			len(got.Holidays) != w.holidays || len(got.Periods) != w.period {
+			t.Errorf("week %d = %+v, want %+v", i, got, w)
+		}
+	}
+
+	code, res = s.do(http.MethodGet, "/api/v1/terms/"+term.ID.Hex()+"/weeks", s.orgAdminToken, nil)
+	body.Weeks = nil
+	s.decode(res, &body)
+	if code != http.StatusOK || len(body.Weeks[4].Holidays) != 1 || body.Weeks[4].InstructionalDays != 4 {
+		t.Fatalf("admin week 4 = %d %+v, want the unpublished holiday counted", code, body.Weeks[4])
+	}
+
+	// a term not published on the channel, or in another organization, is
+	// indistinguishable from one that does not exist
+	for name, path := range map[string]string{
+		"other channel":      "/api/v1/terms/" + term.ID.Hex() + "/weeks?channel=mobile",
+		"unknown term":       "/api/v1/terms/" + objectid.New().Hex() + "/weeks",
+		"other organization": "/api/v1/terms/" + s.seedTerm(&model.Term{OrganizationID: orgB, Title: "B", Audiences: publish.Audiences{{Channel: publish.Desktop}}, StartDate: day(1, 6), EndDate: day(2, 28)}).ID.Hex() + "/weeks",
+	} {
+		if code, _ := s.do(http.MethodGet, path, s.memberToken, nil); code != http.StatusNotFound {
+			t.Errorf("%s status = %d, want %d", name, code, http.StatusNotFound)
+		}
+	}
+	if code, _ := s.do(http.MethodGet, "/api/v1/terms/"+term.ID.Hex()+"/weeks", s.orgBAdminToken, nil); code != http.StatusNotFound {
+		t.Fatalf("other organization admin status = %d, want %d", code, http.StatusNotFound)
+	}
+	if code, _ := s.do(http.MethodGet, "/api/v1/terms/"+term.ID.Hex()+"/weeks?channel=fax", s.memberToken, nil); code != http.StatusBadRequest {
+		t.Fatalf("unknown channel status = %d, want %d", code, http.StatusBadRequest)
+	}
+}
+
+type dateRes struct {
+	OrganizationID string `json:"organization_id"`
+	Date           string `json:"date"`
+	Status         string `json:"status"`
+	Week           int    `json:"week"`
+	Term           *struct {
+		ID string `json:"id"`
+	} `json:"term"`
+	PreviousTerm *struct {
+		ID string `json:"id"`
+	} `json:"previous_term"`
+	NextTerm *struct {
+		ID string `json:"id"`
+	} `json:"next_term"`
+	Holidays []struct {
+		Title string `json:"title"`
+	} `json:"holidays"`
+}
+
+func TestResolveDates(t *testing.T) {
+	s := newTestServer(t)
+	spring := s.seedTerm(&model.Term{OrganizationID: orgA, Title: "Spring", StartDate: day(1, 6), EndDate: day(3, 28), Periods: model.Periods{
+		{ID: "p1", Type: model.PeriodBreak, Title: "Mid-term", StartDate: day(2, 17), EndDate: day(2, 21)},
+	}})
+	autumn := s.seedTerm(&model.Term{OrganizationID: orgA, Title: "Autumn", StartDate: day(9, 1), EndDate: day(12, 19)})
+	if _, err := s.holidays.Create(context.Background(), &holiday_model.Holiday{OrganizationID: orgA, Title: "Tet", StartDate: day(1, 29), EndDate: day(1, 29)}); err != nil {
+		t.Fatalf("seed holiday: %v", err)
+	}
+
+	cases := []struct {
+		date, status   string
+		term, previous string
+		next           string
+		week           int
+	}{
+		{"2025-01-13", "in_term", spring.ID.Hex(), "", "", 2},
+		{"2025-01-29", "holiday", spring.ID.Hex(), "", "", 4},
+		{"2025-02-18", "break", spring.ID.Hex(), "", "", 0},
+		{"2025-06-01", "between_terms", "", spring.ID.Hex(), autumn.ID.Hex(), 0},
+		{"2024-12-01", "no_term", "", "", spring.ID.Hex(), 0},
+	}
+	id := func(ref *struct {
+		ID string `json:"id"`
+	}) string {
+		if ref == nil {
+			return ""
+		}
+		return ref.ID
+	}
+	for _, tc := range cases {
+		t.Run(tc.date, func(t *testing.T) {
+			code, res := s.do(http.MethodGet, "/api/v1/gateway/calendar/dates/"+tc.date+"?organization_id="+orgA, s.serviceToken, nil)
+			if code != http.StatusOK {
+				t.Fatalf("status = %d, body %+v", code, res)
+			}
+			var got dateRes
+			s.decode(res, &got)
+			if got.Status != tc.status || id(got.Term) != tc.term || id(got.PreviousTerm) != tc.previous || id(got.NextTerm) != tc.next || got.Week != tc.week {
+				t.Fatalf("resolved %+v, want %+v", got, tc)
+			}
+		})
+	}
+
+	code, res := s.do(http.MethodPost, "/api/v1/gateway/calendar/dates", s.serviceToken, map[string]interface{}{
+		"organization_id": orgA,
+		"lookups": []map[string]interface{}{
+			{"date": "2025-01-29"},
+			{"organization_id": orgB, "date": "2025-01-29"},
+			{"date": "2025-09-02"},
+		},
+	})
+	if code != http.StatusOK {
+		t.Fatalf("batch status = %d, body %+v", code, res)
+	}
+	var batch struct {
+		Dates []dateRes `json:"dates"`
+	}
+	s.decode(res, &batch)
+	if len(batch.Dates) != 3 || batch.Dates[0].Status != "holiday" || len(batch.Dates[0].Holidays) != 1 ||
+		batch.Dates[1].OrganizationID != orgB || batch.Dates[1].Status != "no_term" ||
+		batch.Dates[2].Status != "in_term" || id(batch.Dates[2].Term) != autumn.ID.Hex() {
+		t.Fatalf("batch = %+v", batch.Dates)
+	}
+
+	invalid := []struct {
+		name, method, path string
+		body               interface{}
+	}{
+		{"missing organization", http.MethodGet, "/api/v1/gateway/calendar/dates/2025-01-13", nil},
+		{"malformed date", http.MethodGet, "/api/v1/gateway/calendar/dates/13-01-2025?organization_id=" + orgA, nil},
+		{"empty batch", http.MethodPost, "/api/v1/gateway/calendar/dates", map[string]interface{}{"organization_id": orgA, "lookups": []interface{}{}}},
+		{"batch without organization", http.MethodPost, "/api/v1/gateway/calendar/dates", map[string]interface{}{"lookups": []map[string]interface{}{{"date": "2025-01-13"}}}},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			if code, _ := s.do(tc.method, tc.path, s.serviceToken, tc.body); code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d", code, http.StatusBadRequest)
+			}
+		})
+	}
+
+	if code, _ := s.do(http.MethodGet, "/api/v1/gateway/calendar/dates/2025-01-13?organization_id="+orgA, s.memberToken, nil); code != http.StatusUnauthorized {
+		t.Fatalf("user token status = %d, want %d", code, http.StatusUnauthorized)
+	}
+}
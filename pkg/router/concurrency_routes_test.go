@@ -0,0 +1,137 @@
+package router_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	holiday_model "term-service/internal/holiday/model"
+	"term-service/internal/term/model"
+)
+
+func TestUploadTermsVersionConflict(t *testing.T) {
+	s := newTestServer(t)
+	term := s.seedTerm(&model.Term{OrganizationID: orgA, Title: "Spring", Color: "#0f0", StartDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC)})
+	edit := func(title string, version int64) map[string]interface{} {
+		item := map[string]interface{}{"id": term.ID.Hex(), "title": title, "color": "#0f0", "start_date": "2025-01-06", "end_date": "2025-03-28"}
+		if version > 0 {
+			item["version"] = version
+		}
+		return uploadTermsBody(item)
+	}
+
+	if code, res := s.do(http.MethodPost, "/api/v1/admin/terms", s.orgAdminToken, edit("Spring A", 1)); code != http.StatusOK {
+		t.Fatalf("first edit status = %d, body %+v", code, res)
+	}
+
+	// the second admin still holds version 1
+	code, res := s.do(http.MethodPost, "/api/v1/admin/terms", s.orgAdminToken, edit("Spring B", 1))
+	if code != http.StatusConflict {
+		t.Fatalf("stale edit status = %d, want %d, body %+v", code, http.StatusConflict, res)
+	}
+	var current struct {
+		Title   string `json:"title"`
+		Version int64  `json:"version"`
+	}
+	s.decode(res, &current)
+	if current.Title != "Spring A" || current.Version != 2 {
+		t.Fatalf("conflict returned %+v, want the stored term at version 2", current)
+	}
+
+	if code, _ := s.do(http.MethodPost, "/api/v1/admin/terms", s.orgAdminToken, edit("Spring B", 0)); code != http.StatusPreconditionRequired {
+		t.Fatalf("edit without version status = %d, want %d", code, http.StatusPreconditionRequired)
+	}
+
+	// If-Match stands in for the body version on single-item updates
+	b, _ := json.Marshal(edit("Spring B", 0))
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/terms", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.orgAdminToken)
+	req.Header.Set("If-Match", `"2"`)
+	rec := httptest.NewRecorder()
+	s.engine.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("If-Match edit status = %d, body %s", rec.Code, rec.Body)
+	}
+
+	stored, _ := s.terms.GetByID(context.Background(), term.ID.Hex())
+	if stored.Title != "Spring B" || stored.Version != 3 {
+		t.Fatalf("stored term = %+v, want Spring B at version 3", stored)
+	}
+}
+
+func TestUploadHolidaysVersionConflict(t *testing.T) {
+	s := newTestServer(t)
+	holiday, err := s.holidays.Create(context.Background(), &holiday_model.Holiday{OrganizationID: orgA, Title: "Tet", Color: "#f00", StartDate: time.Date(2025, 1, 27, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 2, 2, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("seed holiday: %v", err)
+	}
+	edit := func(title string, version int64) map[string]interface{} {
+		return map[string]interface{}{
+			"language_id": 1,
+			"holidays": []map[string]interface{}{
+				{"id": holiday.ID.Hex(), "version": version, "title": title, "color": "#f00", "start_date": "2025-01-27", "end_date": "2025-02-02"},
+			},
+		}
+	}
+
+	if code, res := s.do(http.MethodPost, "/api/v1/admin/holidays", s.orgAdminToken, edit("Lunar New Year", 1)); code != http.StatusOK {
+		t.Fatalf("first edit status = %d, body %+v", code, res)
+	}
+	if code, _ := s.do(http.MethodPost, "/api/v1/admin/holidays", s.orgAdminToken, edit("Tết", 1)); code != http.StatusConflict {
+		t.Fatalf("stale edit status = %d, want %d", code, http.StatusConflict)
+	}
+}
+
+func TestUploadTermsStaleItemWritesNothing(t *testing.T) {
+	s := newTestServer(t)
+	fresh := s.seedTerm(&model.Term{OrganizationID: orgA, Title: "Spring", Color: "#0f0", StartDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC)})
+	stale := s.seedTerm(&model.Term{OrganizationID: orgA, Title: "Summer", Color: "#ff0", StartDate: time.Date(2025, 4, 7, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 6, 27, 0, 0, 0, 0, time.UTC)})
+
+	code, res := s.do(http.MethodPost, "/api/v1/admin/terms", s.orgAdminToken, uploadTermsBody(
+		map[string]interface{}{"title": "Autumn", "color": "#f80", "start_date": "2025-09-01", "end_date": "2025-12-19"},
+		map[string]interface{}{"id": fresh.ID.Hex(), "version": 1, "title": "Spring A", "color": "#0f0", "start_date": "2025-01-06", "end_date": "2025-03-28"},
+		map[string]interface{}{"id": stale.ID.Hex(), "version": 7, "title": "Summer A", "color": "#ff0", "start_date": "2025-04-07", "end_date": "2025-06-27"},
+	))
+	if code != http.StatusConflict {
+		t.Fatalf("upload status = %d, want %d, body %+v", code, http.StatusConflict, res)
+	}
+
+	terms := s.orgTerms(orgA)
+	if len(terms) != 2 {
+		t.Fatalf("terms after rejected upload = %d, want the 2 seeded", len(terms))
+	}
+	stored, _ := s.terms.GetByID(context.Background(), fresh.ID.Hex())
+	if stored.Title != "Spring" || stored.Version != 1 {
+		t.Fatalf("first item = %+v, want it unchanged", stored)
+	}
+	if events, _ := s.outbox.Claim(context.Background(), 10, time.Minute); len(events) != 0 {
+		t.Fatalf("rejected upload recorded %d events", len(events))
+	}
+}
+
+func TestUploadHolidaysStaleItemKeepsDeletes(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+	doomed, _ := s.holidays.Create(ctx, &holiday_model.Holiday{OrganizationID: orgA, Title: "Labour Day", Color: "#00f", StartDate: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)})
+	stale, _ := s.holidays.Create(ctx, &holiday_model.Holiday{OrganizationID: orgA, Title: "Tet", Color: "#f00", StartDate: time.Date(2025, 1, 27, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 2, 2, 0, 0, 0, 0, time.UTC)})
+
+	code, res := s.do(http.MethodPost, "/api/v1/admin/holidays", s.orgAdminToken, map[string]interface{}{
+		"language_id": 1,
+		"delete_ids":  []string{doomed.ID.Hex()},
+		"holidays": []map[string]interface{}{
+			{"id": stale.ID.Hex(), "version": 3, "title": "Lunar New Year", "color": "#f00", "start_date": "2025-01-27", "end_date": "2025-02-02"},
+		},
+	})
+	if code != http.StatusConflict {
+		t.Fatalf("upload status = %d, want %d, body %+v", code, http.StatusConflict, res)
+	}
+
+	if _, err := s.holidays.GetByID(ctx, doomed.ID.Hex()); err != nil {
+		t.Fatalf("holiday deleted by rejected upload: %v", err)
+	}
+}
@@ -0,0 +1,270 @@
+package router_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	holiday_model "term-service/internal/holiday/model"
+	"term-service/internal/term/model"
+)
+
+const draftsPath = "/api/v1/admin/calendar/drafts"
+
+type draftRes struct {
+	ID            string `json:"id"`
+	Status        string `json:"status"`
+	ReviewerID    string `json:"reviewer_id"`
+	ReviewComment string `json:"review_comment"`
+	Version       int64  `json:"version"`
+}
+
+type diffRes struct {
+	Terms struct {
+		Added   []struct{ Title string } `json:"added"`
+		Changed []struct {
+			ID     string   `json:"id"`
+			Fields []string `json:"fields"`
+		} `json:"changed"`
+		Removed []struct{ ID string } `json:"removed"`
+	} `json:"terms"`
+	Holidays struct {
+		Removed []struct{ ID string } `json:"removed"`
+	} `json:"holidays"`
+	Stale bool `json:"stale"`
+}
+
+// seedCalendar gives org A a spring term and a Tet holiday.
+func (s *testServer) seedCalendar() (*model.Term, *holiday_model.Holiday) {
+	s.t.Helper()
+	term := s.seedTerm(&model.Term{OrganizationID: orgA, Title: "Spring", Color: "#0f0", StartDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC)})
+	holiday, err := s.holidays.Create(context.Background(), &holiday_model.Holiday{OrganizationID: orgA, Title: "Tet", Color: "#f00", StartDate: time.Date(2025, 1, 27, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 2, 2, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		s.t.Fatalf("seed holiday: %v", err)
+	}
+	return term, holiday
+}
+
+// draftBody renames the spring term, adds an autumn term and drops every
+// holiday.
+func draftBody(springID string) map[string]interface{} {
+	return map[string]interface{}{
+		"language_id": 1,
+		"word":        "Semester",
+		"terms": []map[string]interface{}{
+			{"id": springID, "title": "Spring (revised)", "color": "#0f0", "start_date": "2025-01-06", "end_date": "2025-03-28"},
+			{"title": "Autumn", "color": "#f00", "start_date": "2025-09-01", "end_date": "2025-12-19"},
+		},
+		"holidays": []interface{}{},
+	}
+}
+
+func (s *testServer) draftStep(token, id, step string, want int) draftRes {
+	s.t.Helper()
+	code, res := s.do(http.MethodPost, draftsPath+"/"+id+"/"+step, token, nil)
+	if code != want {
+		s.t.Fatalf("%s status = %d, want %d (body %+v)", step, code, want, res)
+	}
+	var d draftRes
+	if code == http.StatusOK {
+		s.decode(res, &d)
+	}
+	return d
+}
+
+func TestCalendarDraftApproval(t *testing.T) {
+	s := newTestServer(t)
+	spring, tet := s.seedCalendar()
+
+	// A member drafts and submits without naming the organization.
+	code, res := s.do(http.MethodPost, draftsPath, s.memberToken, draftBody(spring.ID.Hex()))
+	if code != http.StatusCreated {
+		t.Fatalf("create status = %d, body %+v", code, res)
+	}
+	var draft draftRes
+	s.decode(res, &draft)
+	if draft.Status != "draft" {
+		t.Fatalf("created draft = %+v", draft)
+	}
+
+	code, res = s.do(http.MethodGet, draftsPath+"/"+draft.ID+"/diff", s.memberToken, nil)
+	if code != http.StatusOK {
+		t.Fatalf("diff status = %d, body %+v", code, res)
+	}
+	var diff diffRes
+	s.decode(res, &diff)
+	if len(diff.Terms.Added) != 1 || diff.Terms.Added[0].Title != "Autumn" ||
+		len(diff.Terms.Changed) != 1 || diff.Terms.Changed[0].ID != spring.ID.Hex() || len(diff.Terms.Changed[0].Fields) != 1 || diff.Terms.Changed[0].Fields[0] != "title" ||
+		len(diff.Terms.Removed) != 0 || len(diff.Holidays.Removed) != 1 || diff.Holidays.Removed[0].ID != tet.ID.Hex() || diff.Stale {
+		t.Fatalf("diff = %+v", diff)
+	}
+
+	if d := s.draftStep(s.memberToken, draft.ID, "submit", http.StatusOK); d.Status != "pending" {
+		t.Fatalf("submitted draft = %+v", d)
+	}
+	code, _ = s.do(http.MethodPut, draftsPath+"/"+draft.ID, s.memberToken, draftBody(spring.ID.Hex()))
+	if code != http.StatusPreconditionRequired {
+		t.Fatalf("update without version status = %d, want %d", code, http.StatusPreconditionRequired)
+	}
+
+	// Nothing is published before approval.
+	if terms := s.orgTerms(orgA); len(terms) != 1 || terms[0].Title != "Spring" {
+		t.Fatalf("live terms before approval = %+v", terms)
+	}
+
+	s.draftStep(s.memberToken, draft.ID, "approve", http.StatusForbidden)
+	s.draftStep(s.orgBAdminToken, draft.ID, "approve", http.StatusNotFound)
+
+	approved := s.draftStep(s.orgAdminToken, draft.ID, "approve", http.StatusOK)
+	if approved.Status != "approved" || approved.ReviewerID != "admin-a" {
+		t.Fatalf("approved draft = %+v", approved)
+	}
+	terms := s.orgTerms(orgA)
+	if len(terms) != 2 || terms[0].ID != spring.ID || terms[0].Title != "Spring (revised)" || terms[1].Title != "Autumn" {
+		t.Fatalf("live terms after approval = %+v", terms)
+	}
+	if holidays, _ := s.holidays.GetAllByOrgID(context.Background(), orgA); len(holidays) != 0 {
+		t.Fatalf("live holidays after approval = %+v", holidays)
+	}
+
+	s.draftStep(s.orgAdminToken, draft.ID, "approve", http.StatusConflict)
+}
+
+func TestCalendarDraftRejectAndStale(t *testing.T) {
+	s := newTestServer(t)
+	spring, _ := s.seedCalendar()
+
+	code, res := s.do(http.MethodPost, draftsPath, s.memberToken, draftBody(spring.ID.Hex()))
+	if code != http.StatusCreated {
+		t.Fatalf("create status = %d, body %+v", code, res)
+	}
+	var draft draftRes
+	s.decode(res, &draft)
+	s.draftStep(s.memberToken, draft.ID, "submit", http.StatusOK)
+
+	code, res = s.do(http.MethodPost, draftsPath+"/"+draft.ID+"/reject", s.orgAdminToken, map[string]string{"comment": "keep Tet"})
+	if code != http.StatusOK {
+		t.Fatalf("reject status = %d, body %+v", code, res)
+	}
+	var rejected draftRes
+	s.decode(res, &rejected)
+	if rejected.Status != "rejected" || rejected.ReviewComment != "keep Tet" {
+		t.Fatalf("rejected draft = %+v", rejected)
+	}
+
+	// The author reworks it and submits again.
+	body := draftBody(spring.ID.Hex())
+	body["version"] = rejected.Version
+	code, res = s.do(http.MethodPut, draftsPath+"/"+draft.ID, s.memberToken, body)
+	if code != http.StatusOK {
+		t.Fatalf("update status = %d, body %+v", code, res)
+	}
+	s.draftStep(s.memberToken, draft.ID, "submit", http.StatusOK)
+
+	// The live calendar changes before the principal gets to it.
+	code, res = s.do(http.MethodPost, "/api/v1/admin/terms", s.orgAdminToken, uploadTermsBody(
+		map[string]interface{}{"id": spring.ID.Hex(), "version": spring.Version, "title": "Spring (direct)", "color": "#0f0", "start_date": "2025-01-06", "end_date": "2025-03-28"},
+	))
+	if code != http.StatusOK {
+		t.Fatalf("direct upload status = %d, body %+v", code, res)
+	}
+
+	code, res = s.do(http.MethodPost, draftsPath+"/"+draft.ID+"/approve", s.orgAdminToken, nil)
+	if code != http.StatusConflict {
+		t.Fatalf("stale approve status = %d, body %+v", code, res)
+	}
+	var diff diffRes
+	s.decode(res, &diff)
+	if !diff.Stale {
+		t.Fatalf("conflict diff = %+v, want stale", diff)
+	}
+	if terms := s.orgTerms(orgA); len(terms) != 1 || terms[0].Title != "Spring (direct)" {
+		t.Fatalf("live terms after stale approve = %+v", terms)
+	}
+
+	code, res = s.do(http.MethodGet, draftsPath+"?status=pending", s.orgAdminToken, nil)
+	var pending []draftRes
+	if s.decode(res, &pending); code != http.StatusOK || len(pending) != 1 || pending[0].ID != draft.ID {
+		t.Fatalf("pending drafts = %d %+v", code, pending)
+	}
+}
+
+func TestCalendarDraftPeriods(t *testing.T) {
+	s := newTestServer(t)
+	spring, _ := s.seedCalendar()
+
+	body := func(periods ...map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"language_id": 1,
+			"word":        "Semester",
+			"terms": []map[string]interface{}{
+				{"id": spring.ID.Hex(), "title": "Spring", "color": "#0f0", "start_date": "2025-01-06", "end_date": "2025-03-28", "periods": periods},
+			},
+			"holidays": []map[string]interface{}{{"title": "Tet", "color": "#f00", "start_date": "2025-01-27", "end_date": "2025-02-02"}},
+		}
+	}
+
+	code, _ := s.do(http.MethodPost, draftsPath, s.memberToken, body(map[string]interface{}{"type": "exam", "start_date": "2025-04-01", "end_date": "2025-04-04"}))
+	if code != http.StatusBadRequest {
+		t.Fatalf("period outside the term status = %d, want %d", code, http.StatusBadRequest)
+	}
+
+	code, res := s.do(http.MethodPost, draftsPath, s.memberToken, body(map[string]interface{}{"type": "exam", "start_date": "2025-03-17", "end_date": "2025-03-21"}))
+	if code != http.StatusCreated {
+		t.Fatalf("create status = %d, body %+v", code, res)
+	}
+	var draft draftRes
+	s.decode(res, &draft)
+
+	code, res = s.do(http.MethodGet, draftsPath+"/"+draft.ID+"/diff", s.memberToken, nil)
+	var diff diffRes
+	if s.decode(res, &diff); code != http.StatusOK || len(diff.Terms.Changed) != 1 || len(diff.Terms.Changed[0].Fields) != 1 || diff.Terms.Changed[0].Fields[0] != "periods" {
+		t.Fatalf("diff = %d %+v", code, diff)
+	}
+
+	s.draftStep(s.memberToken, draft.ID, "submit", http.StatusOK)
+	s.draftStep(s.orgAdminToken, draft.ID, "approve", http.StatusOK)
+
+	terms := s.orgTerms(orgA)
+	if len(terms) != 1 || len(terms[0].Periods) != 1 || terms[0].Periods[0].Type != model.PeriodExam || terms[0].Periods[0].ID == "" {
+		t.Fatalf("live terms after approval = %+v", terms)
+	}
+}
+
+// Drafts are for members, who cannot write the live calendar. Organization
+// admins, who approve drafts, may still upload directly; a pending draft then
+// turns stale rather than reverting their change.
+func TestCalendarDirectWritesNeedApprover(t *testing.T) {
+	s := newTestServer(t)
+	spring, _ := s.seedCalendar()
+	holidays := map[string]interface{}{
+		"language_id": 1,
+		"holidays":    []map[string]interface{}{{"title": "Founders' day", "color": "#00f", "start_date": "2025-03-10", "end_date": "2025-03-10"}},
+	}
+
+	if code, _ := s.do(http.MethodPost, "/api/v1/admin/terms", s.memberToken, uploadTermsBody(map[string]interface{}{"title": "Summer", "color": "#ff0", "start_date": "2025-06-02", "end_date": "2025-08-15"})); code != http.StatusForbidden {
+		t.Fatalf("member term upload status = %d, want %d", code, http.StatusForbidden)
+	}
+	if code, _ := s.do(http.MethodPost, "/api/v1/admin/holidays", s.memberToken, holidays); code != http.StatusForbidden {
+		t.Fatalf("member holiday upload status = %d, want %d", code, http.StatusForbidden)
+	}
+
+	code, res := s.do(http.MethodPost, draftsPath, s.memberToken, draftBody(spring.ID.Hex()))
+	if code != http.StatusCreated {
+		t.Fatalf("create status = %d, body %+v", code, res)
+	}
+	var draft draftRes
+	s.decode(res, &draft)
+	s.draftStep(s.memberToken, draft.ID, "submit", http.StatusOK)
+
+	if code, res := s.do(http.MethodPost, "/api/v1/admin/holidays", s.orgAdminToken, holidays); code != http.StatusOK {
+		t.Fatalf("admin holiday upload status = %d, body %+v", code, res)
+	}
+	code, res = s.do(http.MethodGet, draftsPath+"/"+draft.ID+"/diff", s.memberToken, nil)
+	var diff diffRes
+	if s.decode(res, &diff); code != http.StatusOK || !diff.Stale {
+		t.Fatalf("diff after a direct upload = %d %+v, want stale", code, diff)
+	}
+	s.draftStep(s.orgAdminToken, draft.ID, "approve", http.StatusConflict)
+}
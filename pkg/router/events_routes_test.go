@@ -0,0 +1,89 @@
+package router_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// eventTypes lists the types recorded in the outbox, oldest first.
+func (s *testServer) eventTypes() []string {
+	var types []string
+	for _, e := range s.outbox.Events() {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+func TestTermUploadsRecordEvents(t *testing.T) {
+	s := newTestServer(t)
+
+	if code, res := s.do(http.MethodPost, "/api/v1/admin/terms", s.orgAdminToken, uploadTermsBody(
+		map[string]interface{}{"title": "Spring", "color": "#0f0", "start_date": "2025-01-06", "end_date": "2025-03-28"},
+	)); code != http.StatusOK {
+		t.Fatalf("create status = %d, body %+v", code, res)
+	}
+	term := s.orgTerms(orgA)[0]
+
+	if code, res := s.do(http.MethodPost, "/api/v1/admin/terms", s.orgAdminToken, uploadTermsBody(
+		map[string]interface{}{"id": term.ID.Hex(), "version": term.Version, "title": "Spring (revised)", "color": "#0f0", "start_date": "2025-01-06", "end_date": "2025-04-04"},
+	)); code != http.StatusOK {
+		t.Fatalf("update status = %d, body %+v", code, res)
+	}
+
+	// a stale update changes nothing and records nothing
+	if code, _ := s.do(http.MethodPost, "/api/v1/admin/terms", s.orgAdminToken, uploadTermsBody(
+		map[string]interface{}{"id": term.ID.Hex(), "version": term.Version, "title": "Lost", "color": "#0f0", "start_date": "2025-01-06", "end_date": "2025-04-04"},
+	)); code != http.StatusConflict {
+		t.Fatalf("stale update status = %d, want %d", code, http.StatusConflict)
+	}
+
+	events := s.outbox.Events()
+	if got := s.eventTypes(); len(got) != 2 || got[0] != "term.created" || got[1] != "term.updated" {
+		t.Fatalf("recorded %v, want [term.created term.updated]", got)
+	}
+	updated := events[1]
+	if updated.AggregateID != term.ID.Hex() || updated.OrganizationID != orgA {
+		t.Fatalf("update event = %+v", updated)
+	}
+
+	var data struct {
+		Title   string `json:"title"`
+		EndDate string `json:"end_date"`
+		Version int64  `json:"version"`
+	}
+	if err := json.Unmarshal(updated.Envelope().Data, &data); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if data.Title != "Spring (revised)" || data.EndDate != "2025-04-04" || data.Version != term.Version+1 {
+		t.Fatalf("update payload = %+v", data)
+	}
+}
+
+func TestHolidayUploadsRecordEvents(t *testing.T) {
+	s := newTestServer(t)
+
+	if code, res := s.do(http.MethodPost, "/api/v1/admin/holidays", s.orgAdminToken, map[string]interface{}{
+		"language_id": 1,
+		"holidays":    []map[string]interface{}{{"title": "Tet", "color": "#f00", "start_date": "2025-01-27", "end_date": "2025-02-02"}},
+	}); code != http.StatusOK {
+		t.Fatalf("create status = %d, body %+v", code, res)
+	}
+	holidays, _ := s.holidays.GetAllByOrgID(context.Background(), orgA)
+
+	if code, res := s.do(http.MethodPost, "/api/v1/admin/holidays", s.orgAdminToken, map[string]interface{}{
+		"language_id": 1,
+		"delete_ids":  []string{holidays[0].ID.Hex()},
+	}); code != http.StatusOK {
+		t.Fatalf("delete status = %d, body %+v", code, res)
+	}
+
+	events := s.outbox.Events()
+	if got := s.eventTypes(); len(got) != 2 || got[0] != "holiday.created" || got[1] != "holiday.deleted" {
+		t.Fatalf("recorded %v, want [holiday.created holiday.deleted]", got)
+	}
+	if deleted := events[1]; deleted.AggregateID != holidays[0].ID.Hex() || deleted.OrganizationID != orgA {
+		t.Fatalf("delete event = %+v", deleted)
+	}
+}
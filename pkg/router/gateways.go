@@ -0,0 +1,26 @@
+package router
+
+import (
+	"term-service/internal/gateway"
+	"term-service/pkg/servicetoken"
+
+	"github.com/hashicorp/consul/api"
+)
+
+// Gateways groups the go-main-service clients shared by the term and
+// holiday services.
+type Gateways struct {
+	User            gateway.UserGateway
+	Organization    gateway.OrganizationGateway
+	MessageLanguage gateway.MessageLanguageGateway
+}
+
+// NewGateways resolves go-main-service through Consul. signer issues the
+// token used for calls made without an end-user token.
+func NewGateways(consulClient *api.Client, signer servicetoken.Signer) Gateways {
+	return Gateways{
+		User:            gateway.NewUserGateway("go-main-service", consulClient, signer),
+		Organization:    gateway.NewOrganizationGateway("go-main-service", consulClient, signer),
+		MessageLanguage: gateway.NewMessageLanguageGateway("go-main-service", consulClient, signer),
+	}
+}
@@ -0,0 +1,137 @@
+package router_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// moment returns an as_of value strictly between the surrounding writes.
+func moment(t *testing.T) string {
+	t.Helper()
+	time.Sleep(2 * time.Millisecond)
+	at := time.Now()
+	time.Sleep(2 * time.Millisecond)
+	return url.QueryEscape(at.Format(time.RFC3339Nano))
+}
+
+func TestTermsAsOf(t *testing.T) {
+	s := newTestServer(t)
+	beforeCreate := moment(t)
+
+	if code, res := s.do(http.MethodPost, "/api/v1/admin/terms", s.orgAdminToken, uploadTermsBody(
+		map[string]interface{}{"title": "Spring", "color": "#0f0", "start_date": "2025-01-06", "end_date": "2025-03-28"},
+	)); code != http.StatusOK {
+		t.Fatalf("upload status = %d, body %+v", code, res)
+	}
+	spring := s.orgTerms(orgA)[0]
+	afterCreate := moment(t)
+
+	if code, res := s.do(http.MethodPost, "/api/v1/admin/terms", s.orgAdminToken, uploadTermsBody(
+		map[string]interface{}{"id": spring.ID.Hex(), "version": spring.Version, "title": "Spring (revised)", "color": "#0f0", "start_date": "2025-01-13", "end_date": "2025-04-04"},
+	)); code != http.StatusOK {
+		t.Fatalf("update status = %d, body %+v", code, res)
+	}
+
+	titles := func(asOf string) []string {
+		t.Helper()
+		code, res := s.do(http.MethodGet, "/api/v1/organization/"+orgA+"/terms?as_of="+asOf, s.memberToken, nil)
+		if code != http.StatusOK {
+			t.Fatalf("list as_of %s status = %d, body %+v", asOf, code, res)
+		}
+		var body struct {
+			Terms []struct {
+				Title string `json:"title"`
+			} `json:"terms"`
+		}
+		s.decode(res, &body)
+		out := make([]string, 0, len(body.Terms))
+		for _, term := range body.Terms {
+			out = append(out, term.Title)
+		}
+		return out
+	}
+
+	if got := titles(beforeCreate); len(got) != 0 {
+		t.Fatalf("terms before creation = %v", got)
+	}
+	if got := titles(afterCreate); len(got) != 1 || got[0] != "Spring" {
+		t.Fatalf("terms after creation = %v", got)
+	}
+	if got := titles(""); len(got) != 1 || got[0] != "Spring (revised)" {
+		t.Fatalf("live terms = %v", got)
+	}
+
+	gw := "/api/v1/gateway/terms/" + spring.ID.Hex() + "?organization_id=" + orgA + "&as_of="
+	code, res := s.do(http.MethodGet, gw+afterCreate, s.serviceToken, nil)
+	if code != http.StatusOK {
+		t.Fatalf("gateway as_of status = %d, body %+v", code, res)
+	}
+	var term struct {
+		Title     string `json:"title"`
+		StartDate string `json:"start_date"`
+	}
+	s.decode(res, &term)
+	if term.Title != "Semester Spring" || term.StartDate != "2025-01-06" {
+		t.Fatalf("gateway term as_of creation = %+v", term)
+	}
+	if code, _ := s.do(http.MethodGet, gw+beforeCreate, s.serviceToken, nil); code != http.StatusNotFound {
+		t.Fatalf("gateway term before creation status = %d, want %d", code, http.StatusNotFound)
+	}
+
+	if code, _ := s.do(http.MethodGet, "/api/v1/organization/"+orgA+"/terms?as_of=yesterday", s.memberToken, nil); code != http.StatusBadRequest {
+		t.Fatalf("invalid as_of status = %d, want %d", code, http.StatusBadRequest)
+	}
+}
+
+func TestHolidaysAsOf(t *testing.T) {
+	s := newTestServer(t)
+
+	if code, res := s.do(http.MethodPost, "/api/v1/admin/holidays", s.orgAdminToken, map[string]interface{}{
+		"language_id": 1,
+		"holidays": []map[string]interface{}{
+			{"title": "Tet", "color": "#f00", "start_date": "2025-01-27", "end_date": "2025-02-02"},
+		},
+	}); code != http.StatusOK {
+		t.Fatalf("upload status = %d, body %+v", code, res)
+	}
+	withTet := moment(t)
+
+	holidays, _ := s.holidays.GetAllByOrgID(t.Context(), orgA)
+	if code, res := s.do(http.MethodPost, "/api/v1/admin/holidays", s.orgAdminToken, map[string]interface{}{
+		"language_id": 1,
+		"delete_ids":  []string{holidays[0].ID.Hex()},
+	}); code != http.StatusOK {
+		t.Fatalf("delete status = %d, body %+v", code, res)
+	}
+
+	count := func(asOf string) int {
+		t.Helper()
+		code, res := s.do(http.MethodGet, "/api/v1/admin/holidays?as_of="+asOf, s.orgAdminToken, nil)
+		if code != http.StatusOK {
+			t.Fatalf("list as_of %s status = %d, body %+v", asOf, code, res)
+		}
+		var body struct {
+			Orgs []struct {
+				Holidays []struct{ Title string } `json:"holidays"`
+			} `json:"holiday_organizations"`
+		}
+		s.decode(res, &body)
+		n := 0
+		for _, org := range body.Orgs {
+			n += len(org.Holidays)
+		}
+		return n
+	}
+
+	if n := count(withTet); n != 1 {
+		t.Fatalf("holidays before deletion = %d, want 1", n)
+	}
+	if n := count(""); n != 0 {
+		t.Fatalf("live holidays after deletion = %d, want 0", n)
+	}
+	if n := count(time.Now().Format(time.DateOnly)); n != 0 {
+		t.Fatalf("holidays as of today = %d, want 0", n)
+	}
+}
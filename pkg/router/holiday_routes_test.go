@@ -0,0 +1,115 @@
+package router_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestUploadHolidaysCreatesAndDeletes(t *testing.T) {
+	s := newTestServer(t)
+
+	code, res := s.do(http.MethodPost, "/api/v1/admin/holidays", s.orgAdminToken, map[string]interface{}{
+		"language_id": 1,
+		"holidays": []map[string]interface{}{
+			{"title": "Tet", "color": "#f00", "published_mobile": true, "start_date": "2025-01-27", "end_date": "2025-02-02"},
+			{"title": "Labour Day", "color": "#00f", "start_date": "2025-05-01", "end_date": "2025-05-01"},
+		},
+	})
+	if code != http.StatusOK {
+		t.Fatalf("upload status = %d, body %+v", code, res)
+	}
+
+	holidays, err := s.holidays.GetAllByOrgID(context.Background(), orgA)
+	if err != nil || len(holidays) != 2 {
+		t.Fatalf("holidays after upload = %+v, %v", holidays, err)
+	}
+
+	code, res = s.do(http.MethodPost, "/api/v1/admin/holidays", s.orgAdminToken, map[string]interface{}{
+		"language_id": 1,
+		"delete_ids":  []string{holidays[1].ID.Hex()},
+	})
+	if code != http.StatusOK {
+		t.Fatalf("delete status = %d, body %+v", code, res)
+	}
+
+	holidays, _ = s.holidays.GetAllByOrgID(context.Background(), orgA)
+	if len(holidays) != 1 || holidays[0].Title != "Tet" {
+		t.Fatalf("holidays after delete = %+v", holidays)
+	}
+}
+
+func TestUploadHolidaysCannotDeleteAnotherOrganization(t *testing.T) {
+	s := newTestServer(t)
+	if code, res := s.do(http.MethodPost, "/api/v1/admin/holidays", s.orgAdminToken, map[string]interface{}{
+		"language_id": 1,
+		"holidays":    []map[string]interface{}{{"title": "Tet", "color": "#f00", "start_date": "2025-01-27", "end_date": "2025-02-02"}},
+	}); code != http.StatusOK {
+		t.Fatalf("seed upload status = %d, body %+v", code, res)
+	}
+	holidays, _ := s.holidays.GetAllByOrgID(context.Background(), orgA)
+
+	code, res := s.do(http.MethodPost, "/api/v1/admin/holidays", s.orgBAdminToken, map[string]interface{}{
+		"language_id": 1,
+		"delete_ids":  []string{holidays[0].ID.Hex()},
+	})
+	if code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d (body %+v)", code, http.StatusForbidden, res)
+	}
+	if remaining, _ := s.holidays.GetAllByOrgID(context.Background(), orgA); len(remaining) != 1 {
+		t.Fatalf("holiday deleted by another organization's admin")
+	}
+}
+
+func TestGetHolidays4Web(t *testing.T) {
+	s := newTestServer(t)
+	if code, res := s.do(http.MethodPost, "/api/v1/admin/holidays", s.orgAdminToken, map[string]interface{}{
+		"language_id": 1,
+		"holidays":    []map[string]interface{}{{"title": "Tet", "color": "#f00", "start_date": "2025-01-27", "end_date": "2025-02-02"}},
+	}); code != http.StatusOK {
+		t.Fatalf("seed upload status = %d, body %+v", code, res)
+	}
+
+	var body struct {
+		HolidaysOrg []struct {
+			OrganizationName string `json:"organization_name"`
+			Holidays         []struct {
+				Title string `json:"title"`
+			} `json:"holidays"`
+		} `json:"holiday_organizations"`
+	}
+
+	cases := []struct {
+		name      string
+		token     string
+		query     string
+		wantCode  int
+		wantOrgs  int
+		wantCount int
+	}{
+		{"own organization", s.orgAdminToken, "", http.StatusOK, 1, 1},
+		{"other organization", s.orgBAdminToken, "", http.StatusOK, 1, 0},
+		{"super admin", s.superAdminToken, "", http.StatusOK, 0, 0},
+		{"super admin on behalf of organization", s.superAdminToken, "?organization_id=" + orgA, http.StatusOK, 1, 1},
+		{"member", s.memberToken, "", http.StatusForbidden, 0, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, res := s.do(http.MethodGet, "/api/v1/admin/holidays"+tc.query, tc.token, nil)
+			if code != tc.wantCode {
+				t.Fatalf("status = %d, want %d (body %+v)", code, tc.wantCode, res)
+			}
+			if code != http.StatusOK {
+				return
+			}
+			body.HolidaysOrg = nil
+			s.decode(res, &body)
+			if len(body.HolidaysOrg) != tc.wantOrgs {
+				t.Fatalf("organizations = %+v, want %d", body.HolidaysOrg, tc.wantOrgs)
+			}
+			if tc.wantOrgs > 0 && len(body.HolidaysOrg[0].Holidays) != tc.wantCount {
+				t.Fatalf("holidays = %+v, want %d", body.HolidaysOrg[0].Holidays, tc.wantCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package router_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func (s *testServer) doIdempotent(key, token string, body interface{}) *httptest.ResponseRecorder {
+	s.t.Helper()
+	b, err := json.Marshal(body)
+	if err != nil {
+		s.t.Fatalf("marshal body: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/terms", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Idempotency-Key", key)
+	rec := httptest.NewRecorder()
+	s.engine.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestUploadTermsIdempotencyKey(t *testing.T) {
+	s := newTestServer(t)
+	body := uploadTermsBody(map[string]interface{}{"title": "Spring", "color": "#0f0", "start_date": "2025-01-06", "end_date": "2025-03-28"})
+
+	first := s.doIdempotent("retry-1", s.orgAdminToken, body)
+	if first.Code != http.StatusOK {
+		t.Fatalf("first upload status = %d, body %s", first.Code, first.Body)
+	}
+
+	retry := s.doIdempotent("retry-1", s.orgAdminToken, body)
+	if retry.Code != http.StatusOK || retry.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("retry status = %d, replayed = %q", retry.Code, retry.Header().Get("Idempotent-Replayed"))
+	}
+	if retry.Body.String() != first.Body.String() {
+		t.Fatalf("retry body = %s, want %s", retry.Body, first.Body)
+	}
+	if terms := s.orgTerms(orgA); len(terms) != 1 {
+		t.Fatalf("terms after retry = %d, want 1", len(terms))
+	}
+
+	other := uploadTermsBody(map[string]interface{}{"title": "Autumn", "color": "#f00", "start_date": "2025-09-01", "end_date": "2025-12-19"})
+	if rec := s.doIdempotent("retry-1", s.orgAdminToken, other); rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("reused key with other body status = %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+	}
+
+	// keys are scoped per organization
+	if rec := s.doIdempotent("retry-1", s.orgBAdminToken, body); rec.Code != http.StatusOK || rec.Header().Get("Idempotent-Replayed") != "" {
+		t.Fatalf("other organization status = %d, replayed = %q", rec.Code, rec.Header().Get("Idempotent-Replayed"))
+	}
+	if terms := s.orgTerms(orgB); len(terms) != 1 {
+		t.Fatalf("org B terms = %d, want 1", len(terms))
+	}
+}
+
+// The message-language upload runs after the terms are committed; its failure
+// must not turn the upload into a 5xx, which would release the key and let a
+// retry store the terms a second time.
+func TestUploadTermsMessageFailureKeepsKey(t *testing.T) {
+	s := newTestServer(t)
+	s.messages.UploadErr = errors.New("message language service unavailable")
+	body := uploadTermsBody(map[string]interface{}{"title": "Spring", "color": "#0f0", "start_date": "2025-01-06", "end_date": "2025-03-28"})
+
+	first := s.doIdempotent("retry-2", s.orgAdminToken, body)
+	if first.Code != http.StatusOK {
+		t.Fatalf("upload status = %d, body %s", first.Code, first.Body)
+	}
+
+	retry := s.doIdempotent("retry-2", s.orgAdminToken, body)
+	if retry.Code != http.StatusOK || retry.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("retry status = %d, replayed = %q", retry.Code, retry.Header().Get("Idempotent-Replayed"))
+	}
+	if terms := s.orgTerms(orgA); len(terms) != 1 {
+		t.Fatalf("terms after retry = %d, want 1", len(terms))
+	}
+}
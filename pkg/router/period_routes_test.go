@@ -0,0 +1,128 @@
+package router_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"term-service/internal/term/model"
+)
+
+type periodRes struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+func (s *testServer) listPeriods(orgID string) []periodRes {
+	s.t.Helper()
+	code, res := s.do(http.MethodGet, "/api/v1/organization/"+orgID+"/terms", s.memberToken, nil)
+	if code != http.StatusOK {
+		s.t.Fatalf("list status = %d, body %+v", code, res)
+	}
+	var body struct {
+		Terms []struct {
+			Periods []periodRes `json:"periods"`
+		} `json:"terms"`
+	}
+	s.decode(res, &body)
+	if len(body.Terms) != 1 {
+		s.t.Fatalf("terms = %+v, want one", body.Terms)
+	}
+	return body.Terms[0].Periods
+}
+
+func TestUploadTermPeriods(t *testing.T) {
+	s := newTestServer(t)
+	spring := func(extra map[string]interface{}) map[string]interface{} {
+		item := map[string]interface{}{"title": "Spring", "color": "#0f0", "start_date": "2025-01-06", "end_date": "2025-03-28"}
+		for k, v := range extra {
+			item[k] = v
+		}
+		return item
+	}
+
+	code, res := s.do(http.MethodPost, "/api/v1/admin/terms", s.orgAdminToken, uploadTermsBody(spring(map[string]interface{}{
+		"periods": []map[string]interface{}{
+			{"type": "break", "title": "Tet", "start_date": "2025-01-27", "end_date": "2025-02-02"},
+			{"type": "exam", "title": "Finals", "start_date": "2025-03-17", "end_date": "2025-03-28"},
+		},
+	})))
+	if code != http.StatusOK {
+		t.Fatalf("upload status = %d, body %+v", code, res)
+	}
+	periods := s.listPeriods(orgA)
+	if len(periods) != 2 || periods[0].Type != "break" || periods[0].ID == "" || periods[1].StartDate != "2025-03-17" {
+		t.Fatalf("periods after create = %+v", periods)
+	}
+	term := s.orgTerms(orgA)[0]
+
+	// Uploads without periods keep them.
+	code, res = s.do(http.MethodPost, "/api/v1/admin/terms", s.orgAdminToken, uploadTermsBody(spring(map[string]interface{}{
+		"id": term.ID.Hex(), "version": term.Version, "title": "Spring (revised)",
+	})))
+	if code != http.StatusOK {
+		t.Fatalf("update status = %d, body %+v", code, res)
+	}
+	if got := s.listPeriods(orgA); len(got) != 2 {
+		t.Fatalf("periods after update without periods = %+v", got)
+	}
+	term = s.orgTerms(orgA)[0]
+
+	// Listing periods updates the named ones and drops the rest.
+	code, res = s.do(http.MethodPost, "/api/v1/admin/terms", s.orgAdminToken, uploadTermsBody(spring(map[string]interface{}{
+		"id": term.ID.Hex(), "version": term.Version,
+		"periods": []map[string]interface{}{
+			{"id": periods[1].ID, "type": "exam", "title": "Finals", "start_date": "2025-03-20", "end_date": "2025-03-28"},
+		},
+	})))
+	if code != http.StatusOK {
+		t.Fatalf("update periods status = %d, body %+v", code, res)
+	}
+	if got := s.listPeriods(orgA); len(got) != 1 || got[0].ID != periods[1].ID || got[0].StartDate != "2025-03-20" {
+		t.Fatalf("periods after update = %+v", got)
+	}
+
+	cases := []struct {
+		name   string
+		period map[string]interface{}
+	}{
+		{"outside the term", map[string]interface{}{"type": "break", "title": "Late", "start_date": "2025-03-24", "end_date": "2025-04-04"}},
+		{"unknown type", map[string]interface{}{"type": "holiday", "title": "Odd", "start_date": "2025-02-03", "end_date": "2025-02-04"}},
+		{"untitled custom", map[string]interface{}{"type": "custom", "start_date": "2025-02-03", "end_date": "2025-02-04"}},
+		{"unknown id", map[string]interface{}{"id": "0123456789abcdef01234567", "type": "exam", "title": "Ghost", "start_date": "2025-02-03", "end_date": "2025-02-04"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, _ := s.do(http.MethodPost, "/api/v1/admin/terms", s.orgAdminToken, uploadTermsBody(spring(map[string]interface{}{
+				"periods": []map[string]interface{}{tc.period},
+			})))
+			if code != http.StatusInternalServerError {
+				t.Fatalf("status = %d, want %d", code, http.StatusInternalServerError)
+			}
+		})
+	}
+}
+
+func TestCurrentWeekSkipsBreaks(t *testing.T) {
+	s := newTestServer(t)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	start := today.AddDate(0, 0, -15) // today is in the third 7-day block
+	s.seedTerm(&model.Term{OrganizationID: orgA, Title: "Now", StartDate: start, EndDate: today.AddDate(0, 0, 30),
+		Periods: model.Periods{{ID: "p1", Type: model.PeriodBreak, Title: "Mid-term", StartDate: start.AddDate(0, 0, 7), EndDate: start.AddDate(0, 0, 13)}}})
+
+	code, res := s.do(http.MethodGet, "/api/v1/terms/current?organization_id="+orgA, s.memberToken, nil)
+	if code != http.StatusOK {
+		t.Fatalf("status = %d, body %+v", code, res)
+	}
+	var term struct {
+		CurrentWeek int         `json:"current_week"`
+		Periods     []periodRes `json:"periods"`
+	}
+	s.decode(res, &term)
+	if term.CurrentWeek != 2 || len(term.Periods) != 1 || term.Periods[0].Type != "break" {
+		t.Fatalf("current term = %+v, want week 2 with the break listed", term)
+	}
+}
@@ -0,0 +1,138 @@
+package router_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"term-service/internal/gateway/dto"
+	"term-service/pkg/ratelimit"
+	"term-service/pkg/router"
+)
+
+func withLimits(limits ratelimit.Limits) func(*router.Dependencies) {
+	return func(deps *router.Dependencies) {
+		deps.RateLimitStore = ratelimit.NewMemoryStore()
+		deps.Limits = limits
+	}
+}
+
+func TestRateLimitPerUser(t *testing.T) {
+	s := newTestServer(t, withLimits(ratelimit.Limits{PerUser: ratelimit.Rule{Requests: 2, Window: time.Minute}}))
+
+	// made-up tokens are refused by the user service and use up nothing
+	for i := 0; i < 3; i++ {
+		if code, _ := s.do(http.MethodGet, "/api/v1/admin/terms", fmt.Sprintf("made-up-%d", i), nil); code != http.StatusUnauthorized {
+			t.Fatalf("made-up token status = %d, want %d", code, http.StatusUnauthorized)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if code, res := s.do(http.MethodGet, "/api/v1/admin/terms", s.orgAdminToken, nil); code != http.StatusOK {
+			t.Fatalf("request %d status = %d, body %+v", i+1, code, res)
+		}
+	}
+
+	// a fresh token of the same user shares the bucket
+	second := s.addUser(&dto.CurrentUser{ID: "admin-a", OrganizationAdmin: &dto.OrganizationAdmin{ID: orgA}, Organization: []string{orgA}})
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/terms", nil)
+	req.Header.Set("Authorization", "Bearer "+second)
+	rec := httptest.NewRecorder()
+	s.engine.ServeHTTP(rec, req)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("third request status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	if got := rec.Header().Get("Retry-After"); got == "" || got == "0" {
+		t.Fatalf("Retry-After = %q, want a positive number of seconds", got)
+	}
+
+	// another user has their own bucket
+	if code, _ := s.do(http.MethodGet, "/api/v1/admin/terms", s.orgBAdminToken, nil); code != http.StatusOK {
+		t.Fatalf("other user status = %d, want %d", code, http.StatusOK)
+	}
+	// probes are never throttled
+	for i := 0; i < 3; i++ {
+		if code, _ := s.do(http.MethodGet, "/healthz", s.orgAdminToken, nil); code != http.StatusOK {
+			t.Fatalf("healthz status = %d, want %d", code, http.StatusOK)
+		}
+	}
+}
+
+func TestRateLimitPerIP(t *testing.T) {
+	get := func(s *testServer, forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/terms", nil)
+		req.RemoteAddr = "203.0.113.7:40000"
+		req.Header.Set("Authorization", "Bearer "+s.orgAdminToken)
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		rec := httptest.NewRecorder()
+		s.engine.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	limits := withLimits(ratelimit.Limits{PerIP: ratelimit.Rule{Requests: 2, Window: time.Minute}})
+
+	// X-Forwarded-For from an untrusted peer is ignored
+	s := newTestServer(t, limits)
+	for i, code := range []int{get(s, "198.51.100.1"), get(s, "198.51.100.2"), get(s, "198.51.100.3")} {
+		if want := []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}[i]; code != want {
+			t.Fatalf("spoofed request %d status = %d, want %d", i+1, code, want)
+		}
+	}
+
+	// behind a trusted proxy each forwarded client has its own bucket
+	s = newTestServer(t, limits, func(deps *router.Dependencies) { deps.TrustedProxies = []string{"203.0.113.0/24"} })
+	for i := 0; i < 3; i++ {
+		if code := get(s, fmt.Sprintf("198.51.100.%d", i+1)); code != http.StatusOK {
+			t.Fatalf("proxied request %d status = %d, want %d", i+1, code, http.StatusOK)
+		}
+	}
+	get(s, "198.51.100.9")
+	get(s, "198.51.100.9")
+	if code := get(s, "198.51.100.9"); code != http.StatusTooManyRequests {
+		t.Fatalf("same forwarded client status = %d, want %d", code, http.StatusTooManyRequests)
+	}
+}
+
+func TestRateLimitPerOrganization(t *testing.T) {
+	s := newTestServer(t, withLimits(ratelimit.Limits{PerOrganization: ratelimit.Rule{Requests: 1, Window: time.Minute}}))
+
+	if code, _ := s.do(http.MethodGet, "/api/v1/admin/terms", s.orgAdminToken, nil); code != http.StatusOK {
+		t.Fatalf("first request status = %d, want %d", code, http.StatusOK)
+	}
+	// the super admin acting on org A shares org A's budget
+	if code, _ := s.do(http.MethodGet, "/api/v1/admin/terms/assign?organization_id="+orgA, s.superAdminToken, nil); code != http.StatusTooManyRequests {
+		t.Fatalf("same organization status = %d, want %d", code, http.StatusTooManyRequests)
+	}
+	if code, _ := s.do(http.MethodGet, "/api/v1/admin/terms", s.orgBAdminToken, nil); code != http.StatusOK {
+		t.Fatalf("other organization status = %d, want %d", code, http.StatusOK)
+	}
+}
+
+func TestUploadSizeLimits(t *testing.T) {
+	s := newTestServer(t, withLimits(ratelimit.Limits{MaxBodyBytes: 2048, MaxUploadItems: 2}))
+
+	item := map[string]interface{}{"title": "T", "color": "#000", "start_date": "2025-01-06", "end_date": "2025-03-28"}
+	if code, res := s.do(http.MethodPost, "/api/v1/admin/terms", s.orgAdminToken, uploadTermsBody(item, item, item)); code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("too many items status = %d, want %d, body %+v", code, http.StatusRequestEntityTooLarge, res)
+	}
+	if len(s.orgTerms(orgA)) != 0 {
+		t.Fatalf("rejected upload stored terms")
+	}
+
+	big := `{"language_id":1,"word":"` + strings.Repeat("x", 4096) + `","terms":[]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/terms", bytes.NewBufferString(big))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.orgAdminToken)
+	rec := httptest.NewRecorder()
+	s.engine.ServeHTTP(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("large body status = %d, want %d", rec.Code, http.StatusRequestEntityTooLarge)
+	}
+
+	if code, res := s.do(http.MethodPost, "/api/v1/admin/terms", s.orgAdminToken, uploadTermsBody(item, item)); code != http.StatusOK {
+		t.Fatalf("upload within limits status = %d, body %+v", code, res)
+	}
+}
@@ -0,0 +1,82 @@
+package router
+
+import (
+	"context"
+
+	draft_repo "term-service/internal/draft/repository"
+	holiday_repo "term-service/internal/holiday/repository"
+	lifecycle_repo "term-service/internal/lifecycle/repository"
+	"term-service/internal/term/repository"
+	webhook_repo "term-service/internal/webhook/repository"
+	"term-service/pkg/db/txn"
+	"term-service/pkg/idempotency"
+	"term-service/pkg/outbox"
+	"term-service/pkg/revision"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+)
+
+// Repositories holds the storage implementations selected by
+// database.active.
+type Repositories struct {
+	Term        repository.TermRepository
+	Holiday     holiday_repo.HolidayRepository
+	Idempotency idempotency.Store
+	Outbox      outbox.Store
+	Transactor  txn.Transactor // spans a change and the outbox events it records
+	Revisions   revision.Store // history of terms and holidays, for as_of reads
+
+	WebhookSubscriptions webhook_repo.SubscriptionRepository
+	WebhookDeliveries    webhook_repo.DeliveryRepository
+
+	LifecycleFired lifecycle_repo.FiredRepository
+
+	Drafts draft_repo.DraftRepository
+}
+
+// NewMongoRepositories asks the server whether it supports transactions, so
+// ctx should bound that startup check. A standalone server is refused unless
+// allowNonTransactional is set.
+func NewMongoRepositories(ctx context.Context, db *mongo.Database, allowNonTransactional bool) (Repositories, error) {
+	transactor, err := txn.NewMongoTransactor(ctx, db.Client(), allowNonTransactional)
+	if err != nil {
+		return Repositories{}, err
+	}
+
+	return Repositories{
+		Term:    repository.NewTermRepository(db.Collection("terms")),
+		Holiday: holiday_repo.NewHolidayRepository(db.Collection("holidays")),
+
+		Idempotency: idempotency.NewMongoStore(db.Collection("idempotency_records")),
+		Outbox:      outbox.NewMongoStore(db.Collection("outbox_events")),
+		Transactor:  transactor,
+		Revisions:   revision.NewMongoStore(db.Collection("revisions")),
+
+		WebhookSubscriptions: webhook_repo.NewSubscriptionRepository(db.Collection("webhook_subscriptions")),
+		WebhookDeliveries:    webhook_repo.NewDeliveryRepository(db.Collection("webhook_deliveries")),
+
+		LifecycleFired: lifecycle_repo.NewFiredRepository(db.Collection("lifecycle_fired")),
+
+		Drafts: draft_repo.NewDraftRepository(db.Collection("calendar_drafts")),
+	}, nil
+}
+
+func NewGormRepositories(db *gorm.DB) Repositories {
+	return Repositories{
+		Term:    repository.NewGormTermRepository(db),
+		Holiday: holiday_repo.NewGormHolidayRepository(db),
+
+		Idempotency: idempotency.NewGormStore(db),
+		Outbox:      outbox.NewGormStore(db),
+		Transactor:  txn.NewGormTransactor(db),
+		Revisions:   revision.NewGormStore(db),
+
+		WebhookSubscriptions: webhook_repo.NewGormSubscriptionRepository(db),
+		WebhookDeliveries:    webhook_repo.NewGormDeliveryRepository(db),
+
+		LifecycleFired: lifecycle_repo.NewGormFiredRepository(db),
+
+		Drafts: draft_repo.NewGormDraftRepository(db),
+	}
+}
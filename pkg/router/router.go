@@ -1,7 +1,14 @@
 package router
 
 import (
-	"term-service/internal/gateway"
+	"time"
+
+	calendar_handler "term-service/internal/calendar/handler"
+	calendar_route "term-service/internal/calendar/route"
+	calendar_service "term-service/internal/calendar/service"
+	draft_handler "term-service/internal/draft/handler"
+	draft_route "term-service/internal/draft/route"
+	draft_service "term-service/internal/draft/service"
 	holiday_handler "term-service/internal/holiday/handler"
 	holiday_repo "term-service/internal/holiday/repository"
 	holiday_route "term-service/internal/holiday/route"
@@ -10,35 +17,119 @@ import (
 	"term-service/internal/term/repository"
 	"term-service/internal/term/route"
 	"term-service/internal/term/service"
+	webhook_handler "term-service/internal/webhook/handler"
+	webhook_route "term-service/internal/webhook/route"
+	webhook_service "term-service/internal/webhook/service"
+	"term-service/pkg/config"
+	"term-service/pkg/constants"
+	"term-service/pkg/health"
+	"term-service/pkg/idempotency"
+	"term-service/pkg/metrics"
+	"term-service/pkg/ratelimit"
+	"term-service/pkg/servicetoken"
+	"term-service/pkg/tracing"
+	"term-service/pkg/zap"
 
 	"github.com/gin-gonic/gin"
-	"github.com/hashicorp/consul/api"
-	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
-func SetupRouter(termCollection *mongo.Collection, holidayCollection *mongo.Collection, consulClient *api.Client) *gin.Engine {
-	r := gin.Default()
-	// consul
-	//consulClient, _ := api.NewClient(api.DefaultConfig())
+// Dependencies is everything SetupRouter wires into the handlers.
+type Dependencies struct {
+	Repositories Repositories
+	Gateways     Gateways
+	Health       *health.Checker
+
+	// TrustedProxies may set X-Forwarded-For; nil trusts none, so the
+	// per-IP limit counts the connection's address.
+	TrustedProxies []string
+
+	ServiceAuth servicetoken.Verifier // verifies callers of /api/v1/gateway routes
+
+	RateLimitStore ratelimit.Store // nil disables rate limiting
+	Limits         ratelimit.Limits
+
+	IdempotencyTTL time.Duration // how long upload responses are replayed; 0 disables
+
+	WebhookSender       webhook_service.Sender       // used by the ping endpoint
+	WebhookDestinations webhook_service.Destinations // which subscription URLs are accepted
+}
+
+func SetupRouter(deps Dependencies) *gin.Engine {
+	repos, gateways := deps.Repositories, deps.Gateways
+
+	var ignoreLogUrls []string
+	if config.AppConfig != nil {
+		ignoreLogUrls = config.AppConfig.App.API.Rest.Setting.IgnoreLogUrls
+	}
+
+	r := gin.New()
+	if err := r.SetTrustedProxies(deps.TrustedProxies); err != nil {
+		zap.L().Errorw("invalid trusted proxies, trusting none", "error", err.Error())
+		_ = r.SetTrustedProxies(nil)
+	}
+	r.Use(zap.RequestIDMiddleware())
+	r.Use(otelgin.Middleware(tracing.ServiceName))
+	r.Use(metrics.GinMiddleware())
+	r.Use(zap.AccessLogMiddleware(ignoreLogUrls))
+	r.Use(gin.Recovery())
 
 	// Gateway setup
-	userGateway := gateway.NewUserGateway("go-main-service", consulClient)
-	orgGateway := gateway.NewOrganizationGateway("go-main-service", consulClient)
-	messageLanguageGW := gateway.NewMessageLanguageGateway("go-main-service", consulClient)
+	userGateway := gateways.User
+	orgGateway := gateways.Organization
+	messageLanguageGW := gateways.MessageLanguage
+
+	// Changes are recorded in the outbox and queued for webhook subscribers
+	// in the same transaction.
+	events := webhook_service.NewFanoutRecorder(repos.Outbox, repos.WebhookSubscriptions, repos.WebhookDeliveries)
 
 	// Term
-	termRepo := repository.NewTermRepository(termCollection)
-	termSvc := service.NewTermService(termRepo, userGateway, orgGateway, messageLanguageGW)
-	termHandler := handler.NewHandler(termSvc)
+	termRepo := repository.NewInstrumentedTermRepository(repository.NewRevisionedTermRepository(repos.Term, repos.Revisions))
+	termSvc := service.NewTermService(termRepo, repos.Revisions, repos.Transactor, events, userGateway, orgGateway, messageLanguageGW)
+	termHandler := handler.NewHandler(termSvc, deps.Limits.MaxUploadItems)
 
 	// Holiday
-	holidayRepo := holiday_repo.NewHolidayRepository(holidayCollection)
-	holidaySvc := holiday_service.NewHolidayService(holidayRepo, userGateway, orgGateway, messageLanguageGW)
-	holidayHandler := holiday_handler.NewHandler(holidaySvc)
+	holidayRepo := holiday_repo.NewInstrumentedHolidayRepository(holiday_repo.NewRevisionedHolidayRepository(repos.Holiday, repos.Revisions))
+	holidaySvc := holiday_service.NewHolidayService(holidayRepo, repos.Revisions, repos.Transactor, events, userGateway, orgGateway, messageLanguageGW)
+	holidayHandler := holiday_handler.NewHandler(holidaySvc, deps.Limits.MaxUploadItems)
+
+	// Webhook
+	webhookSvc := webhook_service.NewWebhookService(repos.WebhookSubscriptions, repos.WebhookDeliveries, repos.Transactor, deps.WebhookSender, deps.WebhookDestinations)
+	webhookHandler := webhook_handler.NewHandler(webhookSvc)
+
+	// Calendar drafts are published through the term and holiday services.
+	draftSvc := draft_service.NewDraftService(repos.Drafts, termRepo, holidayRepo, termSvc, holidaySvc, userGateway, repos.Transactor)
+	draftHandler := draft_handler.NewHandler(draftSvc)
+
+	// Calendar reads span terms and holidays.
+	calendarSvc := calendar_service.NewCalendarService(termRepo, holidayRepo, userGateway, messageLanguageGW)
+	calendarHandler := calendar_handler.NewHandler(calendarSvc)
 
 	// Register routes
-	route.RegisterTermRoutes(r, termHandler)
-	holiday_route.RegisterHolidayRoutes(r, holidayHandler)
+	health.RegisterRoutes(r, deps.Health)
+	metrics.RegisterRoutes(r)
+
+	// Limits only apply to routes registered after this point, so probes and
+	// scrapes above are never throttled.
+	r.Use(ratelimit.MaxBodySize(deps.Limits.MaxBodyBytes))
+	r.Use(ratelimit.Middleware(deps.RateLimitStore, ratelimit.Limit{Scope: "ip", Rule: deps.Limits.PerIP, Key: ratelimit.ByIP}))
+	// Bearer tokens are only trusted once the user service accepted them, so
+	// the per-user limit runs after authorization; made-up tokens never get
+	// that far and only count against their IP.
+	authLimit := ratelimit.Middleware(deps.RateLimitStore,
+		ratelimit.Limit{Scope: "user", Rule: deps.Limits.PerUser, Key: ratelimit.ByContextValue(constants.CallerID.String())},
+		ratelimit.Limit{Scope: "org", Rule: deps.Limits.PerOrganization, Key: ratelimit.ByContextValue(constants.TargetOrgID.String())},
+	)
+
+	idempotent := idempotency.Middleware(repos.Idempotency, deps.IdempotencyTTL, func(c *gin.Context) string {
+		return c.GetString(constants.TargetOrgID.String())
+	})
+
+	route.RegisterTermRoutes(r, termHandler, userGateway, deps.ServiceAuth, authLimit, idempotent)
+	holiday_route.RegisterHolidayRoutes(r, holidayHandler, userGateway, authLimit, idempotent)
+	webhook_route.RegisterWebhookRoutes(r, webhookHandler, userGateway, authLimit)
+	draft_route.RegisterDraftRoutes(r, draftHandler, userGateway, authLimit)
+	calendar_route.RegisterCalendarRoutes(r, calendarHandler, deps.ServiceAuth)
 
 	return r
 }
@@ -0,0 +1,626 @@
+package router_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	draft_repo "term-service/internal/draft/repository"
+	"term-service/internal/gateway/dto"
+	"term-service/internal/gateway/gatewaytest"
+	holiday_repo "term-service/internal/holiday/repository"
+	"term-service/internal/term/model"
+	"term-service/internal/term/repository"
+	webhook_repo "term-service/internal/webhook/repository"
+	webhook_service "term-service/internal/webhook/service"
+	"term-service/pkg/db/txn"
+	"term-service/pkg/health"
+	"term-service/pkg/idempotency"
+	"term-service/pkg/outbox"
+	"term-service/pkg/publish"
+	"term-service/pkg/revision"
+	"term-service/pkg/router"
+	"term-service/pkg/servicetoken"
+	"term-service/pkg/zap"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	orgA = "org-a"
+	orgB = "org-b"
+
+	serviceSecret = "router-test-service-secret-0123456789"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	zap.SetGlobal(zap.NewNop())
+	os.Exit(m.Run())
+}
+
+// testServer wires the real router to in-memory repositories and fake
+// gateways.
+type testServer struct {
+	t        *testing.T
+	engine   *gin.Engine
+	terms    repository.TermRepository
+	holidays holiday_repo.HolidayRepository
+	users    *gatewaytest.UserGateway
+	orgs     *gatewaytest.OrganizationGateway
+	messages *gatewaytest.MessageLanguageGateway
+	outbox   *outbox.MemoryStore
+
+	orgAdminToken   string
+	orgBAdminToken  string
+	superAdminToken string
+	memberToken     string
+	serviceToken    string // go-main-service calling /api/v1/gateway
+}
+
+// newTestServer builds the server; opts adjust the router dependencies
+// before it is set up.
+func newTestServer(t *testing.T, opts ...func(*router.Dependencies)) *testServer {
+	t.Helper()
+
+	s := &testServer{
+		t:        t,
+		terms:    repository.NewMemoryTermRepository(),
+		holidays: holiday_repo.NewMemoryHolidayRepository(),
+		users:    gatewaytest.NewUserGateway(),
+		orgs: gatewaytest.NewOrganizationGateway(
+			dto.OrganizationInfo{ID: orgA, OrganizationName: "School A"},
+			dto.OrganizationInfo{ID: orgB, OrganizationName: "School B"},
+		),
+		messages: gatewaytest.NewMessageLanguageGateway(),
+		outbox:   outbox.NewMemoryStore(),
+	}
+
+	s.orgAdminToken = s.addUser(&dto.CurrentUser{ID: "admin-a", OrganizationAdmin: &dto.OrganizationAdmin{ID: orgA}, Organization: []string{orgA}})
+	s.orgBAdminToken = s.addUser(&dto.CurrentUser{ID: "admin-b", OrganizationAdmin: &dto.OrganizationAdmin{ID: orgB}, Organization: []string{orgB}})
+	s.superAdminToken = s.addUser(&dto.CurrentUser{ID: "root", IsSuperAdmin: true, OrganizationAdmin: &dto.OrganizationAdmin{}})
+	s.memberToken = s.addUser(&dto.CurrentUser{ID: "member-a", OrganizationAdmin: &dto.OrganizationAdmin{}, Organization: []string{orgA}, OrganizationIdActive: orgA})
+
+	s.serviceToken = s.signServiceToken(serviceSecret, "go-main-service")
+
+	deps := router.Dependencies{
+		Repositories: router.Repositories{
+			Term:        s.terms,
+			Holiday:     s.holidays,
+			Idempotency: idempotency.NewMemoryStore(),
+			Outbox:      s.outbox,
+			Transactor:  txn.NewNoopTransactor(),
+
+			WebhookSubscriptions: webhook_repo.NewMemorySubscriptionRepository(),
+			WebhookDeliveries:    webhook_repo.NewMemoryDeliveryRepository(),
+
+			Drafts:    draft_repo.NewMemoryDraftRepository(),
+			Revisions: revision.NewMemoryStore(),
+		},
+		Gateways:       router.Gateways{User: s.users, Organization: s.orgs, MessageLanguage: s.messages},
+		Health:         health.NewChecker(time.Second, time.Second),
+		ServiceAuth:    servicetoken.NewVerifier(serviceSecret, []string{"go-main-service"}),
+		IdempotencyTTL: time.Hour,
+		WebhookSender:  webhook_service.NewSender(5*time.Second, webhook_service.Destinations{AllowInsecure: true}),
+
+		// receivers are httptest servers on loopback; partner.example is
+		// accepted without a DNS lookup
+		WebhookDestinations: webhook_service.Destinations{AllowInsecure: true},
+	}
+	for _, opt := range opts {
+		opt(&deps)
+	}
+	s.engine = router.SetupRouter(deps)
+	return s
+}
+
+// addUser registers user with the fake user gateway and returns a bearer
+// token carrying its id.
+func (s *testServer) addUser(user *dto.CurrentUser) string {
+	s.t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"user_id": user.ID}).SignedString([]byte("test"))
+	if err != nil {
+		s.t.Fatalf("sign token: %v", err)
+	}
+	s.users.AddUser(token, user)
+	return token
+}
+
+func (s *testServer) signServiceToken(secret, caller string) string {
+	s.t.Helper()
+	token, err := servicetoken.NewSigner(secret, caller, time.Minute).Sign(servicetoken.Audience)
+	if err != nil {
+		s.t.Fatalf("sign service token: %v", err)
+	}
+	return token
+}
+
+type apiResponse struct {
+	StatusCode int             `json:"status_code"`
+	Message    string          `json:"message"`
+	Data       json.RawMessage `json:"data"`
+	Error      string          `json:"error"`
+}
+
+func (s *testServer) do(method, path, token string, body interface{}) (int, apiResponse) {
+	s.t.Helper()
+
+	var reader *bytes.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			s.t.Fatalf("marshal body: %v", err)
+		}
+		reader = bytes.NewReader(b)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	s.engine.ServeHTTP(rec, req)
+
+	var res apiResponse
+	if rec.Body.Len() > 0 {
+		_ = json.Unmarshal(rec.Body.Bytes(), &res)
+	}
+	return rec.Code, res
+}
+
+func (s *testServer) decode(res apiResponse, v interface{}) {
+	s.t.Helper()
+	if err := json.Unmarshal(res.Data, v); err != nil {
+		s.t.Fatalf("decode data %s: %v", res.Data, err)
+	}
+}
+
+func (s *testServer) seedTerm(term *model.Term) *model.Term {
+	s.t.Helper()
+	created, err := s.terms.Create(context.Background(), term)
+	if err != nil {
+		s.t.Fatalf("seed term: %v", err)
+	}
+	return created
+}
+
+func (s *testServer) orgTerms(orgID string) []*model.Term {
+	s.t.Helper()
+	terms, err := s.terms.GetAllByOrgID(context.Background(), orgID)
+	if err != nil {
+		s.t.Fatalf("list terms: %v", err)
+	}
+	return terms
+}
+
+func uploadTermsBody(terms ...map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"language_id": 1, "word": "Semester", "terms": terms}
+}
+
+func TestUploadTermsCreatesThenUpdates(t *testing.T) {
+	s := newTestServer(t)
+
+	code, res := s.do(http.MethodPost, "/api/v1/admin/terms", s.orgAdminToken, uploadTermsBody(
+		map[string]interface{}{"title": "Spring", "color": "#0f0", "published_mobile": true, "start_date": "2025-01-06", "end_date": "2025-03-28"},
+		map[string]interface{}{"title": "Autumn", "color": "#f00", "start_date": "2025-09-01", "end_date": "2025-12-19"},
+	))
+	if code != http.StatusOK {
+		t.Fatalf("upload status = %d, body %+v", code, res)
+	}
+
+	terms := s.orgTerms(orgA)
+	if len(terms) != 2 || terms[0].Title != "Spring" || terms[1].Title != "Autumn" {
+		t.Fatalf("org terms after create = %+v", terms)
+	}
+	if !terms[0].VisibleOn(publish.Mobile, time.Now()) || len(terms[1].Audiences) != 0 {
+		t.Fatalf("legacy publish flags not mapped to audiences: %+v", terms)
+	}
+	if len(s.orgTerms(orgB)) != 0 {
+		t.Fatal("upload leaked into another organization")
+	}
+	if uploads := s.messages.Uploads(); len(uploads) == 0 || uploads[0].Value != "Semester" || uploads[0].TypeID != orgA {
+		t.Fatalf("term word not uploaded: %+v", uploads)
+	}
+
+	code, res = s.do(http.MethodPost, "/api/v1/admin/terms", s.orgAdminToken, uploadTermsBody(
+		map[string]interface{}{"id": terms[0].ID.Hex(), "version": terms[0].Version, "title": "Spring (revised)", "color": "#0f0", "start_date": "2025-01-13", "end_date": "2025-04-04",
+			"audiences": []map[string]interface{}{{"channel": "kiosk"}}},
+	))
+	if code != http.StatusOK {
+		t.Fatalf("update status = %d, body %+v", code, res)
+	}
+
+	terms = s.orgTerms(orgA)
+	if len(terms) != 2 {
+		t.Fatalf("update created a new term: %+v", terms)
+	}
+	if terms[0].Title != "Spring (revised)" || terms[0].VisibleOn(publish.Mobile, time.Now()) || !terms[0].VisibleOn(publish.Kiosk, time.Now()) {
+		t.Fatalf("term not updated: %+v", terms[0])
+	}
+}
+
+func TestUploadTermsRejectsInvalidInput(t *testing.T) {
+	s := newTestServer(t)
+
+	cases := []struct {
+		name string
+		body interface{}
+		want int
+	}{
+		{"missing language", map[string]interface{}{"word": "Semester", "terms": []interface{}{}}, http.StatusBadRequest},
+		{"end before start", uploadTermsBody(map[string]interface{}{"title": "Bad", "color": "#000", "start_date": "2025-05-01", "end_date": "2025-04-01"}), http.StatusInternalServerError},
+		{"malformed date", uploadTermsBody(map[string]interface{}{"title": "Bad", "color": "#000", "start_date": "01/05/2025", "end_date": "2025-06-01"}), http.StatusInternalServerError},
+		{"unknown channel", uploadTermsBody(map[string]interface{}{"title": "Bad", "color": "#000", "start_date": "2025-05-01", "end_date": "2025-06-01", "audiences": []map[string]interface{}{{"channel": "fax"}}}), http.StatusInternalServerError},
+		{"unknown id", uploadTermsBody(map[string]interface{}{"id": "0123456789abcdef01234567", "title": "Ghost", "color": "#000", "start_date": "2025-05-01", "end_date": "2025-06-01"}), http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if code, res := s.do(http.MethodPost, "/api/v1/admin/terms", s.orgAdminToken, tc.body); code != tc.want {
+				t.Fatalf("status = %d, want %d (body %+v)", code, tc.want, res)
+			}
+		})
+	}
+
+	if terms := s.orgTerms(orgA); len(terms) != 0 {
+		t.Fatalf("invalid uploads persisted terms: %+v", terms)
+	}
+}
+
+func TestUploadTermsPermissions(t *testing.T) {
+	s := newTestServer(t)
+	body := uploadTermsBody(map[string]interface{}{"title": "Spring", "color": "#0f0", "start_date": "2025-01-06", "end_date": "2025-03-28"})
+
+	cases := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{"no token", "", http.StatusForbidden},
+		{"super admin without organization", s.superAdminToken, http.StatusForbidden},
+		{"organization member", s.memberToken, http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if code, res := s.do(http.MethodPost, "/api/v1/admin/terms", tc.token, body); code != tc.want {
+				t.Fatalf("status = %d, want %d (body %+v)", code, tc.want, res)
+			}
+		})
+	}
+
+	if terms := s.orgTerms(orgA); len(terms) != 0 {
+		t.Fatalf("rejected uploads persisted terms: %+v", terms)
+	}
+}
+
+func TestUploadTermsCannotUpdateAnotherOrganization(t *testing.T) {
+	s := newTestServer(t)
+	term := s.seedTerm(&model.Term{OrganizationID: orgA, Title: "A1", StartDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC)})
+
+	code, res := s.do(http.MethodPost, "/api/v1/admin/terms", s.orgBAdminToken, uploadTermsBody(
+		map[string]interface{}{"id": term.ID.Hex(), "title": "Hijacked", "color": "#000", "start_date": "2025-01-06", "end_date": "2025-03-28"},
+	))
+	if code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d (body %+v)", code, http.StatusForbidden, res)
+	}
+
+	got, err := s.terms.GetByID(context.Background(), term.ID.Hex())
+	if err != nil || got.Title != "A1" {
+		t.Fatalf("term after rejected update = %+v, %v", got, err)
+	}
+}
+
+func TestGetTerms4WebScopesToOrganization(t *testing.T) {
+	s := newTestServer(t)
+	s.seedTerm(&model.Term{OrganizationID: orgA, Title: "A1", StartDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC)})
+	s.seedTerm(&model.Term{OrganizationID: orgB, Title: "B1", StartDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC)})
+
+	var body struct {
+		TermsOrg []struct {
+			OrganizationName string `json:"organization_name"`
+			Terms            []struct {
+				Title string `json:"title"`
+			} `json:"terms"`
+		} `json:"term_organizations"`
+	}
+
+	code, res := s.do(http.MethodGet, "/api/v1/admin/terms", s.orgAdminToken, nil)
+	if code != http.StatusOK {
+		t.Fatalf("org admin status = %d, body %+v", code, res)
+	}
+	s.decode(res, &body)
+	if len(body.TermsOrg) != 1 || body.TermsOrg[0].OrganizationName != "School A" || len(body.TermsOrg[0].Terms) != 1 || body.TermsOrg[0].Terms[0].Title != "A1" {
+		t.Fatalf("org admin sees %+v", body)
+	}
+
+	code, res = s.do(http.MethodGet, "/api/v1/admin/terms", s.superAdminToken, nil)
+	if code != http.StatusOK {
+		t.Fatalf("super admin status = %d, body %+v", code, res)
+	}
+	s.decode(res, &body)
+	if len(body.TermsOrg) != 2 {
+		t.Fatalf("super admin sees %d organizations, want 2", len(body.TermsOrg))
+	}
+
+	if code, _ := s.do(http.MethodGet, "/api/v1/admin/terms", s.memberToken, nil); code != http.StatusForbidden {
+		t.Fatalf("member status = %d, want %d", code, http.StatusForbidden)
+	}
+}
+
+func TestGetCurrentTerm(t *testing.T) {
+	s := newTestServer(t)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	current := s.seedTerm(&model.Term{OrganizationID: orgA, Title: "Now", StartDate: today.AddDate(0, 0, -8), EndDate: today.AddDate(0, 0, 30)})
+	s.seedTerm(&model.Term{OrganizationID: orgA, Title: "Past", StartDate: today.AddDate(0, -6, 0), EndDate: today.AddDate(0, -3, 0)})
+
+	code, res := s.do(http.MethodGet, "/api/v1/terms/current?organization_id="+orgA, s.memberToken, nil)
+	if code != http.StatusOK {
+		t.Fatalf("status = %d, body %+v", code, res)
+	}
+
+	var term struct {
+		ID          string `json:"id"`
+		Title       string `json:"title"`
+		CurrentWeek int    `json:"current_week"`
+	}
+	s.decode(res, &term)
+	if term.ID != current.ID.Hex() {
+		t.Fatalf("current term = %+v, want %s", term, current.ID)
+	}
+	if term.CurrentWeek < 2 {
+		t.Fatalf("current_week = %d, want at least 2 eight days into the term", term.CurrentWeek)
+	}
+
+	if code, _ := s.do(http.MethodGet, "/api/v1/terms/current?organization_id="+orgB, s.memberToken, nil); code != http.StatusInternalServerError {
+		t.Fatalf("org without current term status = %d, want %d", code, http.StatusInternalServerError)
+	}
+}
+
+func TestPreviousTerms4GW(t *testing.T) {
+	s := newTestServer(t)
+	first := s.seedTerm(&model.Term{OrganizationID: orgA, Title: "First", StartDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC)})
+	second := s.seedTerm(&model.Term{OrganizationID: orgA, Title: "Second", StartDate: time.Date(2025, 4, 7, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 6, 27, 0, 0, 0, 0, time.UTC)})
+	third := s.seedTerm(&model.Term{OrganizationID: orgA, Title: "Third", StartDate: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC)})
+
+	code, res := s.do(http.MethodGet, "/api/v1/gateway/terms/"+third.ID.Hex()+"/previous?organization_id="+orgA, s.serviceToken, nil)
+	if code != http.StatusOK {
+		t.Fatalf("previous status = %d, body %+v", code, res)
+	}
+	var prev struct {
+		ID string `json:"id"`
+	}
+	s.decode(res, &prev)
+	if prev.ID != second.ID.Hex() {
+		t.Fatalf("previous term = %s, want %s", prev.ID, second.ID)
+	}
+
+	code, res = s.do(http.MethodGet, "/api/v1/gateway/terms/"+third.ID.Hex()+"/previous/get-list?organization_id="+orgA, s.serviceToken, nil)
+	if code != http.StatusOK {
+		t.Fatalf("previous list status = %d, body %+v", code, res)
+	}
+	var list []struct {
+		ID string `json:"id"`
+	}
+	s.decode(res, &list)
+	if len(list) != 2 || list[0].ID != second.ID.Hex() || list[1].ID != first.ID.Hex() {
+		t.Fatalf("previous terms = %+v, want [second first]", list)
+	}
+
+	if code, _ := s.do(http.MethodGet, "/api/v1/gateway/terms/"+first.ID.Hex()+"/previous?organization_id="+orgA, s.serviceToken, nil); code != http.StatusInternalServerError {
+		t.Fatalf("first term previous status = %d, want %d", code, http.StatusInternalServerError)
+	}
+	if code, _ := s.do(http.MethodGet, "/api/v1/gateway/terms/"+third.ID.Hex()+"/previous", s.serviceToken, nil); code != http.StatusBadRequest {
+		t.Fatalf("missing organization_id status = %d, want %d", code, http.StatusBadRequest)
+	}
+}
+
+func TestGatewayRoutesRequireServiceToken(t *testing.T) {
+	s := newTestServer(t)
+	term := s.seedTerm(&model.Term{OrganizationID: orgA, Title: "A1", StartDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC)})
+	path := "/api/v1/gateway/terms/" + term.ID.Hex() + "?organization_id=" + orgA
+
+	cases := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{"service token", s.serviceToken, http.StatusOK},
+		{"no token", "", http.StatusUnauthorized},
+		{"user token", s.orgAdminToken, http.StatusUnauthorized},
+		{"super admin token", s.superAdminToken, http.StatusUnauthorized},
+		{"wrong secret", s.signServiceToken("another-service-secret-0123456789abcdef", "go-main-service"), http.StatusUnauthorized},
+		{"unknown caller", s.signServiceToken(serviceSecret, "billing-service"), http.StatusUnauthorized},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if code, res := s.do(http.MethodGet, path, tc.token, nil); code != tc.want {
+				t.Fatalf("status = %d, want %d, body %+v", code, tc.want, res)
+			}
+		})
+	}
+}
+
+func TestGetTerm4GwScopedByOrganization(t *testing.T) {
+	s := newTestServer(t)
+	term := s.seedTerm(&model.Term{OrganizationID: orgA, Title: "A1", StartDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC)})
+
+	code, res := s.do(http.MethodGet, "/api/v1/gateway/terms/"+term.ID.Hex()+"?organization_id="+orgA, s.serviceToken, nil)
+	if code != http.StatusOK {
+		t.Fatalf("own organization status = %d, body %+v", code, res)
+	}
+	var got struct {
+		ID string `json:"id"`
+	}
+	s.decode(res, &got)
+	if got.ID != term.ID.Hex() {
+		t.Fatalf("term = %s, want %s", got.ID, term.ID)
+	}
+
+	if code, _ := s.do(http.MethodGet, "/api/v1/gateway/terms/"+term.ID.Hex()+"?organization_id="+orgB, s.serviceToken, nil); code != http.StatusNotFound {
+		t.Fatalf("other organization status = %d, want %d", code, http.StatusNotFound)
+	}
+	if code, _ := s.do(http.MethodGet, "/api/v1/gateway/terms/"+term.ID.Hex(), s.serviceToken, nil); code != http.StatusBadRequest {
+		t.Fatalf("missing organization_id status = %d, want %d", code, http.StatusBadRequest)
+	}
+}
+
+func TestGetTermsByOrgIDRequiresMembership(t *testing.T) {
+	s := newTestServer(t)
+	s.seedTerm(&model.Term{OrganizationID: orgA, Title: "A1", StartDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC)})
+
+	cases := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{"member", s.memberToken, http.StatusOK},
+		{"organization admin", s.orgAdminToken, http.StatusOK},
+		{"super admin", s.superAdminToken, http.StatusOK},
+		{"other organization", s.orgBAdminToken, http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, res := s.do(http.MethodGet, "/api/v1/organization/"+orgA+"/terms", tc.token, nil)
+			if code != tc.want {
+				t.Fatalf("status = %d, want %d (body %+v)", code, tc.want, res)
+			}
+		})
+	}
+}
+
+func TestGetTermsByStudentRequiresMembership(t *testing.T) {
+	s := newTestServer(t)
+	s.seedTerm(&model.Term{OrganizationID: orgA, Title: "A1", Audiences: publish.Audiences{{Channel: publish.Mobile}, {Channel: publish.Desktop}}, StartDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC)})
+	s.users.AddStudent(&dto.StudentResponse{ID: "student-1", OrganizationID: orgA})
+
+	strangerToken := s.addUser(&dto.CurrentUser{ID: "stranger", Organization: []string{orgB}})
+	guardianToken := s.addUser(&dto.CurrentUser{ID: "guardian", Organization: []string{orgB}})
+	teacherToken := s.addUser(&dto.CurrentUser{ID: "teacher"})
+	s.users.AddStudentRelations(&dto.StudentRelations{StudentID: "student-1", GuardianIDs: []string{"guardian"}, TeacherIDs: []string{"teacher"}})
+
+	cases := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{"organization member", s.memberToken, http.StatusOK},
+		{"super admin", s.superAdminToken, http.StatusOK},
+		{"guardian from another organization", guardianToken, http.StatusOK},
+		{"teacher", teacherToken, http.StatusOK},
+		{"stranger", strangerToken, http.StatusForbidden},
+		{"other organization admin", s.orgBAdminToken, http.StatusForbidden},
+	}
+	for _, path := range []string{"/api/v1/terms/student/student-1", "/api/v1/admin/terms/student/student-1"} {
+		for _, tc := range cases {
+			t.Run(path+"/"+tc.name, func(t *testing.T) {
+				code, res := s.do(http.MethodGet, path, tc.token, nil)
+				if code != tc.want {
+					t.Fatalf("status = %d, want %d (body %+v)", code, tc.want, res)
+				}
+			})
+		}
+	}
+}
+
+func TestOrganizationTermsForAppsRequireMembership(t *testing.T) {
+	s := newTestServer(t)
+	s.seedTerm(&model.Term{OrganizationID: orgA, Title: "A1", Audiences: publish.Audiences{{Channel: publish.Mobile}, {Channel: publish.Teacher}}, StartDate: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC)})
+
+	cases := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{"organization member", s.memberToken, http.StatusOK},
+		{"super admin", s.superAdminToken, http.StatusOK},
+		{"other organization admin", s.orgBAdminToken, http.StatusForbidden},
+	}
+	for _, path := range []string{"/api/v1/terms?organization_id=" + orgA, "/api/v1/terms/organization/" + orgA} {
+		for _, tc := range cases {
+			t.Run(path+"/"+tc.name, func(t *testing.T) {
+				code, res := s.do(http.MethodGet, path, tc.token, nil)
+				if code != tc.want {
+					t.Fatalf("status = %d, want %d (body %+v)", code, tc.want, res)
+				}
+			})
+		}
+	}
+}
+
+func TestSuperAdminActsOnBehalfOfOrganization(t *testing.T) {
+	s := newTestServer(t)
+
+	body := uploadTermsBody(map[string]interface{}{"title": "Spring", "color": "#0f0", "published_desktop": true, "start_date": "2025-01-06", "end_date": "2025-03-28"})
+	body["organization_id"] = orgA
+	if code, res := s.do(http.MethodPost, "/api/v1/admin/terms", s.superAdminToken, body); code != http.StatusOK {
+		t.Fatalf("upload with body organization_id status = %d, body %+v", code, res)
+	}
+	if terms := s.orgTerms(orgA); len(terms) != 1 || terms[0].Title != "Spring" {
+		t.Fatalf("org A terms = %+v", terms)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/terms", bytes.NewReader(mustJSON(t, uploadTermsBody(
+		map[string]interface{}{"title": "Autumn", "color": "#f00", "start_date": "2025-09-01", "end_date": "2025-12-19"},
+	))))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.superAdminToken)
+	req.Header.Set("X-Organization-ID", orgB)
+	rec := httptest.NewRecorder()
+	s.engine.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("upload with header status = %d, body %s", rec.Code, rec.Body)
+	}
+	if terms := s.orgTerms(orgB); len(terms) != 1 || terms[0].Title != "Autumn" {
+		t.Fatalf("org B terms = %+v", terms)
+	}
+
+	code, res := s.do(http.MethodGet, "/api/v1/admin/terms/assign?organization_id="+orgA, s.superAdminToken, nil)
+	if code != http.StatusOK {
+		t.Fatalf("assign status = %d, body %+v", code, res)
+	}
+	var assign []struct {
+		Title string `json:"title"`
+	}
+	s.decode(res, &assign)
+	if len(assign) != 1 {
+		t.Fatalf("assignable terms = %+v, want 1", assign)
+	}
+
+	if code, _ := s.do(http.MethodGet, "/api/v1/admin/terms/assign", s.superAdminToken, nil); code != http.StatusForbidden {
+		t.Fatalf("assign without organization status = %d, want %d", code, http.StatusForbidden)
+	}
+}
+
+func TestOrganizationAdminCannotTargetAnotherOrganization(t *testing.T) {
+	s := newTestServer(t)
+
+	body := uploadTermsBody(map[string]interface{}{"title": "Spring", "color": "#0f0", "start_date": "2025-01-06", "end_date": "2025-03-28"})
+	body["organization_id"] = orgA
+	if code, res := s.do(http.MethodPost, "/api/v1/admin/terms", s.orgBAdminToken, body); code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d (body %+v)", code, http.StatusForbidden, res)
+	}
+	if code, _ := s.do(http.MethodGet, "/api/v1/admin/holidays?organization_id="+orgA, s.orgBAdminToken, nil); code != http.StatusForbidden {
+		t.Fatalf("holidays status = %d, want %d", code, http.StatusForbidden)
+	}
+	if terms := s.orgTerms(orgA); len(terms) != 0 {
+		t.Fatalf("org A terms = %+v", terms)
+	}
+}
+
+func mustJSON(t *testing.T, v interface{}) []byte {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return b
+}
@@ -0,0 +1,203 @@
+package router_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	webhook_service "term-service/internal/webhook/service"
+	"term-service/pkg/router"
+)
+
+type subscriptionRes struct {
+	ID         string   `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Active     bool     `json:"active"`
+	Secret     string   `json:"secret"`
+}
+
+type deliveryRes struct {
+	EventType      string `json:"event_type"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	ResponseStatus int    `json:"response_status"`
+}
+
+func (s *testServer) createSubscription(token string, body map[string]interface{}) subscriptionRes {
+	s.t.Helper()
+	code, res := s.do(http.MethodPost, "/api/v1/admin/webhooks", token, body)
+	if code != http.StatusCreated {
+		s.t.Fatalf("create subscription status = %d, body %+v", code, res)
+	}
+	var sub subscriptionRes
+	s.decode(res, &sub)
+	return sub
+}
+
+func (s *testServer) deliveries(token, subscriptionID string) []deliveryRes {
+	s.t.Helper()
+	code, res := s.do(http.MethodGet, "/api/v1/admin/webhooks/"+subscriptionID+"/deliveries", token, nil)
+	if code != http.StatusOK {
+		s.t.Fatalf("deliveries status = %d, body %+v", code, res)
+	}
+	var log []deliveryRes
+	s.decode(res, &log)
+	return log
+}
+
+func TestWebhookSubscriptionLifecycle(t *testing.T) {
+	s := newTestServer(t)
+
+	sub := s.createSubscription(s.orgAdminToken, map[string]interface{}{
+		"url":         "https://partner.example/hooks",
+		"event_types": []string{"term.created"},
+	})
+	if sub.ID == "" || !sub.Active || len(sub.Secret) != 64 {
+		t.Fatalf("created subscription = %+v, want active with a generated secret", sub)
+	}
+
+	code, res := s.do(http.MethodGet, "/api/v1/admin/webhooks/"+sub.ID, s.orgAdminToken, nil)
+	var got subscriptionRes
+	s.decode(res, &got)
+	if code != http.StatusOK || got.Secret != "" || got.EventTypes[0] != "term.created" {
+		t.Fatalf("get = %d %+v, want the subscription without its secret", code, got)
+	}
+
+	// subscriptions are invisible to other organizations
+	for _, path := range []string{"", "/deliveries"} {
+		if code, _ := s.do(http.MethodGet, "/api/v1/admin/webhooks/"+sub.ID+path, s.orgBAdminToken, nil); code != http.StatusNotFound {
+			t.Fatalf("other org GET %s status = %d, want 404", path, code)
+		}
+	}
+	if code, _ := s.do(http.MethodGet, "/api/v1/admin/webhooks", s.memberToken, nil); code != http.StatusForbidden {
+		t.Fatalf("member list status = %d, want 403", code)
+	}
+
+	code, res = s.do(http.MethodPut, "/api/v1/admin/webhooks/"+sub.ID, s.orgAdminToken, map[string]interface{}{
+		"url":    "https://partner.example/v2/hooks",
+		"active": false,
+	})
+	s.decode(res, &got)
+	if code != http.StatusOK || got.Active || got.URL != "https://partner.example/v2/hooks" || len(got.EventTypes) != 0 {
+		t.Fatalf("update = %d %+v", code, got)
+	}
+
+	if code, _ := s.do(http.MethodDelete, "/api/v1/admin/webhooks/"+sub.ID, s.orgAdminToken, nil); code != http.StatusOK {
+		t.Fatalf("delete status = %d", code)
+	}
+	if code, _ := s.do(http.MethodGet, "/api/v1/admin/webhooks/"+sub.ID, s.orgAdminToken, nil); code != http.StatusNotFound {
+		t.Fatalf("get after delete status = %d, want 404", code)
+	}
+}
+
+func TestWebhookSubscriptionValidation(t *testing.T) {
+	s := newTestServer(t)
+
+	for name, body := range map[string]map[string]interface{}{
+		"missing url":   {},
+		"not http":      {"url": "ftp://partner.example/hooks"},
+		"unknown event": {"url": "https://partner.example", "event_types": []string{"term.exploded"}},
+		"short secret":  {"url": "https://partner.example", "secret": "short"},
+	} {
+		if code, res := s.do(http.MethodPost, "/api/v1/admin/webhooks", s.orgAdminToken, body); code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400 (body %+v)", name, code, res)
+		}
+	}
+}
+
+func TestWebhookSubscriptionRejectsInternalDestinations(t *testing.T) {
+	hosts := map[string]string{
+		"partner.example":  "93.184.216.34",
+		"intranet.example": "10.0.0.5",
+	}
+	s := newTestServer(t, func(d *router.Dependencies) {
+		d.WebhookDestinations = webhook_service.Destinations{
+			LookupIP: func(ctx context.Context, network, host string) ([]net.IP, error) {
+				if addr, ok := hosts[host]; ok {
+					return []net.IP{net.ParseIP(addr)}, nil
+				}
+				return nil, errors.New("no such host")
+			},
+		}
+	})
+
+	for _, url := range []string{
+		"http://partner.example/hooks",
+		"https://127.0.0.1/hooks",
+		"https://[::1]/hooks",
+		"https://169.254.169.254/latest/meta-data",
+		"https://192.168.1.10:8500/v1/agent/self",
+		"https://0.0.0.0/hooks",
+		"https://intranet.example/hooks",
+		"https://unknown.example/hooks",
+	} {
+		if code, res := s.do(http.MethodPost, "/api/v1/admin/webhooks", s.orgAdminToken, map[string]interface{}{"url": url}); code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400 (body %+v)", url, code, res)
+		}
+	}
+
+	sub := s.createSubscription(s.orgAdminToken, map[string]interface{}{"url": "https://partner.example/hooks"})
+	if code, res := s.do(http.MethodPut, "/api/v1/admin/webhooks/"+sub.ID, s.orgAdminToken, map[string]interface{}{"url": "https://intranet.example/hooks"}); code != http.StatusBadRequest {
+		t.Fatalf("update to an internal host status = %d, want 400 (body %+v)", code, res)
+	}
+}
+
+func TestTermUploadQueuesWebhookDelivery(t *testing.T) {
+	s := newTestServer(t)
+	wanted := s.createSubscription(s.orgAdminToken, map[string]interface{}{"url": "https://partner.example/hooks", "event_types": []string{"term.created"}})
+	other := s.createSubscription(s.orgAdminToken, map[string]interface{}{"url": "https://partner.example/hooks", "event_types": []string{"holiday.created"}})
+	otherOrg := s.createSubscription(s.orgBAdminToken, map[string]interface{}{"url": "https://partner.example/hooks"})
+
+	if code, res := s.do(http.MethodPost, "/api/v1/admin/terms", s.orgAdminToken, uploadTermsBody(
+		map[string]interface{}{"title": "Spring", "color": "#0f0", "start_date": "2025-01-06", "end_date": "2025-03-28"},
+	)); code != http.StatusOK {
+		t.Fatalf("upload status = %d, body %+v", code, res)
+	}
+
+	if log := s.deliveries(s.orgAdminToken, wanted.ID); len(log) != 1 || log[0].EventType != "term.created" || log[0].Status != "pending" {
+		t.Fatalf("subscribed delivery log = %+v, want one pending term.created", log)
+	}
+	if log := s.deliveries(s.orgAdminToken, other.ID); len(log) != 0 {
+		t.Fatalf("holiday-only subscription got %+v", log)
+	}
+	if log := s.deliveries(s.orgBAdminToken, otherOrg.ID); len(log) != 0 {
+		t.Fatalf("other organization's subscription got %+v", log)
+	}
+}
+
+func TestWebhookPing(t *testing.T) {
+	s := newTestServer(t)
+
+	var gotEvent, gotSignature string
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotEvent, gotSignature = r.Header.Get("X-Webhook-Event"), r.Header.Get("X-Webhook-Signature")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	sub := s.createSubscription(s.orgAdminToken, map[string]interface{}{"url": receiver.URL, "active": false})
+
+	code, res := s.do(http.MethodPost, "/api/v1/admin/webhooks/"+sub.ID+"/ping", s.orgAdminToken, nil)
+	var ping deliveryRes
+	s.decode(res, &ping)
+	if code != http.StatusOK || ping.Status != "succeeded" || ping.ResponseStatus != http.StatusNoContent || ping.Attempts != 1 {
+		t.Fatalf("ping = %d %+v", code, ping)
+	}
+	if gotEvent != "ping" || gotSignature == "" {
+		t.Fatalf("receiver got event %q signature %q", gotEvent, gotSignature)
+	}
+	if log := s.deliveries(s.orgAdminToken, sub.ID); len(log) != 1 || log[0].EventType != "ping" {
+		t.Fatalf("delivery log = %+v, want the ping", log)
+	}
+
+	receiver.Close()
+	code, res = s.do(http.MethodPost, "/api/v1/admin/webhooks/"+sub.ID+"/ping", s.orgAdminToken, nil)
+	s.decode(res, &ping)
+	if code != http.StatusOK || ping.Status != "failed" {
+		t.Fatalf("ping to a closed receiver = %d %+v, want a failed delivery", code, ping)
+	}
+}
@@ -0,0 +1,117 @@
+// Package servicetoken issues and verifies the short-lived HS256 tokens that
+// internal services present to each other instead of end-user tokens.
+package servicetoken
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Audience is the audience term-service expects on incoming service tokens.
+const Audience = "term-service"
+
+var (
+	ErrMissingSecret = errors.New("service token secret is not configured")
+	ErrInvalidToken  = errors.New("invalid service token")
+)
+
+// Claims identifies the calling service in Issuer/Subject.
+type Claims struct {
+	jwt.RegisteredClaims
+}
+
+// Caller returns the name of the service that issued the token.
+func (c *Claims) Caller() string {
+	return c.Issuer
+}
+
+type Signer interface {
+	// Sign issues a token for calling the audience service.
+	Sign(audience string) (string, error)
+}
+
+type Verifier interface {
+	// Verify checks signature, expiry, audience and caller of token.
+	Verify(token string) (*Claims, error)
+}
+
+type signer struct {
+	secret []byte
+	issuer string
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+func NewSigner(secret, issuer string, ttl time.Duration) Signer {
+	return &signer{secret: []byte(secret), issuer: issuer, ttl: ttl, now: time.Now}
+}
+
+func (s *signer) Sign(audience string) (string, error) {
+	if len(s.secret) == 0 {
+		return "", ErrMissingSecret
+	}
+
+	now := s.now()
+	claims := Claims{RegisteredClaims: jwt.RegisteredClaims{
+		Issuer:    s.issuer,
+		Subject:   s.issuer,
+		Audience:  jwt.ClaimStrings{audience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(s.ttl)),
+	}}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+}
+
+type verifier struct {
+	secret  []byte
+	callers map[string]struct{}
+	parser  *jwt.Parser
+}
+
+// NewVerifier accepts tokens for Audience signed with secret. When
+// allowedCallers is not empty, only those issuers are accepted. An empty
+// secret rejects every token.
+func NewVerifier(secret string, allowedCallers []string) Verifier {
+	callers := make(map[string]struct{}, len(allowedCallers))
+	for _, c := range allowedCallers {
+		callers[c] = struct{}{}
+	}
+
+	return &verifier{
+		secret:  []byte(secret),
+		callers: callers,
+		parser: jwt.NewParser(
+			jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+			jwt.WithAudience(Audience),
+			jwt.WithExpirationRequired(),
+			jwt.WithLeeway(30*time.Second),
+		),
+	}
+}
+
+func (v *verifier) Verify(token string) (*Claims, error) {
+	if len(v.secret) == 0 {
+		return nil, ErrMissingSecret
+	}
+
+	claims := &Claims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return v.secret, nil
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if claims.Issuer == "" {
+		return nil, fmt.Errorf("%w: missing issuer", ErrInvalidToken)
+	}
+	if len(v.callers) > 0 {
+		if _, ok := v.callers[claims.Issuer]; !ok {
+			return nil, fmt.Errorf("%w: caller %q is not allowed", ErrInvalidToken, claims.Issuer)
+		}
+	}
+	return claims, nil
+}
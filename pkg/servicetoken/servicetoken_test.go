@@ -0,0 +1,65 @@
+package servicetoken
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const secret = "0123456789abcdef0123456789abcdef"
+
+func TestSignAndVerify(t *testing.T) {
+	token, err := NewSigner(secret, "attendance-service", time.Minute).Sign(Audience)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	claims, err := NewVerifier(secret, []string{"attendance-service"}).Verify(token)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if claims.Caller() != "attendance-service" {
+		t.Fatalf("Caller = %q", claims.Caller())
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	valid := func(s Signer, aud string) string {
+		t.Helper()
+		tok, err := s.Sign(aud)
+		if err != nil {
+			t.Fatalf("Sign: %v", err)
+		}
+		return tok
+	}
+	expired := &signer{secret: []byte(secret), issuer: "attendance-service", ttl: time.Minute, now: func() time.Time { return time.Now().Add(-time.Hour) }}
+	userToken, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"user_id": "u1"}).SignedString([]byte("user-secret"))
+
+	cases := []struct {
+		name     string
+		verifier Verifier
+		token    string
+		want     error
+	}{
+		{"wrong secret", NewVerifier(secret, nil), valid(NewSigner("another-secret-another-secret-xx", "attendance-service", time.Minute), Audience), ErrInvalidToken},
+		{"wrong audience", NewVerifier(secret, nil), valid(NewSigner(secret, "attendance-service", time.Minute), "go-main-service"), ErrInvalidToken},
+		{"expired", NewVerifier(secret, nil), valid(expired, Audience), ErrInvalidToken},
+		{"caller not allowed", NewVerifier(secret, []string{"report-service"}), valid(NewSigner(secret, "attendance-service", time.Minute), Audience), ErrInvalidToken},
+		{"end-user token", NewVerifier(secret, nil), userToken, ErrInvalidToken},
+		{"garbage", NewVerifier(secret, nil), "not-a-token", ErrInvalidToken},
+		{"no secret configured", NewVerifier("", nil), valid(NewSigner(secret, "attendance-service", time.Minute), Audience), ErrMissingSecret},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.verifier.Verify(tc.token); !errors.Is(err, tc.want) {
+				t.Fatalf("Verify = %v, want %v", err, tc.want)
+			}
+		})
+	}
+
+	if _, err := NewSigner("", "term-service", time.Minute).Sign(Audience); !errors.Is(err, ErrMissingSecret) {
+		t.Fatalf("Sign without secret = %v, want ErrMissingSecret", err)
+	}
+}
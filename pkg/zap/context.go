@@ -0,0 +1,62 @@
+package zap
+
+import (
+	"context"
+	"sync"
+	"term-service/pkg/constants"
+
+	"go.uber.org/zap"
+)
+
+var (
+	globalMu sync.RWMutex
+	global   Logger = newFallback()
+)
+
+func newFallback() Logger {
+	l, err := zap.NewProduction()
+	if err != nil {
+		l = zap.NewNop()
+	}
+	return &appLogger{logger: l, sugarLogger: l.Sugar()}
+}
+
+// NewNop returns a Logger that discards everything, for tests.
+func NewNop() Logger {
+	l := zap.NewNop()
+	return &appLogger{logger: l, sugarLogger: l.Sugar()}
+}
+
+// SetGlobal replaces the logger returned by L and FromContext.
+func SetGlobal(l Logger) {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+	global = l
+}
+
+// L returns the process-wide logger.
+func L() Logger {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+	return global
+}
+
+// FromContext returns the process-wide logger annotated with the request id,
+// user id and organization id carried by ctx.
+func FromContext(ctx context.Context) Logger {
+	l := L()
+	if ctx == nil {
+		return l
+	}
+
+	var fields []interface{}
+	for _, key := range []constants.ContextKey{constants.RequestID, constants.UserID, constants.OrganizationID} {
+		if v, ok := ctx.Value(key).(string); ok && v != "" {
+			fields = append(fields, key.String(), v)
+		}
+	}
+	if len(fields) == 0 {
+		return l
+	}
+	return l.With(fields...)
+}
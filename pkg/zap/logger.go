@@ -31,6 +31,11 @@ type Logger interface {
 	Fatal(args ...interface{})
 	Fatalf(template string, args ...interface{})
 	Printf(template string, args ...interface{})
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+	With(keysAndValues ...interface{}) Logger
 	WithName(name string)
 	HttpMiddlewareAccessLogger(method string, uri string, status int, size int64, time time.Duration)
 	GrpcMiddlewareAccessLogger(method string, time time.Duration, metaData map[string][]string, err error)
@@ -134,6 +139,37 @@ func (l *appLogger) WithName(name string) {
 	l.sugarLogger = l.sugarLogger.Named(name)
 }
 
+// With returns a child logger carrying the given key/value pairs on every entry.
+func (l *appLogger) With(keysAndValues ...interface{}) Logger {
+	sugar := l.sugarLogger.With(keysAndValues...)
+	return &appLogger{
+		level:       l.level,
+		devMode:     l.devMode,
+		sugarLogger: sugar,
+		logger:      sugar.Desugar(),
+	}
+}
+
+// Debugw logs a message with additional key/value pairs.
+func (l *appLogger) Debugw(msg string, keysAndValues ...interface{}) {
+	l.sugarLogger.Debugw(msg, keysAndValues...)
+}
+
+// Infow logs a message with additional key/value pairs.
+func (l *appLogger) Infow(msg string, keysAndValues ...interface{}) {
+	l.sugarLogger.Infow(msg, keysAndValues...)
+}
+
+// Warnw logs a message with additional key/value pairs.
+func (l *appLogger) Warnw(msg string, keysAndValues ...interface{}) {
+	l.sugarLogger.Warnw(msg, keysAndValues...)
+}
+
+// Errorw logs a message with additional key/value pairs.
+func (l *appLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	l.sugarLogger.Errorw(msg, keysAndValues...)
+}
+
 // Debug uses fmt.Sprint to construct and log a message.
 func (l *appLogger) Debug(args ...interface{}) {
 	l.sugarLogger.Debug(args...)
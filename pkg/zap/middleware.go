@@ -0,0 +1,67 @@
+package zap
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"term-service/pkg/constants"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware reuses the caller's X-Request-ID or generates one, and
+// stores it in both gin and request contexts so FromContext and outgoing
+// gateway calls pick it up.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := strings.TrimSpace(c.GetHeader(constants.RequestIDHeader))
+		if id == "" || len(id) > maxRequestIDLength {
+			id = newRequestID()
+		}
+
+		c.Set(constants.RequestID.String(), id)
+		ctx := context.WithValue(c.Request.Context(), constants.RequestID, id)
+		c.Request = c.Request.WithContext(ctx)
+		c.Writer.Header().Set(constants.RequestIDHeader, id)
+
+		c.Next()
+	}
+}
+
+// AccessLogMiddleware writes one access log entry per request, skipping the
+// paths listed in ignoreURLs (health probes, metrics scrapes).
+func AccessLogMiddleware(ignoreURLs []string) gin.HandlerFunc {
+	ignored := make(map[string]struct{}, len(ignoreURLs))
+	for _, u := range ignoreURLs {
+		ignored[u] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		if _, skip := ignored[c.Request.URL.Path]; skip {
+			return
+		}
+
+		FromContext(c.Request.Context()).HttpMiddlewareAccessLogger(
+			c.Request.Method,
+			c.Request.URL.RequestURI(),
+			c.Writer.Status(),
+			int64(c.Writer.Size()),
+			time.Since(start),
+		)
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().UTC().Format("20060102150405.000000000")
+	}
+	return hex.EncodeToString(b)
+}